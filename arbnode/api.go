@@ -9,7 +9,10 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/rawdb"
 
+	"github.com/offchainlabs/nitro/arbnode/dataposter"
+	"github.com/offchainlabs/nitro/arbstate"
 	"github.com/offchainlabs/nitro/arbutil"
+	"github.com/offchainlabs/nitro/daprovider"
 	"github.com/offchainlabs/nitro/staker"
 	"github.com/offchainlabs/nitro/validator"
 	"github.com/offchainlabs/nitro/validator/server_api"
@@ -58,3 +61,96 @@ func (a *BlockValidatorDebugAPI) ValidationInputsAt(ctx context.Context, msgNum
 ) (server_api.InputJSON, error) {
 	return a.val.ValidationInputsAt(ctx, arbutil.MessageIndex(msgNum), target)
 }
+
+// DataPosterAPI allows operators to cancel queued batch poster transactions
+// and to repair nonce gaps of its queue.
+type DataPosterAPI struct {
+	dataPoster *dataposter.DataPoster
+}
+
+// CancelNonce replaces the queued transaction with the given nonce by a zero
+// value transfer, and returns the hash of the replacement.
+func (a *DataPosterAPI) CancelNonce(ctx context.Context, nonce hexutil.Uint64) (common.Hash, error) {
+	tx, err := a.dataPoster.CancelNonce(ctx, uint64(nonce))
+	if tx == nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), err
+}
+
+func (a *DataPosterAPI) NonceGaps(ctx context.Context) ([]hexutil.Uint64, error) {
+	gaps, err := a.dataPoster.NonceGaps(ctx)
+	return toHexNonces(gaps), err
+}
+
+func (a *DataPosterAPI) RepairNonceGaps(ctx context.Context) ([]hexutil.Uint64, error) {
+	filled, err := a.dataPoster.RepairNonceGaps(ctx)
+	return toHexNonces(filled), err
+}
+
+func toHexNonces(nonces []uint64) []hexutil.Uint64 {
+	res := make([]hexutil.Uint64, 0, len(nonces))
+	for _, nonce := range nonces {
+		res = append(res, hexutil.Uint64(nonce))
+	}
+	return res
+}
+
+// InboxAPI decodes the sequencer batches read by the inbox reader.
+type InboxAPI struct {
+	inboxReader *InboxReader
+}
+
+type BatchContents struct {
+	BatchNum             hexutil.Uint64           `json:"batchNum"`
+	ParentChainBlock     hexutil.Uint64           `json:"parentChainBlock"`
+	ParentChainBlockHash common.Hash              `json:"parentChainBlockHash"`
+	MinTimestamp         hexutil.Uint64           `json:"minTimestamp"`
+	MaxTimestamp         hexutil.Uint64           `json:"maxTimestamp"`
+	MinL1Block           hexutil.Uint64           `json:"minL1Block"`
+	MaxL1Block           hexutil.Uint64           `json:"maxL1Block"`
+	FirstDelayedMessage  hexutil.Uint64           `json:"firstDelayedMessage"`
+	AfterDelayedMessages hexutil.Uint64           `json:"afterDelayedMessages"`
+	FirstMessage         hexutil.Uint64           `json:"firstMessage"`
+	MessageCount         hexutil.Uint64           `json:"messageCount"`
+	Messages             []*arbstate.BatchMessage `json:"messages"`
+}
+
+// GetBatchContents fetches the sequencer batch from the parent chain, resolves its
+// payload through the configured data availability readers, and decodes its messages.
+func (a *InboxAPI) GetBatchContents(ctx context.Context, batchNum hexutil.Uint64) (*BatchContents, error) {
+	tracker := a.inboxReader.Tracker()
+	metadata, err := tracker.GetBatchMetadata(uint64(batchNum))
+	if err != nil {
+		return nil, err
+	}
+	var prevMetadata BatchMetadata
+	if batchNum > 0 {
+		prevMetadata, err = tracker.GetBatchMetadata(uint64(batchNum) - 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+	data, blockHash, err := a.inboxReader.GetSequencerMessageBytes(ctx, uint64(batchNum))
+	if err != nil {
+		return nil, err
+	}
+	seqMsg, err := arbstate.ParseSequencerMessage(ctx, uint64(batchNum), blockHash, data, tracker.dapReaders, daprovider.KeysetValidate)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchContents{
+		BatchNum:             batchNum,
+		ParentChainBlock:     hexutil.Uint64(metadata.ParentChainBlock),
+		ParentChainBlockHash: blockHash,
+		MinTimestamp:         hexutil.Uint64(seqMsg.MinTimestamp),
+		MaxTimestamp:         hexutil.Uint64(seqMsg.MaxTimestamp),
+		MinL1Block:           hexutil.Uint64(seqMsg.MinL1Block),
+		MaxL1Block:           hexutil.Uint64(seqMsg.MaxL1Block),
+		FirstDelayedMessage:  hexutil.Uint64(prevMetadata.DelayedMessageCount),
+		AfterDelayedMessages: hexutil.Uint64(seqMsg.AfterDelayedMessages),
+		FirstMessage:         hexutil.Uint64(prevMetadata.MessageCount),
+		MessageCount:         hexutil.Uint64(metadata.MessageCount - prevMetadata.MessageCount),
+		Messages:             arbstate.DecodeBatchMessages(seqMsg, prevMetadata.DelayedMessageCount),
+	}, nil
+}
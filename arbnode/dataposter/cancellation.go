@@ -0,0 +1,295 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package dataposter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/offchainlabs/nitro/arbnode/dataposter/storage"
+	"github.com/offchainlabs/nitro/util/arbmath"
+	"github.com/offchainlabs/nitro/util/blobs"
+)
+
+var (
+	ErrNonceNotQueued   = errors.New("nonce isn't queued in the data poster")
+	ErrNonceConfirmed   = errors.New("nonce is already included in the parent chain")
+	ErrQueueUnsupported = errors.New("data poster is using noop storage, which doesn't keep a queue")
+)
+
+// cancellationTx creates a zero value transfer from the sender to itself with
+// the given nonce. If prevTx is set, the transfer is priced to replace it.
+// The mutex must be held by the caller.
+func (p *DataPoster) cancellationTx(ctx context.Context, nonce uint64, prevTx *types.Transaction) (*types.Transaction, types.DynamicFeeTx, error) {
+	var deprecatedData types.DynamicFeeTx
+	if err := p.updateBalance(ctx); err != nil {
+		return nil, deprecatedData, fmt.Errorf("failed to update data poster balance: %w", err)
+	}
+	latestHeader, err := p.headerReader.LastHeader(ctx)
+	if err != nil {
+		return nil, deprecatedData, err
+	}
+	var numBlobs uint64
+	minRbfIncrease := minNonBlobRbfIncrease
+	if prevTx != nil && len(prevTx.BlobHashes()) > 0 {
+		// Parent chain mempools don't allow replacing a blob transaction by a non-blob one.
+		numBlobs = 1
+		minRbfIncrease = minBlobRbfIncrease
+	}
+	feeCap, tipCap, blobFeeCap, err := p.feeAndTipCaps(ctx, nonce, params.TxGas, numBlobs, prevTx, time.Now(), 0, latestHeader)
+	if err != nil {
+		return nil, deprecatedData, err
+	}
+	if prevTx != nil {
+		// feeAndTipCaps may hold off on replacing by fee, but a cancellation must replace prevTx right away.
+		feeCap = arbmath.BigMax(feeCap, arbmath.BigMulByBips(prevTx.GasFeeCap(), minRbfIncrease))
+		tipCap = arbmath.BigMax(tipCap, arbmath.BigMulByBips(prevTx.GasTipCap(), minRbfIncrease))
+		if prevTx.BlobGasFeeCap() != nil {
+			blobFeeCap = arbmath.BigMax(blobFeeCap, arbmath.BigMulByBips(prevTx.BlobGasFeeCap(), minRbfIncrease))
+		}
+	}
+	tipCap = arbmath.BigMin(tipCap, feeCap)
+
+	sender := p.Sender()
+	var inner types.TxData
+	if numBlobs > 0 {
+		// Intentionally break out of date data poster redis clients,
+		// so they don't try to replace by fee a tx they don't understand
+		deprecatedData.Nonce = ^uint64(0)
+		kzgBlobs := make([]kzg4844.Blob, numBlobs)
+		commitments, blobHashes, err := blobs.ComputeCommitmentsAndHashes(kzgBlobs)
+		if err != nil {
+			return nil, deprecatedData, fmt.Errorf("failed to compute KZG commitments: %w", err)
+		}
+		proofs, err := blobs.ComputeBlobProofs(kzgBlobs, commitments)
+		if err != nil {
+			return nil, deprecatedData, fmt.Errorf("failed to compute KZG proofs: %w", err)
+		}
+		inner = &types.BlobTx{
+			Nonce: nonce,
+			Gas:   params.TxGas,
+			To:    sender,
+			Value: uint256.NewInt(0),
+			Sidecar: &types.BlobTxSidecar{
+				Blobs:       kzgBlobs,
+				Commitments: commitments,
+				Proofs:      proofs,
+			},
+			BlobHashes: blobHashes,
+			ChainID:    p.parentChainID256,
+		}
+		if err := updateTxDataGasCaps(inner, feeCap, tipCap, blobFeeCap); err != nil {
+			return nil, deprecatedData, err
+		}
+	} else {
+		deprecatedData = types.DynamicFeeTx{
+			Nonce:     nonce,
+			GasFeeCap: feeCap,
+			GasTipCap: tipCap,
+			Gas:       params.TxGas,
+			To:        &sender,
+			Value:     big.NewInt(0),
+			ChainID:   p.parentChainID,
+		}
+		inner = &deprecatedData
+	}
+	fullTx, err := p.signer(ctx, sender, types.NewTx(inner))
+	if err != nil {
+		return nil, deprecatedData, fmt.Errorf("signing transaction: %w", err)
+	}
+	return fullTx, deprecatedData, nil
+}
+
+func (p *DataPoster) firstReplacementTime(tx *types.Transaction) time.Duration {
+	if len(tx.BlobHashes()) > 0 {
+		return p.config().BlobTxReplacementTimes[0]
+	}
+	return p.config().ReplacementTimes[0]
+}
+
+// CancelNonce replaces the queued transaction with the given nonce by a zero
+// value transfer to the sender, priced to replace the original transaction.
+// The queue entry is kept and marked as cancelled, so the data poster keeps
+// replacing it by fee until it's confirmed. Transactions queued after it will
+// likely revert once it's confirmed, so they should be cancelled first.
+// An error is returned if the cancellation couldn't be sent, but if it was
+// already queued the data poster will keep trying to send it.
+func (p *DataPoster) CancelNonce(ctx context.Context, nonce uint64) (*types.Transaction, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.usingNoOpStorage {
+		return nil, ErrQueueUnsupported
+	}
+	prevTx, err := p.queue.Get(ctx, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if prevTx == nil {
+		return nil, fmt.Errorf("%w: %d", ErrNonceNotQueued, nonce)
+	}
+	unconfirmedNonce, err := p.client.NonceAt(ctx, p.Sender(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting nonce of a dataposter sender: %w", err)
+	}
+	if nonce < unconfirmedNonce {
+		return nil, fmt.Errorf("%w: nonce %d, latest nonce %d", ErrNonceConfirmed, nonce, unconfirmedNonce)
+	}
+	if prevTx.Cancelled {
+		log.Info("DataPoster transaction is already cancelled", "nonce", nonce, "hash", prevTx.FullTx.Hash())
+		return prevTx.FullTx, nil
+	}
+	fullTx, deprecatedData, err := p.cancellationTx(ctx, nonce, prevTx.FullTx)
+	if err != nil {
+		return nil, err
+	}
+	cumulativeWeight := prevTx.CumulativeWeight()
+	newTx := *prevTx
+	newTx.FullTx = fullTx
+	newTx.DeprecatedData = deprecatedData
+	newTx.Sent = false
+	newTx.NextReplacement = time.Now().Add(p.firstReplacementTime(fullTx))
+	newTx.StoredCumulativeWeight = &cumulativeWeight
+	newTx.Cancelled = true
+	// The next transaction continues from the metadata of the last queued one,
+	// which mustn't include the cancelled data anymore.
+	if nonce > 0 {
+		precedingTx, err := p.queue.Get(ctx, nonce-1)
+		if err != nil {
+			return nil, err
+		}
+		if precedingTx != nil {
+			newTx.Meta = precedingTx.Meta
+		} else {
+			log.Warn("DataPoster cancelling transaction without preceding queued transaction, keeping its metadata", "nonce", nonce)
+		}
+	}
+	log.Info("DataPoster cancelling transaction", "nonce", nonce, "cancelledHash", prevTx.FullTx.Hash(), "hash", fullTx.Hash())
+	return fullTx, p.sendTx(ctx, prevTx, &newTx)
+}
+
+// The mutex must be held by the caller.
+func (p *DataPoster) nonceGaps(ctx context.Context) ([]uint64, error) {
+	lastQueued, err := p.queue.FetchLast(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching last element from queue: %w", err)
+	}
+	if lastQueued == nil {
+		return nil, nil
+	}
+	unconfirmedNonce, err := p.client.NonceAt(ctx, p.Sender(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting nonce of a dataposter sender: %w", err)
+	}
+	lastNonce := lastQueued.FullTx.Nonce()
+	if unconfirmedNonce > lastNonce {
+		return nil, nil
+	}
+	contents, err := p.queue.FetchContents(ctx, unconfirmedNonce, lastNonce-unconfirmedNonce+1)
+	if err != nil {
+		return nil, fmt.Errorf("fetching queue contents: %w", err)
+	}
+	var gaps []uint64
+	next := unconfirmedNonce
+	for _, tx := range contents {
+		for ; next < tx.FullTx.Nonce(); next++ {
+			gaps = append(gaps, next)
+		}
+		next = tx.FullTx.Nonce() + 1
+	}
+	return gaps, nil
+}
+
+// NonceGaps returns the nonces between the latest nonce of the sender and the
+// last queued transaction that have no queued transaction, e.g. because they
+// were used by transactions sent outside of the data poster which got dropped.
+// Queued transactions after a gap can't be included until it's filled.
+func (p *DataPoster) NonceGaps(ctx context.Context) ([]uint64, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.usingNoOpStorage {
+		return nil, ErrQueueUnsupported
+	}
+	return p.nonceGaps(ctx)
+}
+
+// RepairNonceGaps fills the nonce gaps of the queue with cancelled
+// transactions and returns the nonces that were filled.
+func (p *DataPoster) RepairNonceGaps(ctx context.Context) ([]uint64, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.usingNoOpStorage {
+		return nil, ErrQueueUnsupported
+	}
+	return p.repairNonceGaps(ctx)
+}
+
+// The mutex must be held by the caller.
+func (p *DataPoster) repairNonceGaps(ctx context.Context) ([]uint64, error) {
+	gaps, err := p.nonceGaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i, nonce := range gaps {
+		fullTx, deprecatedData, err := p.cancellationTx(ctx, nonce, nil)
+		if err != nil {
+			return gaps[:i], err
+		}
+		queuedTx := storage.QueuedTransaction{
+			FullTx:          fullTx,
+			DeprecatedData:  deprecatedData,
+			Created:         time.Now(),
+			NextReplacement: time.Now().Add(p.firstReplacementTime(fullTx)),
+			Cancelled:       true,
+		}
+		if nonce > 0 {
+			precedingTx, err := p.queue.Get(ctx, nonce-1)
+			if err != nil {
+				return gaps[:i], err
+			}
+			if precedingTx != nil {
+				cumulativeWeight := precedingTx.CumulativeWeight()
+				queuedTx.Meta = precedingTx.Meta
+				queuedTx.StoredCumulativeWeight = &cumulativeWeight
+			}
+		}
+		log.Info("DataPoster filling nonce gap", "nonce", nonce, "hash", fullTx.Hash())
+		if err := p.sendTx(ctx, nil, &queuedTx); err != nil {
+			return gaps[:i], err
+		}
+	}
+	return gaps, nil
+}
+
+// checkNonceGaps logs the nonce gaps of the queue, and repairs them if enabled.
+// The mutex must be held by the caller.
+func (p *DataPoster) checkNonceGaps(ctx context.Context) error {
+	if p.usingNoOpStorage {
+		return nil
+	}
+	if !p.config().RepairNonceGaps {
+		gaps, err := p.nonceGaps(ctx)
+		if err != nil {
+			return err
+		}
+		if len(gaps) > 0 {
+			log.Warn("DataPoster queue has nonce gaps, queued transactions after them can't be included until they're filled", "gaps", gaps)
+		}
+		return nil
+	}
+	filled, err := p.repairNonceGaps(ctx)
+	if len(filled) > 0 {
+		log.Warn("DataPoster filled nonce gaps of its queue with cancelled transactions", "nonces", filled)
+	}
+	return err
+}
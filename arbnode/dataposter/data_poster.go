@@ -11,7 +11,6 @@ import (
 	"crypto/x509"
 	"errors"
 	"fmt"
-	"math"
 	"math/big"
 	"net/http"
 	"os"
@@ -19,7 +18,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/Knetic/govaluate"
 	"github.com/holiman/uint256"
 	"github.com/redis/go-redis/v9"
 	"github.com/spf13/pflag"
@@ -96,7 +94,7 @@ type DataPoster struct {
 	queue      QueueStorage
 	errorCount map[uint64]int // number of consecutive intermittent errors rbf-ing or sending, per nonce
 
-	maxFeeCapExpression *govaluate.EvaluableExpression
+	feeStrategy FeeStrategy
 }
 
 // signerFn is a signer function callback when a contract requires a method to
@@ -114,6 +112,9 @@ type DataPosterOpts struct {
 	ExtraBacklog      func() uint64
 	RedisKey          string // Redis storage key
 	ParentChainID     *big.Int
+	// FeeStrategy prices the posted transactions, if nil a FormulaFeeStrategy
+	// using MaxFeeCapFormula is used.
+	FeeStrategy FeeStrategy
 }
 
 func NewDataPoster(ctx context.Context, opts *DataPosterOpts) (*DataPoster, error) {
@@ -150,9 +151,13 @@ func NewDataPoster(ctx context.Context, opts *DataPosterOpts) (*DataPoster, erro
 	default:
 		queue = slice.NewStorage(func() storage.EncoderDecoderInterface { return &storage.EncoderDecoder{} })
 	}
-	expression, err := govaluate.NewEvaluableExpression(cfg.MaxFeeCapFormula)
-	if err != nil {
-		return nil, fmt.Errorf("error creating govaluate evaluable expression for calculating maxFeeCap: %w", err)
+	feeStrategy := opts.FeeStrategy
+	if feeStrategy == nil {
+		var err error
+		feeStrategy, err = NewFormulaFeeStrategy(opts.Config)
+		if err != nil {
+			return nil, err
+		}
 	}
 	dp := &DataPoster{
 		headerReader: opts.HeaderReader,
@@ -161,15 +166,15 @@ func NewDataPoster(ctx context.Context, opts *DataPosterOpts) (*DataPoster, erro
 		signer: func(_ context.Context, addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
 			return opts.Auth.Signer(addr, tx)
 		},
-		config:              opts.Config,
-		usingNoOpStorage:    useNoOpStorage,
-		metadataRetriever:   opts.MetadataRetriever,
-		queue:               queue,
-		errorCount:          make(map[uint64]int),
-		maxFeeCapExpression: expression,
-		extraBacklog:        opts.ExtraBacklog,
-		parentChainID:       opts.ParentChainID,
-		parentChain:         &parent.ParentChain{ChainID: opts.ParentChainID, L1Reader: opts.HeaderReader},
+		config:            opts.Config,
+		usingNoOpStorage:  useNoOpStorage,
+		metadataRetriever: opts.MetadataRetriever,
+		queue:             queue,
+		errorCount:        make(map[uint64]int),
+		feeStrategy:       feeStrategy,
+		extraBacklog:      opts.ExtraBacklog,
+		parentChainID:     opts.ParentChainID,
+		parentChain:       &parent.ParentChain{ChainID: opts.ParentChainID, L1Reader: opts.HeaderReader},
 	}
 	var overflow bool
 	dp.parentChainID256, overflow = uint256.FromBig(opts.ParentChainID)
@@ -481,42 +486,6 @@ func (p *DataPoster) GetNextNonceAndMeta(ctx context.Context) (uint64, []byte, e
 const minNonBlobRbfIncrease = arbmath.OneInBips * 11 / 10
 const minBlobRbfIncrease = arbmath.OneInBips * 2
 
-// evalMaxFeeCapExpr uses MaxFeeCapFormula from config to calculate the expression's result by plugging in appropriate parameter values
-// backlogOfBatches should already include extraBacklog
-func (p *DataPoster) evalMaxFeeCapExpr(backlogOfBatches uint64, elapsed time.Duration) (*big.Int, error) {
-	config := p.config()
-	parameters := map[string]any{
-		"BacklogOfBatches":      float64(backlogOfBatches),
-		"UrgencyGWei":           config.UrgencyGwei,
-		"ElapsedTime":           float64(elapsed),
-		"ElapsedTimeBase":       float64(config.ElapsedTimeBase),
-		"ElapsedTimeImportance": config.ElapsedTimeImportance,
-		"TargetPriceGWei":       config.TargetPriceGwei,
-	}
-	result, err := p.maxFeeCapExpression.Evaluate(parameters)
-	if err != nil {
-		return nil, fmt.Errorf("error evaluating maxFeeCapExpression: %w", err)
-	}
-	resultFloat, ok := result.(float64)
-	if !ok {
-		// This shouldn't be possible because we only pass in float64s as arguments
-		return nil, fmt.Errorf("maxFeeCapExpression evaluated to non-float64: %v", result)
-	}
-	// 1e9 gwei gas price is practically speaking an infinite gas price, so we cap it there.
-	// This also allows the formula to return positive infinity safely.
-	resultFloat = math.Min(resultFloat, 1e9)
-	resultBig := arbmath.FloatToBig(resultFloat * params.GWei)
-	if resultBig == nil {
-		return nil, fmt.Errorf("maxFeeCapExpression evaluated to float64 not convertible to integer: %v", resultFloat)
-	}
-	if resultBig.Sign() < 0 {
-		return nil, fmt.Errorf("maxFeeCapExpression evaluated < 0: %v", resultFloat)
-	}
-	return resultBig, nil
-}
-
-var big4 = big.NewInt(4)
-
 // The dataPosterBacklog argument should *not* include extraBacklog (it's added in in this function)
 func (p *DataPoster) feeAndTipCaps(ctx context.Context, nonce uint64, gasLimit uint64, numBlobs uint64, lastTx *types.Transaction, dataCreatedAt time.Time, dataPosterBacklog uint64, latestHeader *types.Header) (*big.Int, *big.Int, *big.Int, error) {
 	config := p.config()
@@ -553,180 +522,22 @@ func (p *DataPoster) feeAndTipCaps(ctx context.Context, nonce uint64, gasLimit u
 	if err != nil {
 		return nil, nil, nil, err
 	}
-	minTipCapGwei, maxTipCapGwei, minRbfIncrease := config.MinTipCapGwei, config.MaxTipCapGwei, minNonBlobRbfIncrease
-	if numBlobs > 0 {
-		minTipCapGwei, maxTipCapGwei, minRbfIncrease = config.MinBlobTxTipCapGwei, config.MaxBlobTxTipCapGwei, minBlobRbfIncrease
-	}
-	newTipCap := suggestedTip
-	newTipCap = arbmath.BigMax(newTipCap, arbmath.FloatToBig(minTipCapGwei*params.GWei))
-	newTipCap = arbmath.BigMin(newTipCap, arbmath.FloatToBig(maxTipCapGwei*params.GWei))
-
-	// Compute the max fee with normalized gas so that blob txs aren't priced differently.
-	// Later, split the total cost bid into blob and non-blob fee caps.
-	elapsed := time.Since(dataCreatedAt)
-	maxNormalizedFeeCap, err := p.evalMaxFeeCapExpr(dataPosterBacklog, elapsed)
-	if err != nil {
-		return nil, nil, nil, err
-	}
-	normalizedGas := gasLimit + numBlobs*blobs.BlobEncodableData*params.TxDataNonZeroGasEIP2028
-	targetMaxCost := arbmath.BigMulByUint(maxNormalizedFeeCap, normalizedGas)
-
-	maxMempoolWeight := arbmath.MinInt(config.MaxMempoolWeight, config.MaxMempoolTransactions)
-
-	latestBalance := p.balance
-	balanceForTx := new(big.Int).Set(latestBalance)
-	weight := arbmath.MaxInt(1, numBlobs)
-	weightRemaining := weight
-
-	if config.AllocateMempoolBalance && !p.usingNoOpStorage {
-		// We split the transaction weight into three groups:
-		// - The first weight point gets 1/2 of the balance.
-		// - The first half of the weight gets 1/3 of the balance split among them.
-		// - The remaining weight get the remaining 1/6 of the balance split among them.
-		// This helps ensure batch posting is reliable under a variety of fee conditions.
-		// With noop storage, we don't try to replace-by-fee, so we don't need to worry about this.
-		balancePerWeight := new(big.Int).Div(balanceForTx, common.Big2)
-		balanceForTx = big.NewInt(0)
-		if nonce == softConfNonce || maxMempoolWeight == 1 {
-			balanceForTx.Add(balanceForTx, balancePerWeight)
-			weightRemaining -= 1
-		}
-		if weightRemaining > 0 {
-			// Compared to dividing the remaining transactions by balance equally,
-			// the first half of transactions should get a 4/3 weight,
-			// and the remaining half should get a 2/3 weight.
-			// This makes sure the average weight is 1, and the first half of transactions
-			// have twice the weight of the second half of transactions.
-			// The +1 and -1 here are to account for the first transaction being handled separately.
-			if nonce > softConfNonce && nonce < softConfNonce+1+(maxMempoolWeight-1)/2 {
-				balancePerWeight.Mul(balancePerWeight, big4)
-			} else {
-				balancePerWeight.Mul(balancePerWeight, common.Big2)
-			}
-			balancePerWeight.Div(balancePerWeight, common.Big3)
-			// After weighting, split the balance between each of the transactions
-			// other than the first tx which already got half.
-			// balanceForTx /= config.MaxMempoolTransactions-1
-			balancePerWeight.Div(balancePerWeight, arbmath.UintToBig(maxMempoolWeight-1))
-			balanceForTx.Add(balanceForTx, arbmath.BigMulByUint(balancePerWeight, weight))
-		}
-	}
-
-	if arbmath.BigGreaterThan(targetMaxCost, balanceForTx) {
-		log.Warn(
-			"lack of L1 balance prevents posting transaction with desired fee cap",
-			"balance", latestBalance,
-			"weight", weight,
-			"maxMempoolWeight", maxMempoolWeight,
-			"balanceForTransaction", balanceForTx,
-			"gasLimit", gasLimit,
-			"targetMaxCost", targetMaxCost,
-			"nonce", nonce,
-			"softConfNonce", softConfNonce,
-		)
-		targetMaxCost = balanceForTx
-	}
-
-	if lastTx != nil {
-		// Replace by fee rules require that the tip cap is increased
-		newTipCap = arbmath.BigMax(newTipCap, arbmath.BigMulByBips(lastTx.GasTipCap(), minRbfIncrease))
-	}
-
-	// Divide the targetMaxCost into blob and non-blob costs.
-	currentNonBlobFee := arbmath.BigAdd(latestHeader.BaseFee, newTipCap)
-	blobGasUsed := params.BlobTxBlobGasPerBlob * numBlobs
-	currentBlobCost := arbmath.BigMulByUint(currentBlobFee, blobGasUsed)
-	currentNonBlobCost := arbmath.BigMulByUint(currentNonBlobFee, gasLimit)
-	newBlobFeeCap := arbmath.BigMul(targetMaxCost, currentBlobFee)
-	newBlobFeeCap.Div(newBlobFeeCap, arbmath.BigAdd(currentBlobCost, currentNonBlobCost))
-	if lastTx != nil && lastTx.BlobGasFeeCap() != nil {
-		newBlobFeeCap = arbmath.BigMax(newBlobFeeCap, arbmath.BigMulByBips(lastTx.BlobGasFeeCap(), minRbfIncrease))
-	}
-	targetBlobCost := arbmath.BigMulByUint(newBlobFeeCap, blobGasUsed)
-	targetNonBlobCost := arbmath.BigSub(targetMaxCost, targetBlobCost)
-	newBaseFeeCap := arbmath.BigDivByUint(targetNonBlobCost, gasLimit)
-	if lastTx != nil && numBlobs > 0 && lastTx.GasFeeCap().Sign() > 0 && arbmath.BigDivToBips(newBaseFeeCap, lastTx.GasFeeCap()) < minRbfIncrease {
-		// Increase the non-blob fee cap to the minimum rbf increase
-		newBaseFeeCap = arbmath.BigMulByBips(lastTx.GasFeeCap(), minRbfIncrease)
-		newNonBlobCost := arbmath.BigMulByUint(newBaseFeeCap, gasLimit)
-		// Increasing the non-blob fee cap requires lowering the blob fee cap to compensate
-		baseFeeCostIncrease := arbmath.BigSub(newNonBlobCost, targetNonBlobCost)
-		newBlobCost := arbmath.BigSub(targetBlobCost, baseFeeCostIncrease)
-		newBlobFeeCap = arbmath.BigDivByUint(newBlobCost, blobGasUsed)
-	}
-
-	if config.MaxFeeBidMultipleBips > 0 {
-		// Limit the fee caps to be no greater than max(MaxFeeBidMultipleBips, minRbf)
-		maxNonBlobFee := arbmath.BigMulByUBips(currentNonBlobFee, config.MaxFeeBidMultipleBips)
-		if lastTx != nil {
-			maxNonBlobFee = arbmath.BigMax(maxNonBlobFee, arbmath.BigMulByBips(lastTx.GasFeeCap(), minRbfIncrease))
-		}
-		maxBlobFee := arbmath.BigMulByUBips(currentBlobFee, config.MaxFeeBidMultipleBips)
-		if lastTx != nil && lastTx.BlobGasFeeCap() != nil {
-			maxBlobFee = arbmath.BigMax(maxBlobFee, arbmath.BigMulByBips(lastTx.BlobGasFeeCap(), minRbfIncrease))
-		}
-		newBaseFeeCap = arbmath.BigMin(newBaseFeeCap, maxNonBlobFee)
-		newBlobFeeCap = arbmath.BigMin(newBlobFeeCap, maxBlobFee)
-	}
-
-	if arbmath.BigGreaterThan(newTipCap, newBaseFeeCap) {
-		log.Info(
-			"reducing new tip cap to new basefee cap",
-			"proposedTipCap", newTipCap,
-			"newBasefeeCap", newBaseFeeCap,
-		)
-		newTipCap = new(big.Int).Set(newBaseFeeCap)
-	}
-
-	logFields := []any{
-		"targetMaxCost", targetMaxCost,
-		"elapsed", elapsed,
-		"dataPosterBacklog", dataPosterBacklog,
-		"nonce", nonce,
-		"isReplacing", lastTx != nil,
-		"balanceForTx", balanceForTx,
-		"currentBaseFee", latestHeader.BaseFee,
-		"newBasefeeCap", newBaseFeeCap,
-		"suggestedTip", suggestedTip,
-		"newTipCap", newTipCap,
-		"currentBlobFee", currentBlobFee,
-		"newBlobFeeCap", newBlobFeeCap,
-	}
-
-	log.Debug("calculated data poster fee and tip caps", logFields...)
-
-	if newBaseFeeCap.Sign() < 0 || newTipCap.Sign() < 0 || newBlobFeeCap.Sign() < 0 {
-		msg := "can't meet data poster fee cap obligations with current target max cost"
-		log.Info(msg, logFields...)
-		if lastTx != nil {
-			// wait until we have a higher target max cost to replace by fee
-			return lastTx.GasFeeCap(), lastTx.GasTipCap(), lastTx.BlobGasFeeCap(), nil
-		} else {
-			return nil, nil, nil, errors.New(msg)
-		}
-	}
 
-	if lastTx != nil && (arbmath.BigLessThan(newBaseFeeCap, currentNonBlobFee) || (numBlobs > 0 && arbmath.BigLessThan(newBlobFeeCap, currentBlobFee))) {
-		// Make sure our replace by fee can meet the current parent chain fee demands.
-		// Without this check, we'd blindly increase each fee component by the min rbf amount each time,
-		// without looking at which component(s) actually need increased.
-		// E.g. instead of 2x basefee and 2x blobfee, we might actually want to 4x basefee and 2x blobfee.
-		// This check lets us hold off on the rbf until we are actually meet the current fee requirements,
-		// which lets us move in a particular direction (biasing towards either basefee or blobfee).
-		log.Info("can't meet current parent chain fees with current target max cost", logFields...)
-		// wait until we have a higher target max cost to replace by fee
-		return lastTx.GasFeeCap(), lastTx.GasTipCap(), lastTx.BlobGasFeeCap(), nil
-	}
-
-	// Ensure we bid at least 1 wei to prevent division by zero
-	if newBaseFeeCap.Sign() == 0 {
-		newBaseFeeCap = big.NewInt(1)
-	}
-	if newBlobFeeCap.Sign() == 0 {
-		newBlobFeeCap = big.NewInt(1)
-	}
-
-	return newBaseFeeCap, newTipCap, newBlobFeeCap, nil
+	return p.feeStrategy.FeeCaps(&FeeRequest{
+		Nonce:            nonce,
+		GasLimit:         gasLimit,
+		NumBlobs:         numBlobs,
+		LastTx:           lastTx,
+		DataCreatedAt:    dataCreatedAt,
+		Now:              time.Now(),
+		Backlog:          dataPosterBacklog,
+		LatestHeader:     latestHeader,
+		CurrentBlobFee:   currentBlobFee,
+		SoftConfNonce:    softConfNonce,
+		SuggestedTip:     suggestedTip,
+		Balance:          p.balance,
+		UsingNoOpStorage: p.usingNoOpStorage,
+	})
 }
 
 func (p *DataPoster) PostSimpleTransaction(ctx context.Context, to common.Address, calldata []byte, gasLimit uint64, value *big.Int) (*types.Transaction, error) {
@@ -1012,14 +823,8 @@ func (p *DataPoster) replaceTx(ctx context.Context, prevTx *storage.QueuedTransa
 		return err
 	}
 
-	minRbfIncrease := minNonBlobRbfIncrease
-	if len(prevTx.FullTx.BlobHashes()) > 0 {
-		minRbfIncrease = minBlobRbfIncrease
-	}
-
 	newTx := *prevTx
-	if (prevTx.FullTx.GasFeeCap().Sign() > 0 && arbmath.BigDivToBips(newFeeCap, prevTx.FullTx.GasFeeCap()) < minRbfIncrease) ||
-		(prevTx.FullTx.BlobGasFeeCap() != nil && prevTx.FullTx.BlobGasFeeCap().Sign() > 0 && arbmath.BigDivToBips(newBlobFeeCap, prevTx.FullTx.BlobGasFeeCap()) < minRbfIncrease) {
+	if !isReplacementByFee(prevTx.FullTx, newFeeCap, newBlobFeeCap) {
 		log.Debug(
 			"no need to replace by fee transaction",
 			"nonce", prevTx.FullTx.Nonce(),
@@ -1159,6 +964,7 @@ const minWait = time.Second * 10
 // Tries to acquire redis lock, updates balance and nonce,
 func (p *DataPoster) Start(ctxIn context.Context) {
 	p.StopWaiter.Start(ctxIn, p)
+	checkedNonceGaps := false
 	p.CallIteratively(func(ctx context.Context) time.Duration {
 		p.mutex.Lock()
 		defer p.mutex.Unlock()
@@ -1172,6 +978,14 @@ func (p *DataPoster) Start(ctxIn context.Context) {
 			// This is non-fatal because it's only needed for clearing out old queue items.
 			log.Warn("failed to update tx poster nonce", "err", err)
 		}
+		if !checkedNonceGaps {
+			// Gaps are only left by transactions sent outside of the data poster, so only check on startup.
+			if err := p.checkNonceGaps(ctx); err != nil {
+				log.Warn("failed to check tx poster queue for nonce gaps", "err", err)
+			} else {
+				checkedNonceGaps = true
+			}
+		}
 		now := time.Now()
 		nextCheck := now.Add(arbmath.MinInt(p.config().ReplacementTimes[0], p.config().BlobTxReplacementTimes[0]))
 		maxTxsToRbf := p.config().MaxMempoolTransactions
@@ -1307,6 +1121,9 @@ type DataPosterConfig struct {
 	// When set, dataposter will not post new batches, but will keep running to
 	// get existing batches confirmed.
 	DisableNewTx bool `koanf:"disable-new-tx" reload:"hot"`
+	// When set, nonce gaps found in the queue on startup are filled with
+	// zero value transfers to the sender instead of only being reported.
+	RepairNonceGaps bool `koanf:"repair-nonce-gaps" reload:"hot"`
 }
 
 type ExternalSignerCfg struct {
@@ -1385,6 +1202,7 @@ func DataPosterConfigAddOptions(prefix string, f *pflag.FlagSet, defaultDataPost
 	addDangerousOptions(prefix+".dangerous", f)
 	addExternalSignerOptions(prefix+".external-signer", f)
 	f.Bool(prefix+".disable-new-tx", defaultDataPosterConfig.DisableNewTx, "disable posting new transactions, data poster will still keep confirming existing batches")
+	f.Bool(prefix+".repair-nonce-gaps", defaultDataPosterConfig.RepairNonceGaps, "on startup, fill nonce gaps left in the queue by transactions sent outside of the data poster with zero value transfers to the sender")
 }
 
 func addDangerousOptions(prefix string, f *pflag.FlagSet) {
@@ -1426,6 +1244,7 @@ var DefaultDataPosterConfig = DataPosterConfig{
 	ElapsedTimeBase:        10 * time.Minute,
 	ElapsedTimeImportance:  10,
 	DisableNewTx:           false,
+	RepairNonceGaps:        false,
 }
 
 var DefaultDataPosterConfigForValidator = func() DataPosterConfig {
@@ -1461,6 +1280,7 @@ var TestDataPosterConfig = DataPosterConfig{
 	ElapsedTimeBase:        10 * time.Minute,
 	ElapsedTimeImportance:  10,
 	DisableNewTx:           false,
+	RepairNonceGaps:        false,
 }
 
 var TestDataPosterConfigForValidator = func() DataPosterConfig {
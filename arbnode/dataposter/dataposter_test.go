@@ -3,6 +3,7 @@ package dataposter
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 	"testing"
 	"time"
@@ -11,18 +12,27 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/holiman/uint256"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/ethconfig"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethclient/simulated"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/offchainlabs/nitro/arbnode/dataposter/externalsignertest"
+	"github.com/offchainlabs/nitro/arbnode/dataposter/storage"
 	"github.com/offchainlabs/nitro/arbnode/parent"
 	"github.com/offchainlabs/nitro/util/arbmath"
+	"github.com/offchainlabs/nitro/util/headerreader"
+	"github.com/offchainlabs/nitro/util/testhelpers"
 )
 
 var (
@@ -119,7 +129,7 @@ func TestMaxFeeCapFormulaCalculation(t *testing.T) {
 	}
 	config := DefaultDataPosterConfig
 	config.TargetPriceGwei = 0
-	p := &DataPoster{
+	p := &FormulaFeeStrategy{
 		config:              func() *DataPosterConfig { return &config },
 		maxFeeCapExpression: expression,
 	}
@@ -208,8 +218,11 @@ func TestFeeAndTipCaps_EnoughBalance_NoBacklog_NoUnconfirmed_BlobTx(t *testing.T
 		auth: &bind.TransactOpts{
 			From: common.Address{},
 		},
-		maxFeeCapExpression: expression,
-		parentChainID:       big.NewInt(1337),
+		feeStrategy: &FormulaFeeStrategy{
+			config:              conf,
+			maxFeeCapExpression: expression,
+		},
+		parentChainID: big.NewInt(1337),
 		parentChain: &parent.ParentChain{
 			ChainID:  big.NewInt(1337),
 			L1Reader: nil,
@@ -344,8 +357,11 @@ func TestFeeAndTipCaps_RBF_RisingBlobFee_FallingBaseFee(t *testing.T) {
 		auth: &bind.TransactOpts{
 			From: common.Address{},
 		},
-		maxFeeCapExpression: expression,
-		parentChainID:       big.NewInt(1337),
+		feeStrategy: &FormulaFeeStrategy{
+			config:              conf,
+			maxFeeCapExpression: expression,
+		},
+		parentChainID: big.NewInt(1337),
 		parentChain: &parent.ParentChain{
 			ChainID:  big.NewInt(1337),
 			L1Reader: nil,
@@ -433,3 +449,333 @@ func TestFeeAndTipCaps_RBF_RisingBlobFee_FallingBaseFee(t *testing.T) {
 	}
 
 }
+
+func TestNonceGaps(t *testing.T) {
+	ctx := context.Background()
+	queue := newLevelDBStorage(t, func() storage.EncoderDecoderInterface { return &storage.EncoderDecoder{} })
+	for _, i := range []int{2, 3, 5, 8} {
+		// #nosec G115
+		if err := queue.Put(ctx, uint64(i), nil, valueOf(t, i)); err != nil {
+			t.Fatalf("Error putting a key/value: %v", err)
+		}
+	}
+	p := DataPoster{
+		config: func() *DataPosterConfig { return &TestDataPosterConfig },
+		queue:  queue,
+		client: ethclient.NewClient(&stubL1ClientInner{
+			senderNonce: 3,
+		}),
+		auth: &bind.TransactOpts{
+			From: common.Address{},
+		},
+	}
+	gaps, err := p.NonceGaps(ctx)
+	if err != nil {
+		t.Fatalf("NonceGaps() unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]uint64{4, 6, 7}, gaps); diff != "" {
+		t.Errorf("NonceGaps() unexpected diff:\n%s", diff)
+	}
+
+	// Filling a gap must be accounted for in the length of the queue.
+	if err := queue.Put(ctx, 4, nil, valueOf(t, 4)); err != nil {
+		t.Fatalf("Error putting a key/value: %v", err)
+	}
+	if got, err := queue.Length(ctx); err != nil || got != 5 {
+		t.Errorf("Length() = %d, %v want 5", got, err)
+	}
+	gaps, err = p.NonceGaps(ctx)
+	if err != nil {
+		t.Fatalf("NonceGaps() unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]uint64{6, 7}, gaps); diff != "" {
+		t.Errorf("NonceGaps() unexpected diff:\n%s", diff)
+	}
+
+	// Nonces already included in the parent chain aren't gaps.
+	p.client = ethclient.NewClient(&stubL1ClientInner{
+		senderNonce: 7,
+	})
+	gaps, err = p.NonceGaps(ctx)
+	if err != nil {
+		t.Fatalf("NonceGaps() unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]uint64{7}, gaps); diff != "" {
+		t.Errorf("NonceGaps() unexpected diff:\n%s", diff)
+	}
+}
+
+func TestSimulateFeeStrategy(t *testing.T) {
+	// A block every 12 seconds, with the base fee spiking from 10 to 200 gwei between blocks 10 and 29.
+	var headers []*SimulatedHeader
+	for i := uint64(0); i < 40; i++ {
+		baseFee := big.NewInt(10 * params.GWei)
+		if i >= 10 && i < 30 {
+			baseFee = big.NewInt(200 * params.GWei)
+		}
+		headers = append(headers, &SimulatedHeader{
+			Number:    hexutil.Uint64(100 + i),
+			Timestamp: hexutil.Uint64(1_700_000_000 + 12*i),
+			BaseFee:   (*hexutil.Big)(baseFee),
+		})
+	}
+	simConfig := &FeeSimulationConfig{
+		PostInterval: time.Minute,
+		GasLimit:     1_000_000,
+		Balance:      big.NewInt(0).Mul(big.NewInt(params.Ether), big.NewInt(10)),
+	}
+	simulate := func(config DataPosterConfig) *FeeSimulationResult {
+		t.Helper()
+		strategy, err := NewFormulaFeeStrategy(func() *DataPosterConfig { return &config })
+		if err != nil {
+			t.Fatalf("NewFormulaFeeStrategy() unexpected error: %v", err)
+		}
+		result, err := SimulateFeeStrategy(strategy, &config, simConfig, headers)
+		if err != nil {
+			t.Fatalf("SimulateFeeStrategy() unexpected error: %v", err)
+		}
+		return result
+	}
+
+	result := simulate(TestDataPosterConfig)
+	if diff := cmp.Diff(result, simulate(TestDataPosterConfig), cmp.Comparer(arbmath.BigEquals)); diff != "" {
+		t.Errorf("SimulateFeeStrategy() isn't deterministic:\n%s", diff)
+	}
+	if result.Posted != 8 {
+		t.Errorf("Posted = %d want 8", result.Posted)
+	}
+	if result.Included == 0 || result.Included > result.Posted {
+		t.Errorf("Included = %d, posted %d", result.Included, result.Posted)
+	}
+	if result.TotalSpend.Sign() <= 0 {
+		t.Errorf("TotalSpend = %v want > 0", result.TotalSpend)
+	}
+	// With a 60 gwei target price, the transaction posted when the spike
+	// started is only included once the spike is over.
+	if result.MaxLatency != 4*time.Minute {
+		t.Errorf("MaxLatency = %v want %v", result.MaxLatency, 4*time.Minute)
+	}
+
+	// With a target price above the spike, every transaction is included in the next block.
+	config := TestDataPosterConfig
+	config.TargetPriceGwei = 250
+	result = simulate(config)
+	if result.MaxLatency != 12*time.Second {
+		t.Errorf("MaxLatency = %v want %v", result.MaxLatency, 12*time.Second)
+	}
+}
+
+func newSimulatedDataPoster(t *testing.T, ctx context.Context) (*DataPoster, *simulated.Backend) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Error generating key: %v", err)
+	}
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	listener, err := testhelpers.FreeTCPPortListener()
+	if err != nil {
+		t.Fatalf("Error getting free port: %v", err)
+	}
+	port := testhelpers.AddrTCPPort(listener.Addr(), t)
+	if err := listener.Close(); err != nil {
+		t.Fatalf("Error closing listener: %v", err)
+	}
+	backend := simulated.NewBackend(
+		types.GenesisAlloc{sender: {Balance: new(big.Int).Lsh(big.NewInt(1), 100)}},
+		func(n *node.Config, _ *ethconfig.Config) {
+			n.HTTPHost = "127.0.0.1"
+			n.HTTPPort = port
+			n.HTTPModules = []string{"eth", "net", "web3"}
+		},
+	)
+	t.Cleanup(func() { _ = backend.Close() })
+	backend.Commit()
+
+	client, err := ethclient.DialContext(ctx, fmt.Sprintf("http://127.0.0.1:%d", port))
+	if err != nil {
+		t.Fatalf("Error dialing simulated backend: %v", err)
+	}
+	t.Cleanup(client.Close)
+	headerReaderConfig := headerreader.TestConfig
+	headerReaderConfig.PollOnly = true
+	headerReader, err := headerreader.New(ctx, client, func() *headerreader.Config { return &headerReaderConfig }, nil)
+	if err != nil {
+		t.Fatalf("Error creating header reader: %v", err)
+	}
+	headerReader.Start(ctx)
+	t.Cleanup(headerReader.StopAndWait)
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		t.Fatalf("Error getting chain id: %v", err)
+	}
+	auth, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		t.Fatalf("Error creating transactor: %v", err)
+	}
+	config := TestDataPosterConfig
+	config.UseDBStorage = true
+	p, err := NewDataPoster(ctx, &DataPosterOpts{
+		Database:      rawdb.NewMemoryDatabase(),
+		HeaderReader:  headerReader,
+		Auth:          auth,
+		Config:        func() *DataPosterConfig { return &config },
+		ParentChainID: chainID,
+	})
+	if err != nil {
+		t.Fatalf("Error creating data poster: %v", err)
+	}
+	return p, backend
+}
+
+func TestCancelNonce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p, backend := newSimulatedDataPoster(t, ctx)
+	client := backend.Client()
+	recipient := common.HexToAddress("0x1234")
+
+	var posted []*types.Transaction
+	for i := 0; i < 2; i++ {
+		tx, err := p.PostSimpleTransaction(ctx, recipient, nil, params.TxGas, big.NewInt(1))
+		if err != nil {
+			t.Fatalf("Error posting transaction: %v", err)
+		}
+		posted = append(posted, tx)
+	}
+
+	cancelTx, err := p.CancelNonce(ctx, 1)
+	if err != nil {
+		t.Fatalf("CancelNonce() unexpected error: %v", err)
+	}
+	if cancelTx.Nonce() != 1 || *cancelTx.To() != p.Sender() || cancelTx.Value().Sign() != 0 {
+		t.Errorf("CancelNonce() returned nonce %d transfer of %v to %v, want nonce 1 zero value transfer to the sender", cancelTx.Nonce(), cancelTx.Value(), cancelTx.To())
+	}
+	if minFeeCap := arbmath.BigMulByBips(posted[1].GasFeeCap(), minNonBlobRbfIncrease); cancelTx.GasFeeCap().Cmp(minFeeCap) < 0 {
+		t.Errorf("CancelNonce() fee cap %v doesn't replace the cancelled transaction, want at least %v", cancelTx.GasFeeCap(), minFeeCap)
+	}
+	queued, err := p.queue.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Error getting queued transaction: %v", err)
+	}
+	if !queued.Cancelled || !queued.Sent || queued.FullTx.Hash() != cancelTx.Hash() {
+		t.Errorf("queued transaction is cancelled %v, sent %v with hash %v, want the sent cancellation %v", queued.Cancelled, queued.Sent, queued.FullTx.Hash(), cancelTx.Hash())
+	}
+	// Cancelling again returns the queued cancellation.
+	again, err := p.CancelNonce(ctx, 1)
+	if err != nil {
+		t.Fatalf("CancelNonce() unexpected error: %v", err)
+	}
+	if again.Hash() != cancelTx.Hash() {
+		t.Errorf("CancelNonce() = %v, want the queued cancellation %v", again.Hash(), cancelTx.Hash())
+	}
+
+	backend.Commit()
+	receipt, err := client.TransactionReceipt(ctx, cancelTx.Hash())
+	if err != nil {
+		t.Fatalf("Error getting cancellation receipt: %v", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Errorf("cancellation status = %d, want success", receipt.Status)
+	}
+	if _, err := client.TransactionReceipt(ctx, posted[1].Hash()); !errors.Is(err, ethereum.NotFound) {
+		t.Errorf("cancelled transaction receipt error = %v, want not found", err)
+	}
+	balance, err := client.BalanceAt(ctx, recipient, nil)
+	if err != nil {
+		t.Fatalf("Error getting balance: %v", err)
+	}
+	if balance.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("recipient balance = %v, want 1 from the transaction that wasn't cancelled", balance)
+	}
+
+	if _, err := p.CancelNonce(ctx, 0); !errors.Is(err, ErrNonceConfirmed) {
+		t.Errorf("CancelNonce() of an included nonce error = %v, want %v", err, ErrNonceConfirmed)
+	}
+	if _, err := p.CancelNonce(ctx, 5); !errors.Is(err, ErrNonceNotQueued) {
+		t.Errorf("CancelNonce() of a nonce that isn't queued error = %v, want %v", err, ErrNonceNotQueued)
+	}
+}
+
+func TestRepairNonceGaps(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p, backend := newSimulatedDataPoster(t, ctx)
+	client := backend.Client()
+	recipient := common.HexToAddress("0x1234")
+
+	first, err := p.PostSimpleTransaction(ctx, recipient, nil, params.TxGas, big.NewInt(1))
+	if err != nil {
+		t.Fatalf("Error posting transaction: %v", err)
+	}
+	// Queue a transaction after nonce 1, as if it had been used by a
+	// transaction sent outside of the data poster that got dropped.
+	afterGap, err := p.auth.Signer(p.Sender(), types.NewTx(&types.DynamicFeeTx{
+		ChainID:   p.parentChainID,
+		Nonce:     2,
+		GasTipCap: first.GasTipCap(),
+		GasFeeCap: first.GasFeeCap(),
+		Gas:       params.TxGas,
+		To:        &recipient,
+		Value:     big.NewInt(1),
+	}))
+	if err != nil {
+		t.Fatalf("Error signing transaction: %v", err)
+	}
+	if err := client.SendTransaction(ctx, afterGap); err != nil {
+		t.Fatalf("Error sending transaction: %v", err)
+	}
+	if err := p.queue.Put(ctx, 2, nil, &storage.QueuedTransaction{
+		FullTx:          afterGap,
+		Sent:            true,
+		Created:         time.Now(),
+		NextReplacement: time.Now().Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("Error queueing transaction: %v", err)
+	}
+
+	// The transaction after the gap can't be included.
+	backend.Commit()
+	nonce, err := client.NonceAt(ctx, p.Sender(), nil)
+	if err != nil {
+		t.Fatalf("Error getting nonce: %v", err)
+	}
+	if nonce != 1 {
+		t.Fatalf("sender nonce = %d, want 1", nonce)
+	}
+
+	filled, err := p.RepairNonceGaps(ctx)
+	if err != nil {
+		t.Fatalf("RepairNonceGaps() unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]uint64{1}, filled); diff != "" {
+		t.Errorf("RepairNonceGaps() unexpected diff:\n%s", diff)
+	}
+	queued, err := p.queue.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Error getting queued transaction: %v", err)
+	}
+	if queued == nil || !queued.Cancelled || !queued.Sent || *queued.FullTx.To() != p.Sender() {
+		t.Fatalf("nonce gap filled with %+v, want a sent cancellation", queued)
+	}
+	gaps, err := p.NonceGaps(ctx)
+	if err != nil {
+		t.Fatalf("NonceGaps() unexpected error: %v", err)
+	}
+	if len(gaps) != 0 {
+		t.Errorf("NonceGaps() = %v after repair, want none", gaps)
+	}
+
+	// Filling the gap lets the transaction after it be included.
+	backend.Commit()
+	nonce, err = client.NonceAt(ctx, p.Sender(), nil)
+	if err != nil {
+		t.Fatalf("Error getting nonce: %v", err)
+	}
+	if nonce != 3 {
+		t.Errorf("sender nonce = %d, want 3", nonce)
+	}
+	if _, err := client.TransactionReceipt(ctx, afterGap.Hash()); err != nil {
+		t.Errorf("Error getting receipt of the transaction after the gap: %v", err)
+	}
+}
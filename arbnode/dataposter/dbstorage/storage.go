@@ -179,6 +179,11 @@ func (s *Storage) Put(ctx context.Context, index uint64, prev, new *storage.Queu
 		if err := b.Put(countKey, []byte(strconv.Itoa(cnt+1))); err != nil {
 			return fmt.Errorf("updating length counter: %w", err)
 		}
+	} else if prev == nil {
+		// New item filling a nonce gap before the last item.
+		if err := b.Put(countKey, []byte(strconv.Itoa(cnt+1))); err != nil {
+			return fmt.Errorf("updating length counter: %w", err)
+		}
 	}
 	return b.Write()
 }
@@ -0,0 +1,229 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package dataposter
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/offchainlabs/nitro/util/arbmath"
+)
+
+// SimulatedHeader is a recorded parent chain block, as replayed by SimulateFeeStrategy.
+type SimulatedHeader struct {
+	Number    hexutil.Uint64 `json:"number"`
+	Timestamp hexutil.Uint64 `json:"timestamp"`
+	BaseFee   *hexutil.Big   `json:"baseFeePerGas"`
+	// BlobBaseFee is required if blobs are posted.
+	BlobBaseFee *hexutil.Big `json:"blobBaseFee,omitempty"`
+	// PriorityFee is the minimum tip needed to be included in the block, zero if unset.
+	PriorityFee *hexutil.Big `json:"priorityFee,omitempty"`
+}
+
+type FeeSimulationConfig struct {
+	// PostInterval is the time between two new transactions being posted.
+	PostInterval time.Duration
+	GasLimit     uint64
+	NumBlobs     uint64
+	Balance      *big.Int
+}
+
+type FeeSimulationResult struct {
+	Posted       int      `json:"posted"`
+	Included     int      `json:"included"`
+	Replacements int      `json:"replacements"`
+	TotalSpend   *big.Int `json:"totalSpend"`
+	// Latencies are measured from the transaction being posted to its inclusion.
+	TotalLatency time.Duration `json:"totalLatency"`
+	MaxLatency   time.Duration `json:"maxLatency"`
+}
+
+func (r *FeeSimulationResult) AverageLatency() time.Duration {
+	if r.Included == 0 {
+		return 0
+	}
+	return r.TotalLatency / time.Duration(r.Included)
+}
+
+type simulatedTx struct {
+	tx              *types.Transaction
+	created         time.Time
+	nextReplacement time.Time
+}
+
+// SimulateFeeStrategy replays the recorded headers through the fee strategy,
+// posting a transaction every PostInterval and replacing them by fee like the
+// data poster does. A transaction is included in the first block whose base
+// fee, blob base fee and priority fee it pays for, after all the transactions
+// with lower nonces. The simulation is deterministic, time is only taken from
+// the header timestamps.
+func SimulateFeeStrategy(strategy FeeStrategy, config *DataPosterConfig, simConfig *FeeSimulationConfig, headers []*SimulatedHeader) (*FeeSimulationResult, error) {
+	if simConfig.PostInterval <= 0 {
+		return nil, errors.New("post interval must be positive")
+	}
+	if len(headers) == 0 {
+		return nil, errors.New("no headers to simulate")
+	}
+	replacementTimes := config.ReplacementTimes
+	if simConfig.NumBlobs > 0 {
+		replacementTimes = config.BlobTxReplacementTimes
+	}
+	if len(replacementTimes) == 0 {
+		return nil, errors.New("no replacement times configured")
+	}
+	weight := arbmath.MaxInt(1, simConfig.NumBlobs)
+	result := &FeeSimulationResult{TotalSpend: big.NewInt(0)}
+	balance := new(big.Int).Set(simConfig.Balance)
+	var pending []*simulatedTx
+	// includedAt[i] is the number of transactions included up to headers[i].
+	includedAt := make([]uint64, 0, len(headers))
+	var nextNonce uint64
+	nextPost := time.Unix(int64(headers[0].Timestamp), 0) // #nosec G115
+
+	for i, h := range headers {
+		if h.BaseFee == nil {
+			return nil, fmt.Errorf("header %d missing base fee", h.Number)
+		}
+		if simConfig.NumBlobs > 0 && h.BlobBaseFee == nil {
+			return nil, fmt.Errorf("header %d missing blob base fee", h.Number)
+		}
+		if i > 0 && h.Timestamp < headers[i-1].Timestamp {
+			return nil, fmt.Errorf("header %d has a timestamp before its predecessor", h.Number)
+		}
+		now := time.Unix(int64(h.Timestamp), 0) // #nosec G115
+		baseFee := h.BaseFee.ToInt()
+		blobBaseFee := big.NewInt(0)
+		if h.BlobBaseFee != nil {
+			blobBaseFee = h.BlobBaseFee.ToInt()
+		}
+		priorityFee := big.NewInt(0)
+		if h.PriorityFee != nil {
+			priorityFee = h.PriorityFee.ToInt()
+		}
+
+		// Transactions priced before this block can be included in it, in nonce order.
+		for len(pending) > 0 {
+			tx := pending[0].tx
+			if arbmath.BigLessThan(tx.GasFeeCap(), baseFee) {
+				break
+			}
+			tip := arbmath.BigMin(tx.GasTipCap(), arbmath.BigSub(tx.GasFeeCap(), baseFee))
+			if arbmath.BigLessThan(tip, priorityFee) {
+				break
+			}
+			if simConfig.NumBlobs > 0 && arbmath.BigLessThan(tx.BlobGasFeeCap(), blobBaseFee) {
+				break
+			}
+			spend := arbmath.BigMulByUint(arbmath.BigAdd(baseFee, tip), tx.Gas())
+			spend.Add(spend, arbmath.BigMulByUint(blobBaseFee, params.BlobTxBlobGasPerBlob*simConfig.NumBlobs))
+			balance.Sub(balance, spend)
+			result.TotalSpend.Add(result.TotalSpend, spend)
+			latency := now.Sub(pending[0].created)
+			result.TotalLatency += latency
+			result.MaxLatency = arbmath.MaxInt(result.MaxLatency, latency)
+			result.Included++
+			pending = pending[1:]
+		}
+		includedAt = append(includedAt, uint64(result.Included)) // #nosec G115
+		// #nosec G115
+		softConfIndex := int(arbmath.SaturatingUSub(uint64(i), config.NonceRbfSoftConfs))
+
+		header := &types.Header{
+			Number:  new(big.Int).SetUint64(uint64(h.Number)),
+			Time:    uint64(h.Timestamp),
+			BaseFee: baseFee,
+		}
+		request := func(nonce uint64, lastTx *types.Transaction, created time.Time, backlog uint64) *FeeRequest {
+			return &FeeRequest{
+				Nonce:          nonce,
+				GasLimit:       simConfig.GasLimit,
+				NumBlobs:       simConfig.NumBlobs,
+				LastTx:         lastTx,
+				DataCreatedAt:  created,
+				Now:            now,
+				Backlog:        backlog,
+				LatestHeader:   header,
+				CurrentBlobFee: blobBaseFee,
+				SoftConfNonce:  includedAt[softConfIndex],
+				SuggestedTip:   priorityFee,
+				Balance:        new(big.Int).Set(balance),
+			}
+		}
+
+		for j, pendingTx := range pending {
+			if now.Before(pendingTx.nextReplacement) {
+				continue
+			}
+			backlog := uint64(len(pending)-1-j) * weight // #nosec G115
+			feeCap, tipCap, blobFeeCap, err := strategy.FeeCaps(request(pendingTx.tx.Nonce(), pendingTx.tx, pendingTx.created, backlog))
+			if err != nil {
+				return nil, fmt.Errorf("replacing transaction with nonce %d at block %d: %w", pendingTx.tx.Nonce(), h.Number, err)
+			}
+			if !isReplacementByFee(pendingTx.tx, feeCap, blobFeeCap) {
+				pendingTx.nextReplacement = now.Add(time.Minute)
+				continue
+			}
+			pendingTx.tx, err = simulatedTransaction(pendingTx.tx.Nonce(), simConfig, feeCap, tipCap, blobFeeCap)
+			if err != nil {
+				return nil, err
+			}
+			elapsed := now.Sub(pendingTx.created)
+			for _, replacement := range replacementTimes {
+				if elapsed >= replacement {
+					continue
+				}
+				pendingTx.nextReplacement = pendingTx.created.Add(replacement)
+				break
+			}
+			result.Replacements++
+		}
+
+		for !now.Before(nextPost) {
+			feeCap, tipCap, blobFeeCap, err := strategy.FeeCaps(request(nextNonce, nil, now, 0))
+			if err != nil {
+				return nil, fmt.Errorf("posting transaction with nonce %d at block %d: %w", nextNonce, h.Number, err)
+			}
+			tx, err := simulatedTransaction(nextNonce, simConfig, feeCap, tipCap, blobFeeCap)
+			if err != nil {
+				return nil, err
+			}
+			pending = append(pending, &simulatedTx{
+				tx:              tx,
+				created:         now,
+				nextReplacement: now.Add(replacementTimes[0]),
+			})
+			nextNonce++
+			result.Posted++
+			nextPost = nextPost.Add(simConfig.PostInterval)
+		}
+	}
+	return result, nil
+}
+
+func simulatedTransaction(nonce uint64, simConfig *FeeSimulationConfig, feeCap, tipCap, blobFeeCap *big.Int) (*types.Transaction, error) {
+	var inner types.TxData
+	if simConfig.NumBlobs > 0 {
+		inner = &types.BlobTx{
+			Nonce:      nonce,
+			Gas:        simConfig.GasLimit,
+			BlobHashes: make([]common.Hash, simConfig.NumBlobs),
+		}
+	} else {
+		inner = &types.DynamicFeeTx{
+			Nonce: nonce,
+			Gas:   simConfig.GasLimit,
+		}
+	}
+	if err := updateTxDataGasCaps(inner, feeCap, tipCap, blobFeeCap); err != nil {
+		return nil, err
+	}
+	return types.NewTx(inner), nil
+}
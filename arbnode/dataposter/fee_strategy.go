@@ -0,0 +1,303 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package dataposter
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/Knetic/govaluate"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/offchainlabs/nitro/util/arbmath"
+	"github.com/offchainlabs/nitro/util/blobs"
+)
+
+// FeeRequest describes a transaction to be priced, along with the state of
+// the parent chain and of the data poster at the time it's priced.
+type FeeRequest struct {
+	Nonce    uint64
+	GasLimit uint64
+	NumBlobs uint64
+	// LastTx is the transaction being replaced by fee, or nil for a new transaction.
+	LastTx        *types.Transaction
+	DataCreatedAt time.Time
+	Now           time.Time
+	// Backlog is the weight of the transactions queued after this one, including the extra backlog.
+	Backlog      uint64
+	LatestHeader *types.Header
+	// CurrentBlobFee is the blob base fee of LatestHeader, zero if NumBlobs is 0.
+	CurrentBlobFee *big.Int
+	// SoftConfNonce is the nonce of the sender NonceRbfSoftConfs blocks ago.
+	SoftConfNonce    uint64
+	SuggestedTip     *big.Int
+	Balance          *big.Int
+	UsingNoOpStorage bool
+}
+
+// FeeStrategy decides the fee cap, tip cap and blob fee cap of the
+// transactions posted by the data poster. It must not query the parent chain,
+// all the state it may use is part of the FeeRequest, so that strategies can
+// be evaluated offline.
+type FeeStrategy interface {
+	FeeCaps(req *FeeRequest) (feeCap *big.Int, tipCap *big.Int, blobFeeCap *big.Int, err error)
+}
+
+// FormulaFeeStrategy is the default fee strategy. It bids up to the result of
+// MaxFeeCapFormula, spread over the balance available to the mempool.
+type FormulaFeeStrategy struct {
+	config              ConfigFetcher
+	maxFeeCapExpression *govaluate.EvaluableExpression
+}
+
+func NewFormulaFeeStrategy(config ConfigFetcher) (*FormulaFeeStrategy, error) {
+	expression, err := govaluate.NewEvaluableExpression(config().MaxFeeCapFormula)
+	if err != nil {
+		return nil, fmt.Errorf("error creating govaluate evaluable expression for calculating maxFeeCap: %w", err)
+	}
+	return &FormulaFeeStrategy{
+		config:              config,
+		maxFeeCapExpression: expression,
+	}, nil
+}
+
+// evalMaxFeeCapExpr uses MaxFeeCapFormula from config to calculate the expression's result by plugging in appropriate parameter values
+// backlogOfBatches should already include extraBacklog
+func (s *FormulaFeeStrategy) evalMaxFeeCapExpr(backlogOfBatches uint64, elapsed time.Duration) (*big.Int, error) {
+	config := s.config()
+	parameters := map[string]any{
+		"BacklogOfBatches":      float64(backlogOfBatches),
+		"UrgencyGWei":           config.UrgencyGwei,
+		"ElapsedTime":           float64(elapsed),
+		"ElapsedTimeBase":       float64(config.ElapsedTimeBase),
+		"ElapsedTimeImportance": config.ElapsedTimeImportance,
+		"TargetPriceGWei":       config.TargetPriceGwei,
+	}
+	result, err := s.maxFeeCapExpression.Evaluate(parameters)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating maxFeeCapExpression: %w", err)
+	}
+	resultFloat, ok := result.(float64)
+	if !ok {
+		// This shouldn't be possible because we only pass in float64s as arguments
+		return nil, fmt.Errorf("maxFeeCapExpression evaluated to non-float64: %v", result)
+	}
+	// 1e9 gwei gas price is practically speaking an infinite gas price, so we cap it there.
+	// This also allows the formula to return positive infinity safely.
+	resultFloat = math.Min(resultFloat, 1e9)
+	resultBig := arbmath.FloatToBig(resultFloat * params.GWei)
+	if resultBig == nil {
+		return nil, fmt.Errorf("maxFeeCapExpression evaluated to float64 not convertible to integer: %v", resultFloat)
+	}
+	if resultBig.Sign() < 0 {
+		return nil, fmt.Errorf("maxFeeCapExpression evaluated < 0: %v", resultFloat)
+	}
+	return resultBig, nil
+}
+
+var big4 = big.NewInt(4)
+
+func (s *FormulaFeeStrategy) FeeCaps(req *FeeRequest) (*big.Int, *big.Int, *big.Int, error) {
+	config := s.config()
+	nonce, gasLimit, numBlobs, lastTx := req.Nonce, req.GasLimit, req.NumBlobs, req.LastTx
+	latestHeader, currentBlobFee, softConfNonce := req.LatestHeader, req.CurrentBlobFee, req.SoftConfNonce
+
+	minTipCapGwei, maxTipCapGwei, minRbfIncrease := config.MinTipCapGwei, config.MaxTipCapGwei, minNonBlobRbfIncrease
+	if numBlobs > 0 {
+		minTipCapGwei, maxTipCapGwei, minRbfIncrease = config.MinBlobTxTipCapGwei, config.MaxBlobTxTipCapGwei, minBlobRbfIncrease
+	}
+	newTipCap := req.SuggestedTip
+	newTipCap = arbmath.BigMax(newTipCap, arbmath.FloatToBig(minTipCapGwei*params.GWei))
+	newTipCap = arbmath.BigMin(newTipCap, arbmath.FloatToBig(maxTipCapGwei*params.GWei))
+
+	// Compute the max fee with normalized gas so that blob txs aren't priced differently.
+	// Later, split the total cost bid into blob and non-blob fee caps.
+	elapsed := req.Now.Sub(req.DataCreatedAt)
+	maxNormalizedFeeCap, err := s.evalMaxFeeCapExpr(req.Backlog, elapsed)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	normalizedGas := gasLimit + numBlobs*blobs.BlobEncodableData*params.TxDataNonZeroGasEIP2028
+	targetMaxCost := arbmath.BigMulByUint(maxNormalizedFeeCap, normalizedGas)
+
+	maxMempoolWeight := arbmath.MinInt(config.MaxMempoolWeight, config.MaxMempoolTransactions)
+
+	latestBalance := req.Balance
+	balanceForTx := new(big.Int).Set(latestBalance)
+	weight := arbmath.MaxInt(1, numBlobs)
+	weightRemaining := weight
+
+	if config.AllocateMempoolBalance && !req.UsingNoOpStorage {
+		// We split the transaction weight into three groups:
+		// - The first weight point gets 1/2 of the balance.
+		// - The first half of the weight gets 1/3 of the balance split among them.
+		// - The remaining weight get the remaining 1/6 of the balance split among them.
+		// This helps ensure batch posting is reliable under a variety of fee conditions.
+		// With noop storage, we don't try to replace-by-fee, so we don't need to worry about this.
+		balancePerWeight := new(big.Int).Div(balanceForTx, common.Big2)
+		balanceForTx = big.NewInt(0)
+		if nonce == softConfNonce || maxMempoolWeight == 1 {
+			balanceForTx.Add(balanceForTx, balancePerWeight)
+			weightRemaining -= 1
+		}
+		if weightRemaining > 0 {
+			// Compared to dividing the remaining transactions by balance equally,
+			// the first half of transactions should get a 4/3 weight,
+			// and the remaining half should get a 2/3 weight.
+			// This makes sure the average weight is 1, and the first half of transactions
+			// have twice the weight of the second half of transactions.
+			// The +1 and -1 here are to account for the first transaction being handled separately.
+			if nonce > softConfNonce && nonce < softConfNonce+1+(maxMempoolWeight-1)/2 {
+				balancePerWeight.Mul(balancePerWeight, big4)
+			} else {
+				balancePerWeight.Mul(balancePerWeight, common.Big2)
+			}
+			balancePerWeight.Div(balancePerWeight, common.Big3)
+			// After weighting, split the balance between each of the transactions
+			// other than the first tx which already got half.
+			// balanceForTx /= config.MaxMempoolTransactions-1
+			balancePerWeight.Div(balancePerWeight, arbmath.UintToBig(maxMempoolWeight-1))
+			balanceForTx.Add(balanceForTx, arbmath.BigMulByUint(balancePerWeight, weight))
+		}
+	}
+
+	if arbmath.BigGreaterThan(targetMaxCost, balanceForTx) {
+		log.Warn(
+			"lack of L1 balance prevents posting transaction with desired fee cap",
+			"balance", latestBalance,
+			"weight", weight,
+			"maxMempoolWeight", maxMempoolWeight,
+			"balanceForTransaction", balanceForTx,
+			"gasLimit", gasLimit,
+			"targetMaxCost", targetMaxCost,
+			"nonce", nonce,
+			"softConfNonce", softConfNonce,
+		)
+		targetMaxCost = balanceForTx
+	}
+
+	if lastTx != nil {
+		// Replace by fee rules require that the tip cap is increased
+		newTipCap = arbmath.BigMax(newTipCap, arbmath.BigMulByBips(lastTx.GasTipCap(), minRbfIncrease))
+	}
+
+	// Divide the targetMaxCost into blob and non-blob costs.
+	currentNonBlobFee := arbmath.BigAdd(latestHeader.BaseFee, newTipCap)
+	blobGasUsed := params.BlobTxBlobGasPerBlob * numBlobs
+	currentBlobCost := arbmath.BigMulByUint(currentBlobFee, blobGasUsed)
+	currentNonBlobCost := arbmath.BigMulByUint(currentNonBlobFee, gasLimit)
+	newBlobFeeCap := arbmath.BigMul(targetMaxCost, currentBlobFee)
+	newBlobFeeCap.Div(newBlobFeeCap, arbmath.BigAdd(currentBlobCost, currentNonBlobCost))
+	if lastTx != nil && lastTx.BlobGasFeeCap() != nil {
+		newBlobFeeCap = arbmath.BigMax(newBlobFeeCap, arbmath.BigMulByBips(lastTx.BlobGasFeeCap(), minRbfIncrease))
+	}
+	targetBlobCost := arbmath.BigMulByUint(newBlobFeeCap, blobGasUsed)
+	targetNonBlobCost := arbmath.BigSub(targetMaxCost, targetBlobCost)
+	newBaseFeeCap := arbmath.BigDivByUint(targetNonBlobCost, gasLimit)
+	if lastTx != nil && numBlobs > 0 && lastTx.GasFeeCap().Sign() > 0 && arbmath.BigDivToBips(newBaseFeeCap, lastTx.GasFeeCap()) < minRbfIncrease {
+		// Increase the non-blob fee cap to the minimum rbf increase
+		newBaseFeeCap = arbmath.BigMulByBips(lastTx.GasFeeCap(), minRbfIncrease)
+		newNonBlobCost := arbmath.BigMulByUint(newBaseFeeCap, gasLimit)
+		// Increasing the non-blob fee cap requires lowering the blob fee cap to compensate
+		baseFeeCostIncrease := arbmath.BigSub(newNonBlobCost, targetNonBlobCost)
+		newBlobCost := arbmath.BigSub(targetBlobCost, baseFeeCostIncrease)
+		newBlobFeeCap = arbmath.BigDivByUint(newBlobCost, blobGasUsed)
+	}
+
+	if config.MaxFeeBidMultipleBips > 0 {
+		// Limit the fee caps to be no greater than max(MaxFeeBidMultipleBips, minRbf)
+		maxNonBlobFee := arbmath.BigMulByUBips(currentNonBlobFee, config.MaxFeeBidMultipleBips)
+		if lastTx != nil {
+			maxNonBlobFee = arbmath.BigMax(maxNonBlobFee, arbmath.BigMulByBips(lastTx.GasFeeCap(), minRbfIncrease))
+		}
+		maxBlobFee := arbmath.BigMulByUBips(currentBlobFee, config.MaxFeeBidMultipleBips)
+		if lastTx != nil && lastTx.BlobGasFeeCap() != nil {
+			maxBlobFee = arbmath.BigMax(maxBlobFee, arbmath.BigMulByBips(lastTx.BlobGasFeeCap(), minRbfIncrease))
+		}
+		newBaseFeeCap = arbmath.BigMin(newBaseFeeCap, maxNonBlobFee)
+		newBlobFeeCap = arbmath.BigMin(newBlobFeeCap, maxBlobFee)
+	}
+
+	if arbmath.BigGreaterThan(newTipCap, newBaseFeeCap) {
+		log.Info(
+			"reducing new tip cap to new basefee cap",
+			"proposedTipCap", newTipCap,
+			"newBasefeeCap", newBaseFeeCap,
+		)
+		newTipCap = new(big.Int).Set(newBaseFeeCap)
+	}
+
+	logFields := []any{
+		"targetMaxCost", targetMaxCost,
+		"elapsed", elapsed,
+		"dataPosterBacklog", req.Backlog,
+		"nonce", nonce,
+		"isReplacing", lastTx != nil,
+		"balanceForTx", balanceForTx,
+		"currentBaseFee", latestHeader.BaseFee,
+		"newBasefeeCap", newBaseFeeCap,
+		"suggestedTip", req.SuggestedTip,
+		"newTipCap", newTipCap,
+		"currentBlobFee", currentBlobFee,
+		"newBlobFeeCap", newBlobFeeCap,
+	}
+
+	log.Debug("calculated data poster fee and tip caps", logFields...)
+
+	if newBaseFeeCap.Sign() < 0 || newTipCap.Sign() < 0 || newBlobFeeCap.Sign() < 0 {
+		msg := "can't meet data poster fee cap obligations with current target max cost"
+		log.Info(msg, logFields...)
+		if lastTx != nil {
+			// wait until we have a higher target max cost to replace by fee
+			return lastTx.GasFeeCap(), lastTx.GasTipCap(), lastTx.BlobGasFeeCap(), nil
+		} else {
+			return nil, nil, nil, errors.New(msg)
+		}
+	}
+
+	if lastTx != nil && (arbmath.BigLessThan(newBaseFeeCap, currentNonBlobFee) || (numBlobs > 0 && arbmath.BigLessThan(newBlobFeeCap, currentBlobFee))) {
+		// Make sure our replace by fee can meet the current parent chain fee demands.
+		// Without this check, we'd blindly increase each fee component by the min rbf amount each time,
+		// without looking at which component(s) actually need increased.
+		// E.g. instead of 2x basefee and 2x blobfee, we might actually want to 4x basefee and 2x blobfee.
+		// This check lets us hold off on the rbf until we are actually meet the current fee requirements,
+		// which lets us move in a particular direction (biasing towards either basefee or blobfee).
+		log.Info("can't meet current parent chain fees with current target max cost", logFields...)
+		// wait until we have a higher target max cost to replace by fee
+		return lastTx.GasFeeCap(), lastTx.GasTipCap(), lastTx.BlobGasFeeCap(), nil
+	}
+
+	// Ensure we bid at least 1 wei to prevent division by zero
+	if newBaseFeeCap.Sign() == 0 {
+		newBaseFeeCap = big.NewInt(1)
+	}
+	if newBlobFeeCap.Sign() == 0 {
+		newBlobFeeCap = big.NewInt(1)
+	}
+
+	return newBaseFeeCap, newTipCap, newBlobFeeCap, nil
+}
+
+// isReplacementByFee returns whether the new fee caps are a big enough
+// increase over those of prevTx to replace it by fee.
+func isReplacementByFee(prevTx *types.Transaction, newFeeCap, newBlobFeeCap *big.Int) bool {
+	minRbfIncrease := minNonBlobRbfIncrease
+	if len(prevTx.BlobHashes()) > 0 {
+		minRbfIncrease = minBlobRbfIncrease
+	}
+	if prevTx.GasFeeCap().Sign() > 0 && arbmath.BigDivToBips(newFeeCap, prevTx.GasFeeCap()) < minRbfIncrease {
+		return false
+	}
+	if prevTx.BlobGasFeeCap() != nil && prevTx.BlobGasFeeCap().Sign() > 0 && arbmath.BigDivToBips(newBlobFeeCap, prevTx.BlobGasFeeCap()) < minRbfIncrease {
+		return false
+	}
+	return true
+}
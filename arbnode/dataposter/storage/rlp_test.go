@@ -118,3 +118,44 @@ func TestNewQueuedTransactionEncoding(t *testing.T) {
 		t.Fatalf("created %v encoded then decoded to %v", oldTx.Created, dec.Created)
 	}
 }
+
+func TestCancelledQueuedTransactionEncoding(t *testing.T) {
+	weight := uint64(7)
+	tx := &QueuedTransaction{
+		FullTx:                 types.NewTx(&types.DynamicFeeTx{}),
+		Meta:                   []byte{0},
+		Created:                time.Now(),
+		StoredCumulativeWeight: &weight,
+		Cancelled:              true,
+	}
+
+	enc, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal("failed to encode cancelled queued tx", err)
+	}
+	var dec QueuedTransaction
+	err = rlp.DecodeBytes(enc, &dec)
+	if err != nil {
+		t.Fatal("failed to decode cancelled queued tx", err)
+	}
+	if !dec.Cancelled {
+		t.Fatal("cancelled queued tx decoded as not cancelled")
+	}
+	if dec.CumulativeWeight() != weight {
+		t.Fatalf("cumulative weight %v encoded then decoded to %v", weight, dec.CumulativeWeight())
+	}
+
+	// Items stored before the cancelled flag existed aren't cancelled
+	tx.Cancelled = false
+	enc, err = rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal("failed to encode queued tx", err)
+	}
+	err = rlp.DecodeBytes(enc, &dec)
+	if err != nil {
+		t.Fatal("failed to decode queued tx", err)
+	}
+	if dec.Cancelled {
+		t.Fatal("queued tx decoded as cancelled")
+	}
+}
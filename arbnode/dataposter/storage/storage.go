@@ -34,6 +34,9 @@ type QueuedTransaction struct {
 	Created                time.Time // may be earlier than the tx was given to the tx poster
 	NextReplacement        time.Time
 	StoredCumulativeWeight *uint64
+	// Cancelled is set if FullTx is a zero value transfer to the sender
+	// replacing the transaction originally queued with this nonce.
+	Cancelled bool
 }
 
 // CumulativeWeight returns a rough estimate of the total number of batches submitted at this point, not guaranteed to be exact
@@ -52,6 +55,7 @@ type queuedTransactionForEncoding struct {
 	Created                RlpTime
 	NextReplacement        RlpTime
 	StoredCumulativeWeight *uint64 `rlp:"optional"`
+	Cancelled              bool    `rlp:"optional"`
 }
 
 func (qt *QueuedTransaction) EncodeRLP(w io.Writer) error {
@@ -63,6 +67,7 @@ func (qt *QueuedTransaction) EncodeRLP(w io.Writer) error {
 		Created:                (RlpTime)(qt.Created),
 		NextReplacement:        (RlpTime)(qt.NextReplacement),
 		StoredCumulativeWeight: qt.StoredCumulativeWeight,
+		Cancelled:              qt.Cancelled,
 	})
 }
 
@@ -78,6 +83,7 @@ func (qt *QueuedTransaction) DecodeRLP(s *rlp.Stream) error {
 	qt.Created = time.Time(qtEnc.Created)
 	qt.NextReplacement = time.Time(qtEnc.NextReplacement)
 	qt.StoredCumulativeWeight = qtEnc.StoredCumulativeWeight
+	qt.Cancelled = qtEnc.Cancelled
 	return nil
 }
 
@@ -0,0 +1,228 @@
+package melrunner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/offchainlabs/nitro/arbnode/db-schema"
+	"github.com/offchainlabs/nitro/arbnode/mel"
+	"github.com/offchainlabs/nitro/cmd/chaininfo"
+)
+
+const snapshotVersion uint64 = 1
+
+var ErrDatabaseNotEmpty = errors.New("database already has a head mel state")
+
+// BatchCountFetcher reads the batch count of the parent chain's sequencer inbox, see
+// arbnode.SequencerInbox.
+type BatchCountFetcher interface {
+	GetBatchCount(ctx context.Context, blockNumber *big.Int) (uint64, error)
+}
+
+// DelayedBridgeReader reads the delayed inbox of the parent chain's bridge, see arbnode.DelayedBridge.
+// GetAccumulator uses blockHash if nonzero, otherwise uses blockNumber.
+type DelayedBridgeReader interface {
+	GetMessageCount(ctx context.Context, blockNumber *big.Int) (uint64, error)
+	GetAccumulator(ctx context.Context, sequenceNumber uint64, blockNumber *big.Int, blockHash common.Hash) (common.Hash, error)
+}
+
+// SnapshotVerifier checks a snapshot's MEL state against what the parent chain commits to at the
+// state's parent chain block. The parent chain doesn't commit to the MsgCount and MessageAccumulator
+// of the state, these are checked against StateHash, the hash of the state as published by a
+// trusted source along with the snapshot.
+type SnapshotVerifier struct {
+	ParentChainReader ParentChainReader
+	RollupAddrs       *chaininfo.RollupAddresses
+	SequencerInbox    BatchCountFetcher
+	DelayedBridge     DelayedBridgeReader
+	StateHash         common.Hash
+}
+
+// Snapshot is a portable checkpoint of message extraction at a parent chain block. It holds
+// the MEL state along with the delayed messages it has seen but not read yet, which is all
+// that's needed for a fresh node to continue message extraction from that block.
+type Snapshot struct {
+	Version         uint64
+	State           mel.State
+	DelayedMessages []*mel.DelayedInboxMessage
+}
+
+// ExportSnapshot writes the RLP encoded snapshot of the MEL state at the given parent chain block to w,
+// after verifying it against the parent chain. It returns the hash of the exported state, which is to
+// be published along with the snapshot for nodes importing it to check its MsgCount and
+// MessageAccumulator. The verifier's StateHash is ignored.
+func (d *Database) ExportSnapshot(ctx context.Context, parentChainBlockNumber uint64, w io.Writer, verifier *SnapshotVerifier) (common.Hash, error) {
+	state, err := d.State(ctx, parentChainBlockNumber)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error getting mel state at parent chain block %d: %w", parentChainBlockNumber, err)
+	}
+	if state.DelayedMessagesRead > state.DelayedMessagedSeen {
+		return common.Hash{}, fmt.Errorf("mel state's DelayedMessagesRead: %d is higher than DelayedMessagedSeen: %d", state.DelayedMessagesRead, state.DelayedMessagedSeen)
+	}
+	snapshot := &Snapshot{
+		Version:         snapshotVersion,
+		State:           *state,
+		DelayedMessages: make([]*mel.DelayedInboxMessage, 0, state.DelayedMessagedSeen-state.DelayedMessagesRead),
+	}
+	for index := state.DelayedMessagesRead; index < state.DelayedMessagedSeen; index++ {
+		delayed, err := d.fetchDelayedMessage(index)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("error fetching delayed message %d: %w", index, err)
+		}
+		snapshot.DelayedMessages = append(snapshot.DelayedMessages, delayed)
+	}
+	if err := snapshot.verify(ctx, d); err != nil {
+		return common.Hash{}, err
+	}
+	if err := snapshot.verifyParentChain(ctx, verifier); err != nil {
+		return common.Hash{}, err
+	}
+	if err := rlp.Encode(w, snapshot); err != nil {
+		return common.Hash{}, err
+	}
+	return state.Hash(), nil
+}
+
+// ImportSnapshot reads a snapshot written by ExportSnapshot from r and stores it as the head MEL
+// state, after verifying its state against the verifier's StateHash and the parent chain at the
+// state's parent chain block, and its delayed messages against the delayed inbox accumulator of the
+// parent chain's bridge. The database must not have a head MEL state yet. The message extractor
+// should then be started from the returned state's parent chain block hash.
+func (d *Database) ImportSnapshot(ctx context.Context, r io.Reader, verifier *SnapshotVerifier) (*mel.State, error) {
+	hasHead, err := d.db.Has(dbschema.HeadMelStateBlockNumKey)
+	if err != nil {
+		return nil, err
+	}
+	if hasHead {
+		return nil, ErrDatabaseNotEmpty
+	}
+	var snapshot Snapshot
+	if err := rlp.Decode(r, &snapshot); err != nil {
+		return nil, fmt.Errorf("error decoding mel snapshot: %w", err)
+	}
+	if snapshot.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported mel snapshot version %d, expected %d", snapshot.Version, snapshotVersion)
+	}
+	if stateHash := snapshot.State.Hash(); stateHash != verifier.StateHash {
+		return nil, fmt.Errorf("mel snapshot state has hash %v, expected %v", stateHash, verifier.StateHash)
+	}
+	if err := snapshot.verify(ctx, d); err != nil {
+		return nil, err
+	}
+	if err := snapshot.verifyParentChain(ctx, verifier); err != nil {
+		return nil, err
+	}
+	state := &snapshot.State
+	dbBatch := d.db.NewBatch()
+	for i, delayed := range snapshot.DelayedMessages {
+		key := dbKey(dbschema.MelDelayedMessagePrefix, state.DelayedMessagesRead+uint64(i)) // #nosec G115
+		delayedBytes, err := rlp.EncodeToBytes(*delayed)
+		if err != nil {
+			return nil, err
+		}
+		if err := dbBatch.Put(key, delayedBytes); err != nil {
+			return nil, err
+		}
+	}
+	if err := d.setMelState(dbBatch, state.ParentChainBlockNumber, *state); err != nil {
+		return nil, err
+	}
+	if err := d.setHeadMelStateBlockNum(dbBatch, state.ParentChainBlockNumber); err != nil {
+		return nil, err
+	}
+	if err := dbBatch.Write(); err != nil {
+		return nil, err
+	}
+	log.Info("Imported mel snapshot", "parentChainBlockNumber", state.ParentChainBlockNumber, "parentChainBlockHash", state.ParentChainBlockHash, "delayedMessages", len(snapshot.DelayedMessages))
+	return state, nil
+}
+
+// verify checks that the snapshot holds exactly the unread delayed messages of its state, and that
+// they form a chain of delayed inbox accumulators. On its own this doesn't tell whether the chain is
+// the parent chain's one, see verifyParentChain.
+func (s *Snapshot) verify(ctx context.Context, d *Database) error {
+	state := &s.State
+	if state.DelayedMessagesRead > state.DelayedMessagedSeen {
+		return fmt.Errorf("mel state's DelayedMessagesRead: %d is higher than DelayedMessagedSeen: %d", state.DelayedMessagesRead, state.DelayedMessagedSeen)
+	}
+	if unread := state.DelayedMessagedSeen - state.DelayedMessagesRead; uint64(len(s.DelayedMessages)) != unread {
+		return fmt.Errorf("mel snapshot has %d delayed messages, expected %d unread delayed messages", len(s.DelayedMessages), unread)
+	}
+	for i, delayed := range s.DelayedMessages {
+		index := state.DelayedMessagesRead + uint64(i) // #nosec G115
+		if delayed == nil || delayed.Message == nil || delayed.Message.Header == nil || delayed.Message.Header.RequestId == nil || delayed.Message.Header.L1BaseFee == nil {
+			return fmt.Errorf("mel snapshot delayed message %d is incomplete", index)
+		}
+		if i > 0 {
+			if prevAcc := s.DelayedMessages[i-1].AfterInboxAcc(); delayed.BeforeInboxAcc != prevAcc {
+				return fmt.Errorf("mel snapshot delayed message %d has before inbox accumulator %v, expected %v", index, delayed.BeforeInboxAcc, prevAcc)
+			}
+		}
+		if ok, err := d.checkAgainstAccumulator(ctx, state, delayed, index); err != nil {
+			return fmt.Errorf("error checking if delayed message %d is part of the mel state accumulator: %w", index, err)
+		} else if !ok {
+			return fmt.Errorf("mel snapshot delayed message %d not part of the mel state accumulator", index)
+		}
+	}
+	return nil
+}
+
+// verifyParentChain checks that the snapshot's state was extracted from the parent chain block it
+// claims, from the configured rollup's contracts, and that its counts of batches and delayed messages
+// seen are those of the parent chain at that block. It also checks that the accumulator after the
+// last delayed message seen is the one of the parent chain's bridge. As the snapshot's delayed
+// messages form a chain of accumulators, this anchors all of them, including the first one's
+// BeforeInboxAcc.
+func (s *Snapshot) verifyParentChain(ctx context.Context, verifier *SnapshotVerifier) error {
+	state := &s.State
+	blockNumber := new(big.Int).SetUint64(state.ParentChainBlockNumber)
+	block, err := verifier.ParentChainReader.BlockByNumber(ctx, blockNumber)
+	if err != nil {
+		return fmt.Errorf("error getting parent chain block %d: %w", state.ParentChainBlockNumber, err)
+	}
+	if block.Hash() != state.ParentChainBlockHash {
+		return fmt.Errorf("mel snapshot state has parent chain block hash %v, but parent chain block %d has hash %v", state.ParentChainBlockHash, state.ParentChainBlockNumber, block.Hash())
+	}
+	if block.ParentHash() != state.ParentChainPreviousBlockHash {
+		return fmt.Errorf("mel snapshot state has previous parent chain block hash %v, but parent chain block %d has parent hash %v", state.ParentChainPreviousBlockHash, state.ParentChainBlockNumber, block.ParentHash())
+	}
+	if state.BatchPostingTargetAddress != verifier.RollupAddrs.SequencerInbox {
+		return fmt.Errorf("mel snapshot state has batch posting target %v, expected the sequencer inbox %v", state.BatchPostingTargetAddress, verifier.RollupAddrs.SequencerInbox)
+	}
+	if state.DelayedMessagePostingTargetAddress != verifier.RollupAddrs.Bridge {
+		return fmt.Errorf("mel snapshot state has delayed message posting target %v, expected the bridge %v", state.DelayedMessagePostingTargetAddress, verifier.RollupAddrs.Bridge)
+	}
+	batchCount, err := verifier.SequencerInbox.GetBatchCount(ctx, blockNumber)
+	if err != nil {
+		return fmt.Errorf("error getting batch count at parent chain block %d: %w", state.ParentChainBlockNumber, err)
+	}
+	if batchCount != state.BatchCount {
+		return fmt.Errorf("mel snapshot state has BatchCount: %d, but the parent chain has %d", state.BatchCount, batchCount)
+	}
+	delayedCount, err := verifier.DelayedBridge.GetMessageCount(ctx, blockNumber)
+	if err != nil {
+		return fmt.Errorf("error getting delayed message count at parent chain block %d: %w", state.ParentChainBlockNumber, err)
+	}
+	if delayedCount != state.DelayedMessagedSeen {
+		return fmt.Errorf("mel snapshot state has DelayedMessagedSeen: %d, but the parent chain has %d", state.DelayedMessagedSeen, delayedCount)
+	}
+	if len(s.DelayedMessages) == 0 {
+		return nil
+	}
+	lastIndex := state.DelayedMessagedSeen - 1
+	expected, err := verifier.DelayedBridge.GetAccumulator(ctx, lastIndex, blockNumber, state.ParentChainBlockHash)
+	if err != nil {
+		return fmt.Errorf("error getting delayed inbox accumulator %d at parent chain block %d: %w", lastIndex, state.ParentChainBlockNumber, err)
+	}
+	if acc := s.DelayedMessages[len(s.DelayedMessages)-1].AfterInboxAcc(); acc != expected {
+		return fmt.Errorf("mel snapshot delayed message %d has inbox accumulator %v, but the parent chain has %v", lastIndex, acc, expected)
+	}
+	return nil
+}
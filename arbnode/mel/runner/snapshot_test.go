@@ -0,0 +1,269 @@
+package melrunner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/offchainlabs/nitro/arbnode/mel"
+	"github.com/offchainlabs/nitro/arbos/arbostypes"
+	"github.com/offchainlabs/nitro/cmd/chaininfo"
+)
+
+type testSequencerInbox struct {
+	batchCount uint64
+}
+
+func (i *testSequencerInbox) GetBatchCount(_ context.Context, _ *big.Int) (uint64, error) {
+	return i.batchCount, nil
+}
+
+type testDelayedBridge struct {
+	blockHash common.Hash
+	accs      []common.Hash
+}
+
+func (b *testDelayedBridge) GetMessageCount(_ context.Context, _ *big.Int) (uint64, error) {
+	return uint64(len(b.accs)), nil
+}
+
+func (b *testDelayedBridge) GetAccumulator(_ context.Context, sequenceNumber uint64, _ *big.Int, blockHash common.Hash) (common.Hash, error) {
+	if blockHash != b.blockHash || sequenceNumber >= uint64(len(b.accs)) {
+		return common.Hash{}, errors.New("no delayed inbox accumulator")
+	}
+	return b.accs[sequenceNumber], nil
+}
+
+func exportTestSnapshot(t *testing.T, ctx context.Context, state *mel.State, delayedMessages []*mel.DelayedInboxMessage, verifier *SnapshotVerifier) ([]byte, common.Hash) {
+	t.Helper()
+	melDb := NewDatabase(rawdb.NewMemoryDatabase())
+	require.NoError(t, melDb.SaveDelayedMessages(ctx, state, delayedMessages))
+	require.NoError(t, melDb.SaveState(ctx, state))
+	var snapshot bytes.Buffer
+	stateHash, err := melDb.ExportSnapshot(ctx, state.ParentChainBlockNumber, &snapshot, verifier)
+	require.NoError(t, err)
+	require.Equal(t, state.Hash(), stateHash)
+	return snapshot.Bytes(), stateHash
+}
+
+func TestMelSnapshot(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parentBlock := types.NewBlockWithHeader(&types.Header{
+		Number:     big.NewInt(10),
+		ParentHash: common.HexToHash("0x1234"),
+	})
+	rollupAddrs := &chaininfo.RollupAddresses{
+		Bridge:         common.HexToAddress("0x1111"),
+		SequencerInbox: common.HexToAddress("0x2222"),
+	}
+	delayedBridge := &testDelayedBridge{blockHash: parentBlock.Hash()}
+	verifier := &SnapshotVerifier{
+		ParentChainReader: &mockParentChainReader{
+			blocks: map[common.Hash]*types.Block{
+				common.BigToHash(parentBlock.Number()): parentBlock,
+			},
+		},
+		RollupAddrs:    rollupAddrs,
+		SequencerInbox: &testSequencerInbox{batchCount: 3},
+		DelayedBridge:  delayedBridge,
+	}
+	var delayedMessages []*mel.DelayedInboxMessage
+	var acc common.Hash
+	for i := uint64(0); i < 5; i++ {
+		requestId := common.BigToHash(new(big.Int).SetUint64(i))
+		delayed := &mel.DelayedInboxMessage{
+			BeforeInboxAcc: acc,
+			Message: &arbostypes.L1IncomingMessage{
+				Header: &arbostypes.L1IncomingMessageHeader{
+					Kind:      arbostypes.L1MessageType_EndOfBlock,
+					RequestId: &requestId,
+					L1BaseFee: big.NewInt(0),
+				},
+				L2msg: []byte{byte(i)},
+			},
+			ParentChainBlockNumber: 10,
+		}
+		acc = delayed.AfterInboxAcc()
+		delayedBridge.accs = append(delayedBridge.accs, acc)
+		delayedMessages = append(delayedMessages, delayed)
+	}
+	headMelState := &mel.State{
+		ParentChainBlockNumber:             10,
+		BatchPostingTargetAddress:          rollupAddrs.SequencerInbox,
+		DelayedMessagePostingTargetAddress: rollupAddrs.Bridge,
+		ParentChainBlockHash:               parentBlock.Hash(),
+		ParentChainPreviousBlockHash:       parentBlock.ParentHash(),
+		MessageAccumulator:                 common.HexToHash("0x5678"),
+		MsgCount:                           7,
+		BatchCount:                         3,
+		DelayedMessagesRead:                2,
+		DelayedMessagedSeen:                5,
+	}
+	melDb := NewDatabase(rawdb.NewMemoryDatabase())
+	require.NoError(t, melDb.SaveDelayedMessages(ctx, headMelState, delayedMessages))
+	require.NoError(t, melDb.SaveState(ctx, headMelState))
+
+	var snapshot bytes.Buffer
+	stateHash, err := melDb.ExportSnapshot(ctx, headMelState.ParentChainBlockNumber, &snapshot, verifier)
+	require.NoError(t, err)
+	require.Equal(t, headMelState.Hash(), stateHash)
+	snapshotBytes := snapshot.Bytes()
+	verifier.StateHash = stateHash
+
+	// Importing into a database that already has a head mel state fails
+	_, err = melDb.ImportSnapshot(ctx, bytes.NewReader(snapshotBytes), verifier)
+	require.ErrorIs(t, err, ErrDatabaseNotEmpty)
+
+	freshDb := NewDatabase(rawdb.NewMemoryDatabase())
+	imported, err := freshDb.ImportSnapshot(ctx, bytes.NewReader(snapshotBytes), verifier)
+	require.NoError(t, err)
+	require.True(t, reflect.DeepEqual(imported, headMelState))
+	head, err := freshDb.GetHeadMelState(ctx)
+	require.NoError(t, err)
+	require.True(t, reflect.DeepEqual(head, headMelState))
+	for i := headMelState.DelayedMessagesRead; i < headMelState.DelayedMessagedSeen; i++ {
+		delayed, err := freshDb.ReadDelayedMessage(ctx, head, i)
+		require.NoError(t, err)
+		require.Equal(t, delayedMessages[i].AfterInboxAcc(), delayed.AfterInboxAcc())
+	}
+	// Delayed messages already read aren't part of the snapshot
+	_, err = freshDb.fetchDelayedMessage(1)
+	require.Error(t, err)
+
+	// A snapshot whose delayed messages don't form an accumulator chain is rejected
+	var tampered Snapshot
+	require.NoError(t, rlp.DecodeBytes(snapshotBytes, &tampered))
+	tampered.DelayedMessages[1].Message.L2msg = []byte("tampered")
+	tamperedBytes, err := rlp.EncodeToBytes(&tampered)
+	require.NoError(t, err)
+	_, err = NewDatabase(rawdb.NewMemoryDatabase()).ImportSnapshot(ctx, bytes.NewReader(tamperedBytes), verifier)
+	require.ErrorContains(t, err, "before inbox accumulator")
+
+	// A tampered last delayed message still forms a chain, but doesn't match the parent chain
+	require.NoError(t, rlp.DecodeBytes(snapshotBytes, &tampered))
+	tampered.DelayedMessages[2].Message.L2msg = []byte("tampered")
+	tamperedBytes, err = rlp.EncodeToBytes(&tampered)
+	require.NoError(t, err)
+	_, err = NewDatabase(rawdb.NewMemoryDatabase()).ImportSnapshot(ctx, bytes.NewReader(tamperedBytes), verifier)
+	require.ErrorContains(t, err, "but the parent chain has")
+
+	// A snapshot missing delayed messages is rejected as well
+	tampered.DelayedMessages = tampered.DelayedMessages[:2]
+	tamperedBytes, err = rlp.EncodeToBytes(&tampered)
+	require.NoError(t, err)
+	_, err = NewDatabase(rawdb.NewMemoryDatabase()).ImportSnapshot(ctx, bytes.NewReader(tamperedBytes), verifier)
+	require.ErrorContains(t, err, "expected 3 unread delayed messages")
+
+	// The state is checked against the published hash, as the parent chain doesn't commit to its
+	// MsgCount and MessageAccumulator
+	require.NoError(t, rlp.DecodeBytes(snapshotBytes, &tampered))
+	tampered.State.MsgCount++
+	tamperedBytes, err = rlp.EncodeToBytes(&tampered)
+	require.NoError(t, err)
+	_, err = NewDatabase(rawdb.NewMemoryDatabase()).ImportSnapshot(ctx, bytes.NewReader(tamperedBytes), verifier)
+	require.ErrorContains(t, err, "mel snapshot state has hash")
+	require.NoError(t, rlp.DecodeBytes(snapshotBytes, &tampered))
+	tampered.State.MessageAccumulator = common.Hash{}
+	tamperedBytes, err = rlp.EncodeToBytes(&tampered)
+	require.NoError(t, err)
+	_, err = NewDatabase(rawdb.NewMemoryDatabase()).ImportSnapshot(ctx, bytes.NewReader(tamperedBytes), verifier)
+	require.ErrorContains(t, err, "mel snapshot state has hash")
+}
+
+func TestMelSnapshotParentChainVerification(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	parentBlock := types.NewBlockWithHeader(&types.Header{
+		Number:     big.NewInt(20),
+		ParentHash: common.HexToHash("0x1234"),
+	})
+	rollupAddrs := &chaininfo.RollupAddresses{
+		Bridge:         common.HexToAddress("0x1111"),
+		SequencerInbox: common.HexToAddress("0x2222"),
+	}
+	// All the delayed messages seen have been read, so the snapshot has no delayed messages
+	newVerifier := func() *SnapshotVerifier {
+		return &SnapshotVerifier{
+			ParentChainReader: &mockParentChainReader{
+				blocks: map[common.Hash]*types.Block{
+					common.BigToHash(parentBlock.Number()): parentBlock,
+				},
+			},
+			RollupAddrs:    rollupAddrs,
+			SequencerInbox: &testSequencerInbox{batchCount: 4},
+			DelayedBridge: &testDelayedBridge{
+				blockHash: parentBlock.Hash(),
+				accs:      []common.Hash{{1}, {2}},
+			},
+		}
+	}
+	newState := func() *mel.State {
+		return &mel.State{
+			ParentChainBlockNumber:             20,
+			BatchPostingTargetAddress:          rollupAddrs.SequencerInbox,
+			DelayedMessagePostingTargetAddress: rollupAddrs.Bridge,
+			ParentChainBlockHash:               parentBlock.Hash(),
+			ParentChainPreviousBlockHash:       parentBlock.ParentHash(),
+			MsgCount:                           9,
+			BatchCount:                         4,
+			DelayedMessagesRead:                2,
+			DelayedMessagedSeen:                2,
+		}
+	}
+	verifier := newVerifier()
+	snapshotBytes, stateHash := exportTestSnapshot(t, ctx, newState(), nil, verifier)
+	verifier.StateHash = stateHash
+	imported, err := NewDatabase(rawdb.NewMemoryDatabase()).ImportSnapshot(ctx, bytes.NewReader(snapshotBytes), verifier)
+	require.NoError(t, err)
+	require.True(t, reflect.DeepEqual(imported, newState()))
+
+	for _, tc := range []struct {
+		name   string
+		tamper func(*mel.State)
+		errMsg string
+	}{
+		{"block hash", func(s *mel.State) { s.ParentChainBlockHash = common.HexToHash("0xdead") }, "parent chain block hash"},
+		{"previous block hash", func(s *mel.State) { s.ParentChainPreviousBlockHash = common.HexToHash("0xdead") }, "previous parent chain block hash"},
+		{"missing block", func(s *mel.State) { s.ParentChainBlockNumber++ }, "error getting parent chain block"},
+		{"sequencer inbox", func(s *mel.State) { s.BatchPostingTargetAddress = common.HexToAddress("0xdead") }, "expected the sequencer inbox"},
+		{"bridge", func(s *mel.State) { s.DelayedMessagePostingTargetAddress = common.HexToAddress("0xdead") }, "expected the bridge"},
+		{"batch count", func(s *mel.State) { s.BatchCount++ }, "BatchCount: 5, but the parent chain has 4"},
+		{"delayed messages seen", func(s *mel.State) { s.DelayedMessagesRead--; s.DelayedMessagedSeen-- }, "DelayedMessagedSeen: 1, but the parent chain has 2"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			state := newState()
+			tc.tamper(state)
+			// Exporting an inconsistent state fails as well
+			melDb := NewDatabase(rawdb.NewMemoryDatabase())
+			require.NoError(t, melDb.SaveState(ctx, state))
+			_, err := melDb.ExportSnapshot(ctx, state.ParentChainBlockNumber, io.Discard, newVerifier())
+			require.ErrorContains(t, err, tc.errMsg)
+
+			// A snapshot of it with the matching hash is still rejected on import
+			tamperedBytes, err := rlp.EncodeToBytes(&Snapshot{Version: snapshotVersion, State: *state})
+			require.NoError(t, err)
+			verifier := newVerifier()
+			verifier.StateHash = state.Hash()
+			_, err = NewDatabase(rawdb.NewMemoryDatabase()).ImportSnapshot(ctx, bytes.NewReader(tamperedBytes), verifier)
+			require.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
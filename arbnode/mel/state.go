@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/offchainlabs/nitro/arbos/arbostypes"
 )
@@ -96,6 +98,16 @@ func (s *State) Clone() *State {
 	}
 }
 
+// Hash returns the keccak256 hash of the RLP encoded state.
+func (s *State) Hash() common.Hash {
+	encoded, err := rlp.EncodeToBytes(s)
+	if err != nil {
+		// The state only has fixed size fields, so it always encodes.
+		panic(err)
+	}
+	return crypto.Keccak256Hash(encoded)
+}
+
 func (s *State) AccumulateMessage(msg *arbostypes.MessageWithMetadata) error {
 	// TODO: Unimplemented.
 	return nil
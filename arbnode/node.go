@@ -813,6 +813,10 @@ func getTransactionStreamer(
 	if err != nil {
 		return nil, err
 	}
+	if broadcastServer != nil {
+		// Feed clients behind the backlog are caught up from the database if serve-history is enabled
+		broadcastServer.SetHistory(txStreamer)
+	}
 	return txStreamer, nil
 }
 
@@ -1218,6 +1222,22 @@ func registerAPIs(currentNode *Node, stack *node.Node) {
 			Public:    false,
 		})
 	}
+	if currentNode.InboxReader != nil {
+		apis = append(apis, rpc.API{
+			Namespace: "arb",
+			Version:   "1.0",
+			Service:   &InboxAPI{inboxReader: currentNode.InboxReader},
+			Public:    false,
+		})
+	}
+	if currentNode.BatchPoster != nil {
+		apis = append(apis, rpc.API{
+			Namespace: "arbdataposter",
+			Version:   "1.0",
+			Service:   &DataPosterAPI{dataPoster: currentNode.BatchPoster.dataPoster},
+			Public:    false,
+		})
+	}
 	if currentNode.StatelessBlockValidator != nil {
 		apis = append(apis, rpc.API{
 			Namespace: "arbdebug",
@@ -533,6 +533,27 @@ func (s *TransactionStreamer) getMessageWithMetadataAndBlockInfo(msgIdx arbutil.
 	return &msgWithBlockInfo, nil
 }
 
+// GetFeedMessages returns the messages from start to end, inclusive, as feed
+// messages to catch up feed clients that are behind the broadcaster's backlog.
+func (s *TransactionStreamer) GetFeedMessages(start, end arbutil.MessageIndex) ([]*message.BroadcastFeedMessage, error) {
+	if s.broadcastServer == nil {
+		return nil, errors.New("transaction streamer has no broadcast server")
+	}
+	feedMessages := make([]*message.BroadcastFeedMessage, 0, end-start+1)
+	for msgIdx := start; msgIdx <= end; msgIdx++ {
+		msg, err := s.getMessageWithMetadataAndBlockInfo(msgIdx)
+		if err != nil {
+			return nil, err
+		}
+		feedMessage, err := s.broadcastServer.NewBroadcastFeedMessage(msg.MessageWithMeta, msgIdx, msg.BlockHash, msg.BlockMetadata)
+		if err != nil {
+			return nil, err
+		}
+		feedMessages = append(feedMessages, feedMessage)
+	}
+	return feedMessages, nil
+}
+
 // Note: if changed to acquire the mutex, some internal users may need to be updated to a non-locking version.
 func (s *TransactionStreamer) GetMessageCount() (arbutil.MessageIndex, error) {
 	countBytes, err := s.db.Get(messageCountKey)
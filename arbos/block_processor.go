@@ -371,7 +371,9 @@ func ProduceBlockAdvanced(
 
 			gasPool := gethGas
 			blockContext := core.NewEVMBlockContext(header, chainContext, &header.Coinbase)
-			evm := vm.NewEVM(blockContext, statedb, chainConfig, vm.Config{ExposeMultiGas: exposeMultiGas})
+			// the resource constraints price the multi-dimensional gas used, so it must be tracked
+			trackMultiGas := exposeMultiGas || arbosVersion >= params.ArbosVersion_60
+			evm := vm.NewEVM(blockContext, statedb, chainConfig, vm.Config{ExposeMultiGas: trackMultiGas})
 			receipt, result, err := core.ApplyTransactionWithResultFilter(
 				evm,
 				&gasPool,
@@ -461,6 +463,14 @@ func ProduceBlockAdvanced(
 			}
 		}
 
+		if arbosVer >= params.ArbosVersion_60 {
+			// The tx processing hooks don't see the multi-dimensional gas used by the tx,
+			// so it's added to the resource constraint backlogs once the receipt is known.
+			if err := addToResourceBacklogs(statedb, receipt, result.ScheduledTxes); err != nil {
+				return nil, nil, err
+			}
+		}
+
 		// Update expectedTotalBalanceDelta (also done in logs loop)
 		switch txInner := tx.GetInner().(type) {
 		case *types.ArbitrumDepositTx:
@@ -565,6 +575,28 @@ func ProduceBlockAdvanced(
 	return block, receipts, nil
 }
 
+// addToResourceBacklogs adds the multi-dimensional gas used by a transaction to the backlogs of
+// the resource constraints, if the chain prices gas by them.
+func addToResourceBacklogs(statedb *state.StateDB, receipt *types.Receipt, scheduledTxes types.Transactions) error {
+	arbState, err := arbosState.OpenSystemArbosState(statedb, nil, false)
+	if err != nil {
+		return err
+	}
+	l2Pricing := arbState.L2PricingState()
+	useConstraints, err := l2Pricing.UsesResourceConstraints()
+	if err != nil || !useConstraints {
+		return err
+	}
+	// the gas donated to redeems is used by the retry txs themselves
+	var donatedGas uint64
+	for _, scheduledTx := range scheduledTxes {
+		if inner, ok := scheduledTx.GetInner().(*types.ArbitrumRetryTx); ok {
+			donatedGas = arbmath.SaturatingUAdd(donatedGas, inner.Gas)
+		}
+	}
+	return l2Pricing.AddToResourceBacklogs(receipt.MultiGasUsed, donatedGas)
+}
+
 // Also sets header.Root
 func FinalizeBlock(header *types.Header, txs types.Transactions, statedb vm.StateDB, chainConfig *params.ChainConfig) {
 	if header != nil {
@@ -5,18 +5,23 @@
 package constraints
 
 import (
+	"slices"
 	"time"
 
 	"github.com/ethereum/go-ethereum/arbitrum/multigas"
+
+	"github.com/offchainlabs/nitro/util/arbmath"
 )
 
 // The period duration for a resource constraint.
 type PeriodSecs uint32
 
 // resourceConstraint defines the max gas target per second for the given period for a single resource.
+// The backlog is the gas used above the target, which is paid off as time passes.
 type resourceConstraint struct {
-	period time.Duration
-	target uint64
+	period  time.Duration
+	target  uint64
+	backlog uint64
 }
 
 // ResourceConstraints is a set of constraints for all resources.
@@ -46,8 +51,9 @@ func (rc ResourceConstraints) SetConstraint(
 	resource multigas.ResourceKind, periodSecs PeriodSecs, targetPerPeriod uint64,
 ) {
 	rc[resource][periodSecs] = resourceConstraint{
-		period: time.Duration(periodSecs) * time.Second,
-		target: targetPerPeriod / uint64(periodSecs),
+		period:  time.Duration(periodSecs) * time.Second,
+		target:  targetPerPeriod / uint64(periodSecs),
+		backlog: rc[resource][periodSecs].backlog,
 	}
 }
 
@@ -55,3 +61,72 @@ func (rc ResourceConstraints) SetConstraint(
 func (rc ResourceConstraints) ClearConstraint(resource multigas.ResourceKind, periodSecs PeriodSecs) {
 	delete(rc[resource], periodSecs)
 }
+
+// Len returns the number of constraints in the set.
+func (rc ResourceConstraints) Len() int {
+	count := 0
+	for _, constraints := range rc {
+		count += len(constraints)
+	}
+	return count
+}
+
+// ConstraintInfo describes a resource constraint and its current backlog.
+type ConstraintInfo struct {
+	Resource        multigas.ResourceKind
+	PeriodSecs      PeriodSecs
+	TargetPerSecond uint64
+	Backlog         uint64
+}
+
+// List returns the constraints ordered by resource and period.
+func (rc ResourceConstraints) List() []ConstraintInfo {
+	list := make([]ConstraintInfo, 0, rc.Len())
+	for resource := multigas.ResourceKindUnknown + 1; resource < multigas.NumResourceKind; resource++ {
+		constraints := rc[resource]
+		periods := make([]PeriodSecs, 0, len(constraints))
+		for periodSecs := range constraints {
+			periods = append(periods, periodSecs)
+		}
+		slices.Sort(periods)
+		for _, periodSecs := range periods {
+			list = append(list, ConstraintInfo{
+				Resource:        resource,
+				PeriodSecs:      periodSecs,
+				TargetPerSecond: constraints[periodSecs].target,
+				Backlog:         constraints[periodSecs].backlog,
+			})
+		}
+	}
+	return list
+}
+
+// PayOffBacklogs pays off the backlog of every constraint by its target for the time passed.
+func (rc ResourceConstraints) PayOffBacklogs(timePassed uint64) {
+	for _, constraints := range rc {
+		for periodSecs, constraint := range constraints {
+			constraint.backlog = arbmath.SaturatingUSub(constraint.backlog, arbmath.SaturatingUMul(timePassed, constraint.target))
+			constraints[periodSecs] = constraint
+		}
+	}
+}
+
+// ExponentBips returns the exponent of the base fee multiplier required by the most congested constraint.
+//
+// A constraint's exponent is its backlog relative to the gas it targets over its period, so a
+// backlog of a whole period's worth of gas multiplies the base fee by e. Constraints with long
+// periods thus react slowly to bursts of usage, which are meant to be handled by shorter ones.
+func (rc ResourceConstraints) ExponentBips() arbmath.Bips {
+	var exponent arbmath.Bips
+	for _, constraints := range rc {
+		for periodSecs, constraint := range constraints {
+			targetPerPeriod := arbmath.SaturatingUMul(uint64(periodSecs), constraint.target)
+			if constraint.backlog == 0 || targetPerPeriod == 0 {
+				continue
+			}
+			backlogBips := arbmath.NaturalToBips(arbmath.SaturatingCast[int64](constraint.backlog))
+			exponent = max(exponent, backlogBips/arbmath.SaturatingCastToBips(targetPerPeriod))
+		}
+	}
+	return exponent
+}
@@ -4,10 +4,15 @@
 package constraints
 
 import (
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/ethereum/go-ethereum/arbitrum/multigas"
+
+	"github.com/offchainlabs/nitro/arbos/burn"
+	"github.com/offchainlabs/nitro/arbos/storage"
+	"github.com/offchainlabs/nitro/util/arbmath"
 )
 
 func TestResourceConstraints(t *testing.T) {
@@ -81,3 +86,148 @@ func TestResourceConstraints(t *testing.T) {
 		t.Errorf("unexpected number of storage growth constraints: got %v, want %v", got, want)
 	}
 }
+
+func TestResourceConstraintsBacklogs(t *testing.T) {
+	rc := NewResourceConstraints()
+	rc.SetConstraint(multigas.ResourceKindComputation, 10, 1_000*10)
+	rc.SetConstraint(multigas.ResourceKindComputation, 100, 500*100)
+	rc.SetConstraint(multigas.ResourceKindHistoryGrowth, 10, 100*10)
+
+	setBacklogs(rc, multigas.ResourceKindComputation, 20_000)
+	if got, want := rc[multigas.ResourceKindComputation][10].backlog, uint64(20_000); got != want {
+		t.Errorf("unexpected backlog: got %v, want %v", got, want)
+	}
+	if got, want := rc[multigas.ResourceKindComputation][100].backlog, uint64(20_000); got != want {
+		t.Errorf("unexpected backlog: got %v, want %v", got, want)
+	}
+	if got, want := rc[multigas.ResourceKindHistoryGrowth][10].backlog, uint64(0); got != want {
+		t.Errorf("unexpected backlog: got %v, want %v", got, want)
+	}
+
+	// The most congested constraint relative to its period sets the exponent
+	if got, want := rc.ExponentBips(), arbmath.Bips(20_000*10_000/(1_000*10)); got != want {
+		t.Errorf("unexpected exponent: got %v, want %v", got, want)
+	}
+
+	// Updating a constraint keeps its backlog
+	rc.SetConstraint(multigas.ResourceKindComputation, 10, 2_000*10)
+	if got, want := rc[multigas.ResourceKindComputation][10].backlog, uint64(20_000); got != want {
+		t.Errorf("unexpected backlog: got %v, want %v", got, want)
+	}
+
+	rc.PayOffBacklogs(5)
+	if got, want := rc[multigas.ResourceKindComputation][10].backlog, uint64(10_000); got != want {
+		t.Errorf("unexpected backlog: got %v, want %v", got, want)
+	}
+	if got, want := rc[multigas.ResourceKindComputation][100].backlog, uint64(17_500); got != want {
+		t.Errorf("unexpected backlog: got %v, want %v", got, want)
+	}
+	rc.PayOffBacklogs(5)
+	if got, want := rc[multigas.ResourceKindComputation][10].backlog, uint64(0); got != want {
+		t.Errorf("unexpected backlog: got %v, want %v", got, want)
+	}
+	if got, want := rc.ExponentBips(), arbmath.Bips(15_000*10_000/(500*100)); got != want {
+		t.Errorf("unexpected exponent: got %v, want %v", got, want)
+	}
+}
+
+func TestStorageBackedResourceConstraints(t *testing.T) {
+	sto := OpenStorageBackedResourceConstraints(storage.NewMemoryBacked(burn.NewSystemBurner(nil, false)))
+	rc, err := sto.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := rc.Len(), 0; got != want {
+		t.Fatalf("unexpected number of constraints: got %v, want %v", got, want)
+	}
+
+	rc.SetConstraint(multigas.ResourceKindComputation, 12, 7_000_000*12)
+	rc.SetConstraint(multigas.ResourceKindComputation, 3600, 5_000_000*3600)
+	rc.SetConstraint(multigas.ResourceKindStorageGrowth, 60, 10_000*60)
+	setBacklogs(rc, multigas.ResourceKindComputation, 1_000)
+	if err := sto.Store(rc); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := sto.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := loaded.Len(), 3; got != want {
+		t.Fatalf("unexpected number of constraints: got %v, want %v", got, want)
+	}
+	for resource, constraints := range rc {
+		for periodSecs, constraint := range constraints {
+			if got := loaded[resource][periodSecs]; got != constraint {
+				t.Errorf("unexpected constraint for resource %v and period %v: got %+v, want %+v", resource, periodSecs, got, constraint)
+			}
+		}
+	}
+
+	// Backlogs are updated in place, paying off computation only
+	gasUsed, _ := multigas.ComputationGas(3_000).SafeIncrement(multigas.ResourceKindStorageGrowth, 200)
+	if err := sto.AddToBacklogs(gasUsed, 500); err != nil {
+		t.Fatal(err)
+	}
+	setBacklogs(rc, multigas.ResourceKindComputation, 3_500)
+	setBacklogs(rc, multigas.ResourceKindStorageGrowth, 200)
+	loaded, err = sto.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for resource, constraints := range rc {
+		for periodSecs, constraint := range constraints {
+			if got := loaded[resource][periodSecs]; got != constraint {
+				t.Errorf("unexpected constraint for resource %v and period %v: got %+v, want %+v", resource, periodSecs, got, constraint)
+			}
+		}
+	}
+	if got, want := loaded[multigas.ResourceKindComputation][12].backlog, uint64(3_500); got != want {
+		t.Errorf("unexpected computation backlog: got %v, want %v", got, want)
+	}
+	if err := sto.AddToBacklogs(multigas.ComputationGas(0), 10_000); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err = sto.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := loaded[multigas.ResourceKindComputation][3600].backlog, uint64(0); got != want {
+		t.Errorf("unexpected computation backlog: got %v, want %v", got, want)
+	}
+	if got, want := loaded[multigas.ResourceKindStorageGrowth][60].backlog, uint64(200); got != want {
+		t.Errorf("unexpected storage growth backlog: got %v, want %v", got, want)
+	}
+
+	// Removed constraints are cleared from storage
+	loaded.ClearConstraint(multigas.ResourceKindComputation, 12)
+	if err := sto.Store(loaded); err != nil {
+		t.Fatal(err)
+	}
+	size, err := sto.Size()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size != 2 {
+		t.Fatalf("unexpected number of stored constraints: got %v, want 2", size)
+	}
+	for offset := uint64(1 + 2*constraintSlots); offset < 1+3*constraintSlots; offset++ {
+		if value, err := sto.storage.GetUint64ByUint64(offset); err != nil || value != 0 {
+			t.Errorf("expected slot %v to be cleared, got %v (err %v)", offset, value, err)
+		}
+	}
+
+	for i := 0; i <= MaxConstraints; i++ {
+		// #nosec G115
+		loaded.SetConstraint(multigas.ResourceKindHistoryGrowth, PeriodSecs(i+1), 1_000*uint64(i+1))
+	}
+	if err := sto.Store(loaded); !errors.Is(err, ErrTooManyConstraints) {
+		t.Errorf("expected too many constraints error, got %v", err)
+	}
+}
+
+func setBacklogs(rc ResourceConstraints, resource multigas.ResourceKind, backlog uint64) {
+	for periodSecs, constraint := range rc[resource] {
+		constraint.backlog = backlog
+		rc[resource][periodSecs] = constraint
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package constraints
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/arbitrum/multigas"
+
+	"github.com/offchainlabs/nitro/arbos/storage"
+	"github.com/offchainlabs/nitro/util/arbmath"
+)
+
+// MaxConstraints bounds the number of constraints, since all of them are loaded to price every block.
+const MaxConstraints = 32
+
+var ErrTooManyConstraints = errors.New("too many resource constraints")
+
+const (
+	resourceOffset uint64 = iota
+	periodSecsOffset
+	targetOffset
+	backlogOffset
+	constraintSlots
+)
+
+// StorageBackedResourceConstraints persists a set of resource constraints in ArbOS storage.
+// The number of constraints is stored at position 0, and the resource, period, target per
+// second and backlog of each constraint are stored sequentially from 1 onward.
+type StorageBackedResourceConstraints struct {
+	storage *storage.Storage
+	size    storage.StorageBackedUint64
+}
+
+func OpenStorageBackedResourceConstraints(sto *storage.Storage) *StorageBackedResourceConstraints {
+	return &StorageBackedResourceConstraints{
+		storage: sto,
+		size:    sto.OpenStorageBackedUint64(0),
+	}
+}
+
+// Size returns the number of stored constraints.
+func (s *StorageBackedResourceConstraints) Size() (uint64, error) {
+	return s.size.Get()
+}
+
+// Load reads all the stored constraints.
+func (s *StorageBackedResourceConstraints) Load() (ResourceConstraints, error) {
+	size, err := s.size.Get()
+	if err != nil {
+		return nil, err
+	}
+	rc := NewResourceConstraints()
+	for i := uint64(0); i < size; i++ {
+		offset := 1 + i*constraintSlots
+		resource, err := s.storage.GetUint64ByUint64(offset + resourceOffset)
+		if err != nil {
+			return nil, err
+		}
+		periodSecs, err := s.storage.GetUint64ByUint64(offset + periodSecsOffset)
+		if err != nil {
+			return nil, err
+		}
+		target, err := s.storage.GetUint64ByUint64(offset + targetOffset)
+		if err != nil {
+			return nil, err
+		}
+		backlog, err := s.storage.GetUint64ByUint64(offset + backlogOffset)
+		if err != nil {
+			return nil, err
+		}
+		constraints, ok := rc[multigas.ResourceKind(resource)] // #nosec G115
+		if !ok {
+			return nil, errors.New("stored resource constraint has an unknown resource kind")
+		}
+		// #nosec G115
+		constraints[PeriodSecs(periodSecs)] = resourceConstraint{
+			period:  time.Duration(periodSecs) * time.Second,
+			target:  target,
+			backlog: backlog,
+		}
+	}
+	return rc, nil
+}
+
+// Store replaces the stored constraints with the given ones, ordered by resource and period.
+func (s *StorageBackedResourceConstraints) Store(rc ResourceConstraints) error {
+	oldSize, err := s.size.Get()
+	if err != nil {
+		return err
+	}
+	newSize := uint64(rc.Len()) // #nosec G115
+	if newSize > MaxConstraints {
+		return ErrTooManyConstraints
+	}
+	offset := uint64(1)
+	for _, constraint := range rc.List() {
+		if err := s.storage.SetUint64ByUint64(offset+resourceOffset, uint64(constraint.Resource)); err != nil {
+			return err
+		}
+		if err := s.storage.SetUint64ByUint64(offset+periodSecsOffset, uint64(constraint.PeriodSecs)); err != nil {
+			return err
+		}
+		if err := s.storage.SetUint64ByUint64(offset+targetOffset, constraint.TargetPerSecond); err != nil {
+			return err
+		}
+		if err := s.storage.SetUint64ByUint64(offset+backlogOffset, constraint.Backlog); err != nil {
+			return err
+		}
+		offset += constraintSlots
+	}
+	// clear the constraints which were removed
+	for end := 1 + oldSize*constraintSlots; offset < end; offset++ {
+		if err := s.storage.ClearByUint64(offset); err != nil {
+			return err
+		}
+	}
+	return s.size.Set(newSize)
+}
+
+// AddToBacklogs adds the gas used for each resource to the stored backlogs of its constraints,
+// then pays off the given amount of computation. Unlike loading and storing all the constraints,
+// only the backlogs which change are written, as this runs for every transaction.
+func (s *StorageBackedResourceConstraints) AddToBacklogs(gasUsed multigas.MultiGas, computationPaidOff uint64) error {
+	size, err := s.size.Get()
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < size; i++ {
+		offset := 1 + i*constraintSlots
+		resource, err := s.storage.GetUint64ByUint64(offset + resourceOffset)
+		if err != nil {
+			return err
+		}
+		kind := multigas.ResourceKind(resource) // #nosec G115
+		if kind <= multigas.ResourceKindUnknown || kind >= multigas.NumResourceKind {
+			return errors.New("stored resource constraint has an unknown resource kind")
+		}
+		amount := gasUsed.Get(kind)
+		var paidOff uint64
+		if kind == multigas.ResourceKindComputation {
+			paidOff = computationPaidOff
+		}
+		if amount == 0 && paidOff == 0 {
+			continue
+		}
+		backlog, err := s.storage.GetUint64ByUint64(offset + backlogOffset)
+		if err != nil {
+			return err
+		}
+		newBacklog := arbmath.SaturatingUSub(arbmath.SaturatingUAdd(backlog, amount), paidOff)
+		if newBacklog == backlog {
+			continue
+		}
+		if err := s.storage.SetUint64ByUint64(offset+backlogOffset, newBacklog); err != nil {
+			return err
+		}
+	}
+	return nil
+}
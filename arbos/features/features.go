@@ -5,6 +5,11 @@
 package features
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/params"
+
 	"github.com/offchainlabs/nitro/arbos/storage"
 )
 
@@ -14,12 +19,92 @@ const (
 	increasedCalldata int = iota
 )
 
+const maxFeatures = 256
+
+var (
+	ErrUnknownFeature     = errors.New("unknown ArbOS feature")
+	ErrFeatureUnavailable = errors.New("ArbOS feature not available in the current ArbOS version")
+)
+
+// Feature is a chain-level toggle which the chain owner can turn on or off
+// once the chain runs at least its minimum ArbOS version.
+type Feature struct {
+	Name            string
+	Description     string
+	MinArbosVersion uint64
+	bit             int
+}
+
+// registry declares all the features. Each feature keeps its bit forever, so
+// bits of removed features must not be reused.
+var registry = []Feature{
+	{
+		Name:            "calldata-price-increase",
+		Description:     "increases the price of calldata heavy transactions (EIP-7623)",
+		MinArbosVersion: params.ArbosVersion_40,
+		bit:             increasedCalldata,
+	},
+}
+
+var featuresByName = make(map[string]*Feature)
+
+func init() {
+	bits := make(map[int]bool)
+	for i := range registry {
+		feature := &registry[i]
+		if feature.bit < 0 || feature.bit >= maxFeatures {
+			panic(fmt.Sprintf("ArbOS feature %s has out of range bit %d", feature.Name, feature.bit))
+		}
+		if featuresByName[feature.Name] != nil || bits[feature.bit] {
+			panic(fmt.Sprintf("ArbOS feature %s is declared twice", feature.Name))
+		}
+		featuresByName[feature.Name] = feature
+		bits[feature.bit] = true
+	}
+}
+
+// All returns all the declared features.
+func All() []Feature {
+	return append([]Feature{}, registry...)
+}
+
+// Lookup returns the feature declared with the given name.
+func Lookup(name string) (Feature, error) {
+	feature, ok := featuresByName[name]
+	if !ok {
+		return Feature{}, fmt.Errorf("%w: %s", ErrUnknownFeature, name)
+	}
+	return *feature, nil
+}
+
 // Features is a thin wrapper around a storage.StorageBackedBigUint that
 // provides accessors for various feature toggles.
 type Features struct {
 	features storage.StorageBackedBigUint
 }
 
+// Set turns the named feature on or off, provided the chain runs at least
+// its minimum ArbOS version.
+func (f *Features) Set(name string, enabled bool, arbosVersion uint64) error {
+	feature, err := Lookup(name)
+	if err != nil {
+		return err
+	}
+	if arbosVersion < feature.MinArbosVersion {
+		return fmt.Errorf("%w: %s requires ArbOS version %d", ErrFeatureUnavailable, name, feature.MinArbosVersion)
+	}
+	return f.setBit(feature.bit, enabled)
+}
+
+// IsEnabled returns true if the named feature is turned on.
+func (f *Features) IsEnabled(name string) (bool, error) {
+	feature, err := Lookup(name)
+	if err != nil {
+		return false, err
+	}
+	return f.isSet(feature.bit)
+}
+
 // SetIncreasedCalldataPriceIncrease sets the increased calldata price feature
 // on or off depending on the value of enabled.
 func (f *Features) SetCalldataPriceIncrease(enabled bool) error {
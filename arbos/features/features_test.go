@@ -0,0 +1,58 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see
+// https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package features
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/offchainlabs/nitro/arbos/burn"
+	"github.com/offchainlabs/nitro/arbos/storage"
+)
+
+func TestFeatureRegistry(t *testing.T) {
+	f := Open(storage.NewMemoryBacked(burn.NewSystemBurner(nil, false)))
+	const name = "calldata-price-increase"
+
+	feature, err := Lookup(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if feature.MinArbosVersion != params.ArbosVersion_40 {
+		t.Errorf("unexpected minimum ArbOS version %d", feature.MinArbosVersion)
+	}
+	if _, err := Lookup("unknown"); !errors.Is(err, ErrUnknownFeature) {
+		t.Errorf("expected unknown feature error, got %v", err)
+	}
+	if err := f.Set("unknown", true, params.ArbosVersion_40); !errors.Is(err, ErrUnknownFeature) {
+		t.Errorf("expected unknown feature error, got %v", err)
+	}
+	if err := f.Set(name, true, params.ArbosVersion_32); !errors.Is(err, ErrFeatureUnavailable) {
+		t.Errorf("expected unavailable feature error, got %v", err)
+	}
+
+	for _, enabled := range []bool{true, false} {
+		if err := f.Set(name, enabled, params.ArbosVersion_40); err != nil {
+			t.Fatal(err)
+		}
+		got, err := f.IsEnabled(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != enabled {
+			t.Errorf("IsEnabled got: %v, want: %v", got, enabled)
+		}
+		// the dedicated accessors share the same bit
+		got, err = f.IsIncreasedCalldataPriceEnabled()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != enabled {
+			t.Errorf("IsIncreasedCalldataPriceEnabled got: %v, want: %v", got, enabled)
+		}
+	}
+}
@@ -6,6 +6,10 @@ package l2pricing
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/arbitrum/multigas"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/offchainlabs/nitro/arbos/constraints"
 	"github.com/offchainlabs/nitro/arbos/storage"
 )
 
@@ -19,8 +23,13 @@ type L2PricingState struct {
 	pricingInertia      storage.StorageBackedUint64
 	backlogTolerance    storage.StorageBackedUint64
 	perTxGasLimit       storage.StorageBackedUint64
+	resourceConstraints *constraints.StorageBackedResourceConstraints // introduced in ArbOS version 60
+
+	ArbosVersion uint64
 }
 
+var resourceConstraintsKey = []byte{0}
+
 const (
 	speedLimitPerSecondOffset uint64 = iota
 	perBlockGasLimitOffset
@@ -44,7 +53,7 @@ func InitializeL2PricingState(sto *storage.Storage) error {
 	return sto.SetUint64ByUint64(minBaseFeeWeiOffset, InitialMinimumBaseFeeWei)
 }
 
-func OpenL2PricingState(sto *storage.Storage) *L2PricingState {
+func OpenL2PricingState(sto *storage.Storage, arbosVersion uint64) *L2PricingState {
 	return &L2PricingState{
 		storage:             sto,
 		speedLimitPerSecond: sto.OpenStorageBackedUint64(speedLimitPerSecondOffset),
@@ -55,6 +64,8 @@ func OpenL2PricingState(sto *storage.Storage) *L2PricingState {
 		pricingInertia:      sto.OpenStorageBackedUint64(pricingInertiaOffset),
 		backlogTolerance:    sto.OpenStorageBackedUint64(backlogToleranceOffset),
 		perTxGasLimit:       sto.OpenStorageBackedUint64(perTxGasLimitOffset),
+		resourceConstraints: constraints.OpenStorageBackedResourceConstraints(sto.OpenCachedSubStorage(resourceConstraintsKey)),
+		ArbosVersion:        arbosVersion,
 	}
 }
 
@@ -125,6 +136,38 @@ func (ps *L2PricingState) SetBacklogTolerance(val uint64) error {
 	return ps.backlogTolerance.Set(val)
 }
 
+func (ps *L2PricingState) ResourceConstraints() (constraints.ResourceConstraints, error) {
+	return ps.resourceConstraints.Load()
+}
+
+func (ps *L2PricingState) SetResourceConstraint(resource multigas.ResourceKind, periodSecs constraints.PeriodSecs, targetPerPeriod uint64) error {
+	rc, err := ps.resourceConstraints.Load()
+	if err != nil {
+		return err
+	}
+	rc.SetConstraint(resource, periodSecs, targetPerPeriod)
+	return ps.resourceConstraints.Store(rc)
+}
+
+func (ps *L2PricingState) ClearResourceConstraint(resource multigas.ResourceKind, periodSecs constraints.PeriodSecs) error {
+	rc, err := ps.resourceConstraints.Load()
+	if err != nil {
+		return err
+	}
+	rc.ClearConstraint(resource, periodSecs)
+	return ps.resourceConstraints.Store(rc)
+}
+
+// UsesResourceConstraints returns true if the base fee is priced by the resource constraints
+// rather than by the single gas backlog, which is the case once the chain owner sets any.
+func (ps *L2PricingState) UsesResourceConstraints() (bool, error) {
+	if ps.ArbosVersion < params.ArbosVersion_60 {
+		return false, nil
+	}
+	size, err := ps.resourceConstraints.Size()
+	return size > 0, err
+}
+
 func (ps *L2PricingState) Restrict(err error) {
 	ps.storage.Burner().Restrict(err)
 }
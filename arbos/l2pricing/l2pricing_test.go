@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/arbitrum/multigas"
+	"github.com/ethereum/go-ethereum/params"
+
 	"github.com/offchainlabs/nitro/arbos/burn"
 	"github.com/offchainlabs/nitro/arbos/storage"
 	"github.com/offchainlabs/nitro/util/arbmath"
@@ -18,7 +21,7 @@ func PricingForTest(t *testing.T) *L2PricingState {
 	storage := storage.NewMemoryBacked(burn.NewSystemBurner(nil, false))
 	err := InitializeL2PricingState(storage)
 	Require(t, err)
-	return OpenL2PricingState(storage)
+	return OpenL2PricingState(storage, params.ArbosVersion_60)
 }
 
 func fakeBlockUpdate(t *testing.T, pricing *L2PricingState, gasUsed int64, timePassed uint64) {
@@ -90,6 +93,65 @@ func TestPricingModelExp(t *testing.T) {
 	}
 }
 
+func TestPricingModelWithConstraints(t *testing.T) {
+	pricing := PricingForTest(t)
+	minPrice := getMinPrice(t, pricing)
+
+	// constrain the storage growth only
+	const periodSecs = 60
+	const targetPerSecond = 100_000
+	Require(t, pricing.SetResourceConstraint(multigas.ResourceKindStorageGrowth, periodSecs, periodSecs*targetPerSecond))
+	useConstraints, err := pricing.UsesResourceConstraints()
+	Require(t, err)
+	if !useConstraints {
+		Fail(t, "resource constraints should be in use")
+	}
+
+	// show that computation doesn't raise the price, even far over the speed limit
+	colors.PrintBlue("computation only")
+	for seconds := 0; seconds < 4; seconds++ {
+		Require(t, pricing.AddToResourceBacklogs(multigas.ComputationGas(1_000_000_000), 0))
+		fakeBlockUpdate(t, pricing, 1_000_000_000, 1)
+		if getPrice(t, pricing) != minPrice {
+			Fail(t, "price changed when it shouldn't have")
+		}
+	}
+
+	// show that growing the state over the target escalates the price
+	colors.PrintBlue("storage growth over the target")
+	storageGrowth, _ := multigas.ZeroGas().SafeIncrement(multigas.ResourceKindStorageGrowth, 10*targetPerSecond)
+	price := minPrice
+	for seconds := 0; seconds < 4; seconds++ {
+		Require(t, pricing.AddToResourceBacklogs(storageGrowth, 0))
+		fakeBlockUpdate(t, pricing, 0, 1)
+		newPrice := getPrice(t, pricing)
+		if newPrice <= price {
+			Fail(t, "price should have risen", price, newPrice)
+		}
+		price = newPrice
+	}
+
+	// show that the price falls back as the backlog gets paid off
+	colors.PrintBlue("backlog paid off")
+	fakeBlockUpdate(t, pricing, 0, 4*10)
+	if getPrice(t, pricing) != minPrice {
+		Fail(t, "price should be back to the minimum")
+	}
+
+	// show that clearing the constraints goes back to pricing the gas backlog
+	Require(t, pricing.ClearResourceConstraint(multigas.ResourceKindStorageGrowth, periodSecs))
+	useConstraints, err = pricing.UsesResourceConstraints()
+	Require(t, err)
+	if useConstraints {
+		Fail(t, "resource constraints should not be in use")
+	}
+	Require(t, pricing.SetGasBacklog(100000000))
+	fakeBlockUpdate(t, pricing, 0, 1)
+	if getPrice(t, pricing) <= minPrice {
+		Fail(t, "price should have risen")
+	}
+}
+
 func getPrice(t *testing.T, pricing *L2PricingState) uint64 {
 	value, err := pricing.BaseFeeWei()
 	Require(t, err)
@@ -6,6 +6,7 @@ package l2pricing
 import (
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/arbitrum/multigas"
 	"github.com/ethereum/go-ethereum/params"
 
 	"github.com/offchainlabs/nitro/util/arbmath"
@@ -35,10 +36,24 @@ func (ps *L2PricingState) AddToGasPool(gas int64) error {
 	return ps.SetGasBacklog(backlog)
 }
 
+// AddToResourceBacklogs adds the multi-dimensional gas used by a transaction to the backlogs of
+// the resource constraints. The gas the transaction donated to scheduled retries was charged as
+// computation, and is left out since the retries add the computation they actually use.
+func (ps *L2PricingState) AddToResourceBacklogs(gasUsed multigas.MultiGas, donatedGas uint64) error {
+	return ps.resourceConstraints.AddToBacklogs(gasUsed, donatedGas)
+}
+
 // UpdatePricingModel updates the pricing model with info from the last block
 func (ps *L2PricingState) UpdatePricingModel(l2BaseFee *big.Int, timePassed uint64, debug bool) {
 	speedLimit, _ := ps.SpeedLimitPerSecond()
 	_ = ps.AddToGasPool(arbmath.SaturatingCast[int64](arbmath.SaturatingUMul(timePassed, speedLimit)))
+	// The gas pool is kept up to date while the resource constraints are in use, so that
+	// the chain can go back to the single gas backlog by clearing them.
+	useConstraints, _ := ps.UsesResourceConstraints()
+	if useConstraints {
+		ps.updatePricingModelWithConstraints(timePassed)
+		return
+	}
 	inertia, _ := ps.PricingInertia()
 	tolerance, _ := ps.BacklogTolerance()
 	backlog, _ := ps.GasBacklog()
@@ -51,3 +66,22 @@ func (ps *L2PricingState) UpdatePricingModel(l2BaseFee *big.Int, timePassed uint
 	}
 	_ = ps.SetBaseFeeWei(baseFee)
 }
+
+// updatePricingModelWithConstraints prices the base fee by the most congested resource constraint,
+// so that congestion of a resource raises the base fee without the usage of the other resources
+// adding to it.
+func (ps *L2PricingState) updatePricingModelWithConstraints(timePassed uint64) {
+	rc, err := ps.resourceConstraints.Load()
+	if err != nil {
+		ps.Restrict(err)
+		return
+	}
+	rc.PayOffBacklogs(timePassed)
+	ps.Restrict(ps.resourceConstraints.Store(rc))
+	minBaseFee, _ := ps.MinBaseFeeWei()
+	baseFee := minBaseFee
+	if exponentBips := rc.ExponentBips(); exponentBips > 0 {
+		baseFee = arbmath.BigMulByBips(minBaseFee, arbmath.ApproxExpBasisPoints(exponentBips, 4))
+	}
+	_ = ps.SetBaseFeeWei(baseFee)
+}
@@ -6,6 +6,8 @@ package arbos
 import (
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/offchainlabs/nitro/arbos/arbosState"
 	"github.com/offchainlabs/nitro/arbos/storage"
 	"github.com/offchainlabs/nitro/arbos/util"
@@ -38,7 +40,34 @@ func TestQueue(t *testing.T) {
 		}
 	}
 
-	for i := uint64(0); i < 150; i++ {
+	// offsets are counted from the start of the queue's storage, where the first 2 slots hold its bounds
+	var visited []uint64
+	Require(t, q.ForEachFromOffset(2+140, func(offset uint64, val common.Hash) (bool, error) {
+		if val != util.UintToHash(val0+offset-2) {
+			Fail(t, "unexpected value at offset", offset)
+		}
+		visited = append(visited, offset)
+		return offset == 2+145, nil
+	}))
+	if len(visited) != 6 || visited[0] != 2+140 || visited[5] != 2+145 {
+		Fail(t, "unexpected offsets visited", visited)
+	}
+
+	// offsets before the front of the queue are skipped
+	for i := uint64(0); i < 10; i++ {
+		_, err := q.Get()
+		Require(t, err)
+	}
+	visited = nil
+	Require(t, q.ForEachFromOffset(0, func(offset uint64, val common.Hash) (bool, error) {
+		visited = append(visited, offset)
+		return true, nil
+	}))
+	if len(visited) != 1 || visited[0] != 2+10 {
+		Fail(t, "unexpected offsets visited", visited)
+	}
+
+	for i := uint64(10); i < 150; i++ {
 		val := util.UintToHash(val0 + i)
 		res, err := q.Get()
 		Require(t, err)
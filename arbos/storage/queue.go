@@ -128,3 +128,33 @@ func (q *Queue) ForEach(closure func(uint64, common.Hash) (bool, error)) error {
 	}
 	return nil
 }
+
+// ForEachFromOffset applies a closure on the elements of the queue stored from the given offset,
+// without reading the elements before it. Offsets before the front of the queue are clamped to it.
+// The closure is given the storage offset of each element, which, unlike its index, doesn't change
+// as elements are removed from the front of the queue.
+func (q *Queue) ForEachFromOffset(start uint64, closure func(uint64, common.Hash) (bool, error)) error {
+	put, err := q.nextPutOffset.Get()
+	if err != nil {
+		return err
+	}
+	get, err := q.nextGetOffset.Get()
+	if err != nil {
+		return err
+	}
+
+	for offset := max(start, get); offset < put; offset++ {
+		entry, err := q.storage.GetByUint64(offset)
+		if err != nil {
+			return err
+		}
+		done, err := closure(offset, entry)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package arbstate
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/offchainlabs/nitro/arbcompress"
+	"github.com/offchainlabs/nitro/arbos/arbostypes"
+	"github.com/offchainlabs/nitro/daprovider"
+)
+
+// BatchMessage is a message sequenced by a batch, as produced by the inbox multiplexer.
+// It's either an L2 message, a reference to a delayed message, or an invalid message.
+type BatchMessage struct {
+	// Segment is the index of the segment the message was read from,
+	// nil for the virtual delayed message segments after the last segment.
+	Segment     *hexutil.Uint64 `json:"segment,omitempty"`
+	Timestamp   hexutil.Uint64  `json:"timestamp,omitempty"`
+	BlockNumber hexutil.Uint64  `json:"blockNumber,omitempty"`
+	// L2Message is the decompressed L2 message.
+	L2Message hexutil.Bytes `json:"l2Message,omitempty"`
+	// DelayedMessage is the sequence number of the delayed message read.
+	DelayedMessage *hexutil.Uint64 `json:"delayedMessage,omitempty"`
+	// Invalid is the reason the segment was replaced by an invalid message.
+	Invalid string `json:"invalid,omitempty"`
+}
+
+// DecodeBatchMessages returns the messages of the sequencer message, in the order the
+// inbox multiplexer produces them given the number of delayed messages read before the batch.
+// Delayed messages are returned as references, they aren't read.
+func DecodeBatchMessages(seqMsg *SequencerMessage, delayedMessagesRead uint64) []*BatchMessage {
+	backend := &batchDecodingBackend{}
+	r, ok := NewInboxMultiplexer(backend, delayedMessagesRead, nil, daprovider.KeysetDontValidate).(*inboxMultiplexer)
+	if !ok {
+		panic("unexpected inbox multiplexer type")
+	}
+	r.cachedSequencerMessage = seqMsg
+	var messages []*BatchMessage
+	for r.cachedSequencerMessage != nil {
+		delayedMessagesRead := r.delayedMessagesRead
+		// The backend doesn't fail, so neither does the multiplexer
+		msg, segmentNum, _ := r.popCachedMsg()
+		batchMsg := &BatchMessage{}
+		if segmentNum < uint64(len(seqMsg.Segments)) {
+			segment := hexutil.Uint64(segmentNum)
+			batchMsg.Segment = &segment
+		}
+		switch {
+		case msg.Message == arbostypes.InvalidL1Message:
+			batchMsg.Invalid = invalidSegmentReason(seqMsg, segmentNum)
+		case msg.DelayedMessagesRead > delayedMessagesRead:
+			delayed := hexutil.Uint64(delayedMessagesRead)
+			batchMsg.DelayedMessage = &delayed
+		default:
+			batchMsg.Timestamp = hexutil.Uint64(msg.Message.Header.Timestamp)
+			batchMsg.BlockNumber = hexutil.Uint64(msg.Message.Header.BlockNumber)
+			batchMsg.L2Message = msg.Message.L2msg
+		}
+		messages = append(messages, batchMsg)
+	}
+	return messages
+}
+
+// invalidSegmentReason explains why the multiplexer replaced the segment with an invalid message.
+func invalidSegmentReason(seqMsg *SequencerMessage, segmentNum uint64) string {
+	if segmentNum >= uint64(len(seqMsg.Segments)) || len(seqMsg.Segments[segmentNum]) == 0 {
+		return fmt.Sprintf("attempt to read past batch delayed message count %d", seqMsg.AfterDelayedMessages)
+	}
+	segment := seqMsg.Segments[segmentNum]
+	switch segment[0] {
+	case BatchSegmentKindL2MessageBrotli:
+		_, err := arbcompress.Decompress(segment[1:], arbostypes.MaxL2MessageSize)
+		return fmt.Sprintf("failed to decompress L2 message: %v", err)
+	case BatchSegmentKindDelayedMessages:
+		return fmt.Sprintf("attempt to read past batch delayed message count %d", seqMsg.AfterDelayedMessages)
+	default:
+		return fmt.Sprintf("unknown segment kind %d", segment[0])
+	}
+}
+
+// batchDecodingBackend feeds a single sequencer message to the inbox multiplexer,
+// reading empty placeholders for its delayed messages.
+type batchDecodingBackend struct {
+	positionWithinMessage uint64
+}
+
+func (b *batchDecodingBackend) PeekSequencerInbox() ([]byte, common.Hash, error) {
+	return nil, common.Hash{}, errors.New("the sequencer message is already cached")
+}
+
+func (b *batchDecodingBackend) GetSequencerInboxPosition() uint64 {
+	return 0
+}
+
+func (b *batchDecodingBackend) AdvanceSequencerInbox() {}
+
+func (b *batchDecodingBackend) GetPositionWithinMessage() uint64 {
+	return b.positionWithinMessage
+}
+
+func (b *batchDecodingBackend) SetPositionWithinMessage(pos uint64) {
+	b.positionWithinMessage = pos
+}
+
+func (b *batchDecodingBackend) ReadDelayedInbox(uint64) (*arbostypes.L1IncomingMessage, error) {
+	return &arbostypes.L1IncomingMessage{}, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package arbstate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/offchainlabs/nitro/arbcompress"
+)
+
+func TestDecodeBatchMessages(t *testing.T) {
+	advance, err := rlp.EncodeToBytes(uint64(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	l2Message := []byte("l2 message")
+	compressed, err := arbcompress.CompressWell(l2Message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seqMsg := &SequencerMessage{
+		MinTimestamp:         5,
+		MaxTimestamp:         100,
+		MinL1Block:           7,
+		MaxL1Block:           20,
+		AfterDelayedMessages: 3,
+		Segments: [][]byte{
+			append([]byte{BatchSegmentKindAdvanceTimestamp}, advance...),
+			{},
+			append([]byte{BatchSegmentKindL2Message}, l2Message...),
+			append([]byte{BatchSegmentKindL2MessageBrotli}, compressed...),
+			{BatchSegmentKindDelayedMessages},
+			{0xff},
+			{BatchSegmentKindL2MessageBrotli, 0x01, 0x02},
+		},
+	}
+	messages := DecodeBatchMessages(seqMsg, 1)
+	if len(messages) != 6 {
+		t.Fatalf("expected 6 messages, got %d", len(messages))
+	}
+	for i, segment := range []uint64{2, 3} {
+		msg := messages[i]
+		if msg.Segment == nil || uint64(*msg.Segment) != segment {
+			t.Errorf("message %d has segment %v, expected %d", i, msg.Segment, segment)
+		}
+		if !bytes.Equal(msg.L2Message, l2Message) {
+			t.Errorf("message %d has L2 message %v, expected %v", i, msg.L2Message, l2Message)
+		}
+		if msg.Timestamp != 10 || msg.BlockNumber != 7 {
+			t.Errorf("message %d has timestamp %d and block number %d, expected 10 and 7", i, msg.Timestamp, msg.BlockNumber)
+		}
+	}
+	if messages[2].DelayedMessage == nil || *messages[2].DelayedMessage != 1 {
+		t.Errorf("expected delayed message 1, got %v", messages[2].DelayedMessage)
+	}
+	for _, i := range []int{3, 4} {
+		if messages[i].Invalid == "" {
+			t.Errorf("expected message %d to be invalid", i)
+		}
+	}
+	if messages[5].Segment != nil || messages[5].DelayedMessage == nil || *messages[5].DelayedMessage != 2 {
+		t.Errorf("expected virtual delayed message 2, got %+v", messages[5])
+	}
+
+	// Trailing segments of unknown kinds are ignored once all delayed messages are read.
+	seqMsg.Segments = seqMsg.Segments[:6]
+	messages = DecodeBatchMessages(seqMsg, 2)
+	if len(messages) != 3 || messages[2].DelayedMessage == nil || *messages[2].DelayedMessage != 2 {
+		t.Errorf("expected the batch to end with delayed message 2, got %d messages", len(messages))
+	}
+
+	// A batch without messages still produces an invalid message.
+	messages = DecodeBatchMessages(&SequencerMessage{AfterDelayedMessages: 3}, 3)
+	if len(messages) != 1 || messages[0].Invalid == "" {
+		t.Errorf("expected a single invalid message, got %d messages", len(messages))
+	}
+}
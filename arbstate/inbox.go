@@ -214,7 +214,15 @@ func (r *inboxMultiplexer) Pop(ctx context.Context) (*arbostypes.MessageWithMeta
 			return nil, err
 		}
 	}
+	msg, _, err := r.popCachedMsg()
+	return msg, err
+}
+
+// popCachedMsg returns the next message of the cached sequencer message, along with
+// the number of the segment it was read from, and advances past it.
+func (r *inboxMultiplexer) popCachedMsg() (*arbostypes.MessageWithMetadata, uint64, error) {
 	msg, err := r.getNextMsg()
+	segmentNum := r.cachedSegmentNum
 	// advance even if there was an error
 	if r.IsCachedSegementLast() {
 		r.advanceSequencerMsg()
@@ -228,7 +236,7 @@ func (r *inboxMultiplexer) Pop(ctx context.Context) (*arbostypes.MessageWithMeta
 			DelayedMessagesRead: r.delayedMessagesRead,
 		}
 	}
-	return msg, err
+	return msg, segmentNum, err
 }
 
 func (r *inboxMultiplexer) advanceSequencerMsg() {
@@ -9,6 +9,7 @@ package backend
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -32,6 +33,7 @@ type BusinessLogicProvider interface {
 	GetEdges(ctx context.Context, opts ...db.EdgeOption) ([]*api.JsonEdge, error)
 	GetTrackedRoyalEdges(ctx context.Context) ([]*api.JsonEdgesByChallengedAssertion, error)
 	GetMiniStakes(ctx context.Context, assertionHash protocol.AssertionHash, opts ...db.EdgeOption) (*api.JsonMiniStakes, error)
+	SubscribeEvents() (<-chan *api.JsonEvent, func(), error)
 }
 
 type EdgeTrackerFetcher interface {
@@ -286,3 +288,14 @@ func (b *Backend) GetTrackedRoyalEdges(ctx context.Context) ([]*api.JsonEdgesByC
 	}
 	return edgesByAssertion, nil
 }
+
+// SubscribeEvents subscribes to the protocol events observed by the chain
+// watcher from now on.
+func (b *Backend) SubscribeEvents() (<-chan *api.JsonEvent, func(), error) {
+	feed := b.chainWatcher.EventFeed()
+	if feed == nil {
+		return nil, nil, errors.New("event feed not enabled")
+	}
+	events, unsubscribe := feed.Subscribe()
+	return events, unsubscribe, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see:
+// https://github.com/offchainlabs/nitro/blob/master/LICENSE.md
+
+package api
+
+import (
+	"sync"
+
+	"github.com/ethereum/go-ethereum/metrics"
+)
+
+var droppedEventSubscribersCounter = metrics.NewRegisteredCounter("arb/validator/api/events/dropped_subscribers", nil)
+
+// EventFeed fans out protocol events to subscribers. Publishing never
+// blocks: a subscriber that falls behind by more than its buffer is dropped,
+// and its channel closed, so it can resubscribe and catch up via the
+// polling endpoints instead of silently missing events.
+type EventFeed struct {
+	mutex       sync.Mutex
+	bufferSize  int
+	subscribers map[chan *JsonEvent]struct{}
+}
+
+func NewEventFeed(bufferSize int) *EventFeed {
+	return &EventFeed{
+		bufferSize:  bufferSize,
+		subscribers: make(map[chan *JsonEvent]struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving the events published from now on,
+// in order, and a function to unsubscribe.
+func (f *EventFeed) Subscribe() (<-chan *JsonEvent, func()) {
+	ch := make(chan *JsonEvent, f.bufferSize)
+	f.mutex.Lock()
+	f.subscribers[ch] = struct{}{}
+	f.mutex.Unlock()
+	return ch, func() {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		if _, ok := f.subscribers[ch]; ok {
+			delete(f.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+func (f *EventFeed) Publish(event *JsonEvent) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	for ch := range f.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(f.subscribers, ch)
+			close(ch)
+			droppedEventSubscribersCounter.Inc(1)
+		}
+	}
+}
+
+func (f *EventFeed) NumSubscribers() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return len(f.subscribers)
+}
@@ -0,0 +1,39 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see:
+// https://github.com/offchainlabs/nitro/blob/master/LICENSE.md
+
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventFeed(t *testing.T) {
+	feed := NewEventFeed(2)
+	fast, unsubscribeFast := feed.Subscribe()
+	slow, _ := feed.Subscribe()
+	require.Equal(t, 2, feed.NumSubscribers())
+
+	for i := uint64(0); i < 3; i++ {
+		feed.Publish(&JsonEvent{Type: EdgeAddedEvent, BlockNumber: i})
+		require.Equal(t, i, (<-fast).BlockNumber)
+	}
+	// The slow subscriber got the first events in order, then was dropped.
+	for i := uint64(0); i < 2; i++ {
+		event, ok := <-slow
+		require.True(t, ok)
+		require.Equal(t, i, event.BlockNumber)
+	}
+	_, ok := <-slow
+	require.False(t, ok)
+	require.Equal(t, 1, feed.NumSubscribers())
+
+	unsubscribeFast()
+	unsubscribeFast()
+	_, ok = <-fast
+	require.False(t, ok)
+	require.Equal(t, 0, feed.NumSubscribers())
+	feed.Publish(&JsonEvent{Type: EdgeAddedEvent})
+}
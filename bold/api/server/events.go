@@ -0,0 +1,149 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see:
+// https://github.com/offchainlabs/nitro/blob/master/LICENSE.md
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/bold/api"
+)
+
+var eventWriteTimeout = 10 * time.Second
+
+// StreamEvents upgrades the connection to a websocket and pushes the protocol
+// events observed by the validator to it as they happen, one JSON message per
+// event. Slow clients are disconnected rather than missing events, and should
+// reconnect and catch up with the polling endpoints.
+//
+// method:
+// - GET
+// - /api/v1/events
+//
+// request query params:
+//   - types: comma separated list of the event types to stream, all of them
+//     by default (assertion_created, assertion_confirmed, edge_added,
+//     edge_bisected, edge_confirmed_by_time, edge_confirmed_by_one_step_proof,
+//     mini_stake)
+//
+// response:
+// - a stream of *JsonEvent
+func (s *Server) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	var types map[api.EventType]bool
+	if val := r.URL.Query().Get("types"); val != "" {
+		types = make(map[api.EventType]bool)
+		for _, t := range strings.Split(val, ",") {
+			types[api.EventType(strings.TrimSpace(t))] = true
+		}
+	}
+	serverCtx, err := s.GetContextSafe()
+	if err != nil {
+		http.Error(w, "API server not started", http.StatusServiceUnavailable)
+		return
+	}
+	events, unsubscribe, err := s.backend.SubscribeEvents()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Could not subscribe to events: %v", err), http.StatusServiceUnavailable)
+		return
+	}
+	defer unsubscribe()
+	conn, _, _, err := ws.UpgradeHTTP(r, w)
+	if err != nil {
+		log.Warn("Could not upgrade events connection to websocket", "err", err, "remoteAddr", r.RemoteAddr)
+		return
+	}
+	defer conn.Close()
+	// Clear the deadlines the HTTP server set for regular requests.
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		log.Warn("Could not clear events connection deadline", "err", err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(serverCtx)
+	defer cancel()
+	// Answering pings and close frames writes to the connection, so the
+	// reader and the event writes below have to take turns.
+	var writeMutex sync.Mutex
+	// Read until the client goes away.
+	go func() {
+		defer cancel()
+		readEventsClient(conn, &writeMutex)
+	}()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				log.Warn("Disconnecting events client that fell behind", "remoteAddr", r.RemoteAddr)
+				return
+			}
+			if types != nil && !types[event.Type] {
+				continue
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Error("Could not marshal event", "err", err)
+				continue
+			}
+			if err := writeEvent(conn, &writeMutex, data); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func writeEvent(conn net.Conn, writeMutex *sync.Mutex, data []byte) error {
+	writeMutex.Lock()
+	defer writeMutex.Unlock()
+	if err := conn.SetWriteDeadline(time.Now().Add(eventWriteTimeout)); err != nil {
+		return err
+	}
+	return wsutil.WriteServerText(conn, data)
+}
+
+// Reads the frames sent by the client until it goes away, answering its
+// control frames and discarding its messages.
+func readEventsClient(conn net.Conn, writeMutex *sync.Mutex) {
+	controlHandler := wsutil.ControlFrameHandler(conn, ws.StateServerSide)
+	lockedControlHandler := func(header ws.Header, r io.Reader) error {
+		writeMutex.Lock()
+		defer writeMutex.Unlock()
+		return controlHandler(header, r)
+	}
+	reader := wsutil.Reader{
+		Source:    conn,
+		State:     ws.StateServerSide,
+		CheckUTF8: true,
+		// control frames may be sent between the frames of a fragmented message
+		OnIntermediate: lockedControlHandler,
+	}
+	for {
+		header, err := reader.NextFrame()
+		if err != nil {
+			return
+		}
+		if header.OpCode.IsControl() {
+			err = lockedControlHandler(header, &reader)
+		} else {
+			err = reader.Discard()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
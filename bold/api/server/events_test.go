@@ -0,0 +1,79 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see:
+// https://github.com/offchainlabs/nitro/blob/master/LICENSE.md
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gobwas/ws"
+	"github.com/gobwas/ws/wsutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/offchainlabs/nitro/bold/api"
+	"github.com/offchainlabs/nitro/bold/api/backend"
+)
+
+type eventsBackend struct {
+	backend.BusinessLogicProvider
+	feed *api.EventFeed
+}
+
+func (b *eventsBackend) SubscribeEvents() (<-chan *api.JsonEvent, func(), error) {
+	events, unsubscribe := b.feed.Subscribe()
+	return events, unsubscribe, nil
+}
+
+func TestStreamEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	const numEvents = 100
+	feed := api.NewEventFeed(2 * numEvents)
+	s := &Server{backend: &eventsBackend{feed: feed}}
+	s.StopWaiter.Start(ctx, s)
+	defer s.StopWaiter.StopAndWait()
+	httpServer := httptest.NewServer(http.HandlerFunc(s.StreamEvents))
+	defer httpServer.Close()
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") + apiVersion + "/events?types=edge_added"
+	conn, _, _, err := ws.Dial(ctx, url)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Eventually(t, func() bool { return feed.NumSubscribers() == 1 }, 10*time.Second, 10*time.Millisecond)
+
+	// The server answers the pings while it writes the events.
+	pingsDone := make(chan error, 1)
+	go func() {
+		for i := 0; i < numEvents; i++ {
+			if err := wsutil.WriteClientMessage(conn, ws.OpPing, nil); err != nil {
+				pingsDone <- err
+				return
+			}
+		}
+		pingsDone <- nil
+	}()
+	for i := uint64(0); i < numEvents; i++ {
+		feed.Publish(&api.JsonEvent{Type: api.AssertionCreatedEvent, BlockNumber: i})
+		feed.Publish(&api.JsonEvent{Type: api.EdgeAddedEvent, BlockNumber: i})
+	}
+	for i := uint64(0); i < numEvents; i++ {
+		data, err := wsutil.ReadServerText(conn)
+		require.NoError(t, err)
+		var event api.JsonEvent
+		require.NoError(t, json.Unmarshal(data, &event))
+		require.Equal(t, api.EdgeAddedEvent, event.Type)
+		require.Equal(t, i, event.BlockNumber)
+	}
+	require.NoError(t, <-pingsDone)
+
+	// Closing the connection unsubscribes the client.
+	require.NoError(t, wsutil.WriteClientMessage(conn, ws.OpClose, ws.NewCloseFrameBody(ws.StatusNormalClosure, "")))
+	require.Eventually(t, func() bool { return feed.NumSubscribers() == 0 }, 10*time.Second, 10*time.Millisecond)
+}
@@ -83,6 +83,7 @@ func (s *Server) registerMethods() error {
 	r.HandleFunc("/challenge/{assertion-hash}/ministakes", s.MiniStakes).Methods("GET")
 	r.HandleFunc("/tracked/royal-edges", s.RoyalTrackedChallengeEdges).Methods("GET")
 	r.HandleFunc("/state-provider/requests/collect-machine-hashes", s.CollectMachineHashes).Methods("GET")
+	r.HandleFunc("/events", s.StreamEvents).Methods("GET")
 	s.registered = true
 	return nil
 }
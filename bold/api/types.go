@@ -122,6 +122,76 @@ type JsonCollectMachineHashes struct {
 	FinishTime           *time.Time  `json:"finishTime" db:"FinishTime"`
 }
 
+type EventType string
+
+const (
+	AssertionCreatedEvent            EventType = "assertion_created"
+	AssertionConfirmedEvent          EventType = "assertion_confirmed"
+	EdgeAddedEvent                   EventType = "edge_added"
+	EdgeBisectedEvent                EventType = "edge_bisected"
+	EdgeConfirmedByTimeEvent         EventType = "edge_confirmed_by_time"
+	EdgeConfirmedByOneStepProofEvent EventType = "edge_confirmed_by_one_step_proof"
+	MiniStakeEvent                   EventType = "mini_stake"
+)
+
+// JsonEvent is a protocol event observed onchain, with Data holding one of
+// the Json*Event types below depending on its Type. An event may be delivered
+// twice if scanning its block range is retried; BlockNumber, TransactionHash
+// and LogIndex identify it.
+type JsonEvent struct {
+	Type            EventType   `json:"type"`
+	BlockNumber     uint64      `json:"blockNumber"`
+	TransactionHash common.Hash `json:"transactionHash"`
+	LogIndex        uint        `json:"logIndex"`
+	Data            any         `json:"data"`
+}
+
+type JsonAssertionCreatedEvent struct {
+	AssertionHash       common.Hash `json:"assertionHash"`
+	ParentAssertionHash common.Hash `json:"parentAssertionHash"`
+	InboxMaxCount       string      `json:"inboxMaxCount"`
+	WasmModuleRoot      common.Hash `json:"wasmModuleRoot"`
+	RequiredStake       string      `json:"requiredStake"`
+}
+
+type JsonAssertionConfirmedEvent struct {
+	AssertionHash common.Hash `json:"assertionHash"`
+	BlockHash     common.Hash `json:"blockHash"`
+	SendRoot      common.Hash `json:"sendRoot"`
+}
+
+type JsonEdgeAddedEvent struct {
+	EdgeId         common.Hash `json:"edgeId"`
+	MutualId       common.Hash `json:"mutualId"`
+	OriginId       common.Hash `json:"originId"`
+	ClaimId        common.Hash `json:"claimId"`
+	Length         string      `json:"length"`
+	ChallengeLevel uint8       `json:"challengeLevel"`
+	HasRival       bool        `json:"hasRival"`
+	IsLayerZero    bool        `json:"isLayerZero"`
+}
+
+type JsonEdgeBisectedEvent struct {
+	EdgeId                  common.Hash `json:"edgeId"`
+	LowerChildId            common.Hash `json:"lowerChildId"`
+	UpperChildId            common.Hash `json:"upperChildId"`
+	LowerChildAlreadyExists bool        `json:"lowerChildAlreadyExists"`
+}
+
+type JsonEdgeConfirmedEvent struct {
+	EdgeId             common.Hash `json:"edgeId"`
+	MutualId           common.Hash `json:"mutualId"`
+	TotalTimeUnrivaled uint64      `json:"totalTimeUnrivaled,omitempty"`
+}
+
+type JsonMiniStakeEvent struct {
+	EdgeId                  common.Hash    `json:"edgeId"`
+	ChallengeOriginId       common.Hash    `json:"challengeOriginId"`
+	ChallengedAssertionHash common.Hash    `json:"challengedAssertionHash"`
+	ChallengeLevel          uint8          `json:"challengeLevel"`
+	Staker                  common.Address `json:"staker"`
+}
+
 func IsNil(i any) bool {
 	return i == nil || reflect.ValueOf(i).IsNil()
 }
@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"sync/atomic"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
 
@@ -36,6 +38,7 @@ import (
 	"github.com/offchainlabs/nitro/bold/runtime"
 	"github.com/offchainlabs/nitro/bold/util/stopwaiter"
 	"github.com/offchainlabs/nitro/solgen/go/challengeV2gen"
+	"github.com/offchainlabs/nitro/solgen/go/rollupgen"
 )
 
 var (
@@ -46,6 +49,10 @@ var (
 	assertionConfirmedCounter               = metrics.GetOrRegisterCounter("arb/validator/scanner/assertion_confirmed", nil)
 )
 
+// Number of events a subscriber to the event feed can fall behind by before
+// being dropped.
+const eventFeedBufferSize = 1024
+
 // EdgeManager provides a method to track edges, via edge tracker goroutines.
 type EdgeManager interface {
 	TrackEdge(ctx context.Context, edge protocol.VerifiedRoyalEdge) error
@@ -88,6 +95,12 @@ type Watcher struct {
 	// Track all if empty / nil.
 	trackChallengeParentAssertionHashes []protocol.AssertionHash
 	maxGetLogBlocks                     uint64
+	eventFeed                           *api.EventFeed
+	// The events of the range being scanned, which are only published to the
+	// event feed once the whole range was processed.
+	pendingEvents []*api.JsonEvent
+	// The last event published, as the ranges scanned overlap by a block.
+	lastPublishedEvent *api.JsonEvent
 }
 
 // New initializes a watcher service for frequently scanning the chain
@@ -125,6 +138,19 @@ func (w *Watcher) SetEdgeManager(em EdgeManager) {
 	w.edgeManager = em
 }
 
+// EnableEventFeed makes the watcher publish the protocol events it observes,
+// including assertion and bisection events it otherwise doesn't scan for. It
+// must be called before starting the watcher.
+func (w *Watcher) EnableEventFeed() {
+	w.eventFeed = api.NewEventFeed(eventFeedBufferSize)
+}
+
+// EventFeed returns the feed of observed protocol events, or nil if it is
+// not enabled.
+func (w *Watcher) EventFeed() *api.EventFeed {
+	return w.eventFeed
+}
+
 // AvgBlockTime returns the average time for block creation.
 func (w *Watcher) AvgBlockTime() time.Duration {
 	return w.averageTimeForBlockCreation
@@ -223,26 +249,36 @@ func (w *Watcher) Start(ctx context.Context) {
 
 		// Checks for different events right away before we start polling.
 		_, err = retry.UntilSucceeds(ctx, func() (bool, error) {
-			return true, w.checkForEdgeAdded(ctx, filterer, filterOpts)
+			return true, w.queueingEvents(func() error { return w.checkForEdgeAdded(ctx, filterer, filterOpts) })
 		})
 		if err != nil {
 			log.Error("Could not check for edge added", "err", err)
 			return
 		}
 		_, err = retry.UntilSucceeds(ctx, func() (bool, error) {
-			return true, w.checkForEdgeConfirmedByOneStepProof(ctx, filterer, filterOpts)
+			return true, w.queueingEvents(func() error { return w.checkForEdgeConfirmedByOneStepProof(ctx, filterer, filterOpts) })
 		})
 		if err != nil {
 			log.Error("Could not check for edge confirmed by osp", "err", err)
 			return
 		}
 		_, err = retry.UntilSucceeds(ctx, func() (bool, error) {
-			return true, w.checkForEdgeConfirmedByTime(ctx, filterer, filterOpts)
+			return true, w.queueingEvents(func() error { return w.checkForEdgeConfirmedByTime(ctx, filterer, filterOpts) })
 		})
 		if err != nil {
 			log.Error("Could not check for edge confirmed by time", "err", err)
 			return
 		}
+		if w.eventFeed != nil {
+			_, err = retry.UntilSucceeds(ctx, func() (bool, error) {
+				return true, w.queueingEvents(func() error { return w.checkForStreamedEvents(filterer, filterOpts) })
+			})
+			if err != nil {
+				log.Error("Could not check for streamed events", "err", err)
+				return
+			}
+		}
+		w.publishPendingEvents()
 	}
 
 	fromBlock = toBlock
@@ -279,6 +315,8 @@ func (w *Watcher) Start(ctx context.Context) {
 				End:     &toBlock,
 				Context: ctx,
 			}
+			// Drop the events queued by a previous attempt at the range which failed.
+			w.pendingEvents = w.pendingEvents[:0]
 			if err = w.checkForEdgeAdded(ctx, filterer, filterOpts); err != nil {
 				log.Error("Could not check for edge added", "err", err)
 				continue
@@ -291,6 +329,13 @@ func (w *Watcher) Start(ctx context.Context) {
 				log.Error("Could not check for edge confirmed by time", "err", err)
 				continue
 			}
+			if w.eventFeed != nil {
+				if err = w.checkForStreamedEvents(filterer, filterOpts); err != nil {
+					log.Error("Could not check for streamed events", "err", err)
+					continue
+				}
+			}
+			w.publishPendingEvents()
 			fromBlock = toBlock
 		case <-ctx.Done():
 			return
@@ -584,6 +629,7 @@ func (w *Watcher) checkForEdgeAdded(
 		if edgeAdded {
 			edgeAddedCounter.Inc(1)
 		}
+		w.publishEdgeAdded(ctx, it.Event)
 	}
 	return nil
 }
@@ -742,6 +788,10 @@ func (w *Watcher) checkForEdgeConfirmedByOneStepProof(
 			return processErr
 		}
 		edgeConfirmedByOSPCounter.Inc(1)
+		w.publishEvent(api.EdgeConfirmedByOneStepProofEvent, it.Event.Raw, &api.JsonEdgeConfirmedEvent{
+			EdgeId:   it.Event.EdgeId,
+			MutualId: it.Event.MutualId,
+		})
 	}
 	return nil
 }
@@ -780,10 +830,170 @@ func (w *Watcher) checkForEdgeConfirmedByTime(
 			return processErr
 		}
 		edgeConfirmedByTimeCounter.Inc(1)
+		w.publishEvent(api.EdgeConfirmedByTimeEvent, it.Event.Raw, &api.JsonEdgeConfirmedEvent{
+			EdgeId:             it.Event.EdgeId,
+			MutualId:           it.Event.MutualId,
+			TotalTimeUnrivaled: it.Event.TotalTimeUnrivaled,
+		})
+	}
+	return nil
+}
+
+// Filters for the events within a range which are only of interest to the
+// event feed, namely assertion creations and confirmations, and bisections,
+// and publishes them.
+func (w *Watcher) checkForStreamedEvents(
+	filterer *challengeV2gen.EdgeChallengeManagerFilterer,
+	filterOpts *bind.FilterOpts,
+) error {
+	rollupFilterer, err := rollupgen.NewRollupUserLogicFilterer(w.chain.RollupAddress(), w.backend)
+	if err != nil {
+		return err
+	}
+	createdIt, err := rollupFilterer.FilterAssertionCreated(filterOpts, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer createdIt.Close()
+	for createdIt.Next() {
+		w.publishEvent(api.AssertionCreatedEvent, createdIt.Event.Raw, &api.JsonAssertionCreatedEvent{
+			AssertionHash:       createdIt.Event.AssertionHash,
+			ParentAssertionHash: createdIt.Event.ParentAssertionHash,
+			InboxMaxCount:       createdIt.Event.InboxMaxCount.String(),
+			WasmModuleRoot:      createdIt.Event.WasmModuleRoot,
+			RequiredStake:       createdIt.Event.RequiredStake.String(),
+		})
+	}
+	if err = createdIt.Error(); err != nil {
+		return err
+	}
+	confirmedIt, err := rollupFilterer.FilterAssertionConfirmed(filterOpts, nil)
+	if err != nil {
+		return err
+	}
+	defer confirmedIt.Close()
+	for confirmedIt.Next() {
+		w.publishEvent(api.AssertionConfirmedEvent, confirmedIt.Event.Raw, &api.JsonAssertionConfirmedEvent{
+			AssertionHash: confirmedIt.Event.AssertionHash,
+			BlockHash:     confirmedIt.Event.BlockHash,
+			SendRoot:      confirmedIt.Event.SendRoot,
+		})
+	}
+	if err = confirmedIt.Error(); err != nil {
+		return err
+	}
+	bisectedIt, err := filterer.FilterEdgeBisected(filterOpts, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer bisectedIt.Close()
+	for bisectedIt.Next() {
+		w.publishEvent(api.EdgeBisectedEvent, bisectedIt.Event.Raw, &api.JsonEdgeBisectedEvent{
+			EdgeId:                  bisectedIt.Event.EdgeId,
+			LowerChildId:            bisectedIt.Event.LowerChildId,
+			UpperChildId:            bisectedIt.Event.UpperChildId,
+			LowerChildAlreadyExists: bisectedIt.Event.LowerChildAlreadyExists,
+		})
+	}
+	return bisectedIt.Error()
+}
+
+// Publishes an edge added event, and the mini-stake that came with it for
+// layer zero edges.
+func (w *Watcher) publishEdgeAdded(ctx context.Context, event *challengeV2gen.EdgeChallengeManagerEdgeAdded) {
+	if w.eventFeed == nil {
+		return
+	}
+	w.publishEvent(api.EdgeAddedEvent, event.Raw, &api.JsonEdgeAddedEvent{
+		EdgeId:         event.EdgeId,
+		MutualId:       event.MutualId,
+		OriginId:       event.OriginId,
+		ClaimId:        event.ClaimId,
+		Length:         event.Length.String(),
+		ChallengeLevel: event.Level,
+		HasRival:       event.HasRival,
+		IsLayerZero:    event.IsLayerZero,
+	})
+	if !event.IsLayerZero {
+		return
+	}
+	edgeOpt, err := w.chain.SpecChallengeManager().GetEdge(ctx, protocol.EdgeId{Hash: event.EdgeId})
+	if err != nil || edgeOpt.IsNone() {
+		log.Warn("Could not get layer zero edge to publish its mini-stake", "edgeId", common.Hash(event.EdgeId), "err", err)
+		return
+	}
+	edge := edgeOpt.Unwrap()
+	if edge.MiniStaker().IsNone() {
+		return
+	}
+	challengedAssertionHash, err := edge.AssertionHash(ctx)
+	if err != nil {
+		log.Warn("Could not get challenged assertion of layer zero edge to publish its mini-stake", "edgeId", common.Hash(event.EdgeId), "err", err)
+		return
+	}
+	w.publishEvent(api.MiniStakeEvent, event.Raw, &api.JsonMiniStakeEvent{
+		EdgeId:                  event.EdgeId,
+		ChallengeOriginId:       event.OriginId,
+		ChallengedAssertionHash: challengedAssertionHash.Hash,
+		ChallengeLevel:          event.Level,
+		Staker:                  edge.MiniStaker().Unwrap(),
+	})
+}
+
+// Queues an event to be published once the range being scanned was processed.
+func (w *Watcher) publishEvent(eventType api.EventType, raw types.Log, data any) {
+	if w.eventFeed == nil {
+		return
+	}
+	w.pendingEvents = append(w.pendingEvents, &api.JsonEvent{
+		Type:            eventType,
+		BlockNumber:     raw.BlockNumber,
+		TransactionHash: raw.TxHash,
+		LogIndex:        raw.Index,
+		Data:            data,
+	})
+}
+
+// Runs a check of the events within a range, dropping the events it queued
+// if it fails, so that retrying it doesn't queue them twice.
+func (w *Watcher) queueingEvents(check func() error) error {
+	queued := len(w.pendingEvents)
+	if err := check(); err != nil {
+		w.pendingEvents = w.pendingEvents[:queued]
+		return err
 	}
 	return nil
 }
 
+// Publishes the events queued while scanning a range in the order they were
+// emitted, skipping those already published with the previous range.
+func (w *Watcher) publishPendingEvents() {
+	if w.eventFeed == nil {
+		return
+	}
+	// The mini-stake events share the log of the edge they were added with,
+	// so the sort is stable to publish them after it.
+	sort.SliceStable(w.pendingEvents, func(i, j int) bool {
+		return eventBefore(w.pendingEvents[i], w.pendingEvents[j])
+	})
+	lastPublished := w.lastPublishedEvent
+	for _, event := range w.pendingEvents {
+		if lastPublished != nil && !eventBefore(lastPublished, event) {
+			continue
+		}
+		w.eventFeed.Publish(event)
+		w.lastPublishedEvent = event
+	}
+	w.pendingEvents = w.pendingEvents[:0]
+}
+
+func eventBefore(a, b *api.JsonEvent) bool {
+	if a.BlockNumber != b.BlockNumber {
+		return a.BlockNumber < b.BlockNumber
+	}
+	return a.LogIndex < b.LogIndex
+}
+
 // Processes an edge confirmation event by checking if it claims an edge. If so,
 // we add the claim id to the confirmed, level zero edge claim ids map for the
 // associated assertion-level challenge the edge is a part of.
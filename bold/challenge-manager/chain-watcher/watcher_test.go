@@ -6,6 +6,7 @@ package watcher
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"testing"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 
+	"github.com/offchainlabs/nitro/bold/api"
 	"github.com/offchainlabs/nitro/bold/chain-abstraction"
 	"github.com/offchainlabs/nitro/bold/containers/option"
 	"github.com/offchainlabs/nitro/bold/containers/threadsafe"
@@ -311,3 +313,70 @@ func TestWatcher_AddVerifiedHonestEdge(t *testing.T) {
 	_, ok := watcher.challenges.TryGet(assertionHash)
 	require.Equal(t, true, ok)
 }
+
+func TestWatcher_publishPendingEvents(t *testing.T) {
+	w := &Watcher{eventFeed: api.NewEventFeed(16)}
+	events, unsubscribe := w.eventFeed.Subscribe()
+	defer unsubscribe()
+	queue := func(eventType api.EventType, block uint64, index uint) {
+		w.publishEvent(eventType, types.Log{BlockNumber: block, Index: index}, nil)
+	}
+	type position struct {
+		eventType api.EventType
+		block     uint64
+		index     uint
+	}
+	received := func(count int) []position {
+		var got []position
+		for i := 0; i < count; i++ {
+			event := <-events
+			got = append(got, position{event.Type, event.BlockNumber, event.LogIndex})
+		}
+		require.Empty(t, events)
+		return got
+	}
+
+	// A failed check drops the events it queued, so that retrying it doesn't
+	// publish them twice.
+	require.Error(t, w.queueingEvents(func() error {
+		queue(api.EdgeAddedEvent, 10, 1)
+		return errors.New("failed to get logs")
+	}))
+	require.NoError(t, w.queueingEvents(func() error {
+		queue(api.EdgeAddedEvent, 10, 1)
+		queue(api.MiniStakeEvent, 10, 1)
+		queue(api.EdgeAddedEvent, 12, 0)
+		return nil
+	}))
+	require.NoError(t, w.queueingEvents(func() error {
+		queue(api.EdgeConfirmedByTimeEvent, 11, 3)
+		return nil
+	}))
+	require.NoError(t, w.queueingEvents(func() error {
+		queue(api.AssertionCreatedEvent, 10, 0)
+		queue(api.EdgeBisectedEvent, 12, 2)
+		return nil
+	}))
+	require.Empty(t, events)
+	w.publishPendingEvents()
+	require.Equal(t, []position{
+		{api.AssertionCreatedEvent, 10, 0},
+		{api.EdgeAddedEvent, 10, 1},
+		{api.MiniStakeEvent, 10, 1},
+		{api.EdgeConfirmedByTimeEvent, 11, 3},
+		{api.EdgeAddedEvent, 12, 0},
+		{api.EdgeBisectedEvent, 12, 2},
+	}, received(6))
+
+	// The next range starts at the last block of the previous one, whose
+	// events were already published.
+	queue(api.EdgeAddedEvent, 12, 0)
+	queue(api.EdgeBisectedEvent, 12, 2)
+	queue(api.AssertionConfirmedEvent, 12, 5)
+	queue(api.EdgeAddedEvent, 13, 0)
+	w.publishPendingEvents()
+	require.Equal(t, []position{
+		{api.AssertionConfirmedEvent, 12, 5},
+		{api.EdgeAddedEvent, 13, 0},
+	}, received(2))
+}
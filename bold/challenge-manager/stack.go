@@ -237,6 +237,7 @@ func NewChallengeStack(
 	// Create the api backend server.
 	var api *server.Server
 	if params.apiAddr != "" {
+		watcher.EnableEventFeed()
 		bknd := backend.NewBackend(apiDB, chain, watcher)
 		api, err = server.New(params.apiAddr, bknd)
 		if err != nil {
@@ -0,0 +1,392 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see:
+// https://github.com/offchainlabs/nitro/blob/master/LICENSE.md
+
+package endtoend
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"sort"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/offchainlabs/nitro/bold/api/db"
+	"github.com/offchainlabs/nitro/bold/chain-abstraction"
+	"github.com/offchainlabs/nitro/bold/layer2-state-provider"
+	"github.com/offchainlabs/nitro/bold/runtime"
+	"github.com/offchainlabs/nitro/bold/testing/mocks/state-provider"
+	"github.com/offchainlabs/nitro/bold/testing/setup"
+	"github.com/offchainlabs/nitro/cmd/util/confighelpers"
+	"github.com/offchainlabs/nitro/solgen/go/challengeV2gen"
+	"github.com/offchainlabs/nitro/solgen/go/rollupgen"
+	"github.com/offchainlabs/nitro/staker/bold"
+	"github.com/offchainlabs/nitro/staker/challenge-cache"
+)
+
+// TestEndToEnd_ChallengeSimulation rehearses a challenge and logs how long it
+// took to confirm an assertion, along with the gas each validator spent and
+// the edges each of them created per challenge level. The protocol, evil
+// validator and state provider parameters can be changed to match those of a
+// chain by passing flags after a "--" delimiter, see the README.
+func TestEndToEnd_ChallengeSimulation(t *testing.T) {
+	cfg, err := parseSimulationConfig(simulationArgs())
+	require.NoError(t, err)
+	if cfg.StateProviderConfig.MachineLeavesCachePath == "" {
+		cfg.StateProviderConfig.MachineLeavesCachePath = t.TempDir()
+	}
+	protocolCfg := defaultProtocolParams()
+	protocolCfg.challengePeriodBlocks = cfg.Protocol.ConfirmPeriodBlocks
+	protocolCfg.numBigStepLevels = cfg.Protocol.NumBigStepLevels
+	protocolCfg.layerZeroHeights = protocol.LayerZeroHeights{
+		BlockChallengeHeight:     cfg.Protocol.BlockChallengeHeight,
+		BigStepChallengeHeight:   cfg.Protocol.BigStepChallengeHeight,
+		SmallStepChallengeHeight: cfg.Protocol.SmallStepChallengeHeight,
+	}
+	timeCfg := defaultTimeParams()
+	timeCfg.blockTime = cfg.BlockTime
+	runEndToEndTest(t, &e2eConfig{
+		backend:  simulated,
+		protocol: protocolCfg,
+		inbox: inboxParams{
+			numBatchesPosted: cfg.NumBatches,
+		},
+		actors: actorParams{
+			numEvilValidators: cfg.Evil.Validators,
+			evil: &evilParams{
+				blockDivergenceHeight: cfg.Evil.BlockDivergenceHeight,
+				blockHeightOffset:     cfg.Evil.BlockHeightOffset,
+			},
+			honestStateProvider: &cfg.StateProviderConfig,
+		},
+		timings: timeCfg,
+		expectations: []expect{
+			expectChallengeWinWithAllHonestEssentialEdgesConfirmed,
+			reportChallengeSimulation,
+		},
+	})
+}
+
+type simulationConfig struct {
+	Protocol            simulationProtocolConfig `koanf:"protocol"`
+	Evil                simulationEvilConfig     `koanf:"evil"`
+	NumBatches          uint64                   `koanf:"num-batches"`
+	BlockTime           time.Duration            `koanf:"block-time"`
+	StateProviderConfig bold.StateProviderConfig `koanf:"state-provider-config"`
+}
+
+type simulationProtocolConfig struct {
+	ConfirmPeriodBlocks      uint64 `koanf:"confirm-period-blocks"`
+	NumBigStepLevels         uint8  `koanf:"num-big-step-levels"`
+	BlockChallengeHeight     uint64 `koanf:"block-challenge-height"`
+	BigStepChallengeHeight   uint64 `koanf:"big-step-challenge-height"`
+	SmallStepChallengeHeight uint64 `koanf:"small-step-challenge-height"`
+}
+
+type simulationEvilConfig struct {
+	Validators            uint64 `koanf:"validators"`
+	BlockDivergenceHeight uint64 `koanf:"block-divergence-height"`
+	BlockHeightOffset     int64  `koanf:"block-height-offset"`
+}
+
+func (c *simulationConfig) Validate() error {
+	if c.Protocol.NumBigStepLevels == 0 {
+		return errors.New("the number of big step levels must be at least 1")
+	}
+	for _, height := range []uint64{c.Protocol.BlockChallengeHeight, c.Protocol.BigStepChallengeHeight, c.Protocol.SmallStepChallengeHeight} {
+		if height == 0 || height&(height-1) != 0 {
+			return errors.New("the challenge heights must be powers of two")
+		}
+	}
+	if c.NumBatches == 0 {
+		return errors.New("at least one batch must be posted")
+	}
+	if c.BlockTime <= 0 {
+		return errors.New("the block time must be positive")
+	}
+	return nil
+}
+
+func simulationConfigAddOptions(f *pflag.FlagSet) {
+	protocolCfg := defaultProtocolParams()
+	evil := defaultEvilParams()
+	f.Uint64("protocol.confirm-period-blocks", protocolCfg.challengePeriodBlocks, "number of parent chain blocks before an assertion can be confirmed")
+	f.Uint8("protocol.num-big-step-levels", protocolCfg.numBigStepLevels, "number of big step challenge levels")
+	f.Uint64("protocol.block-challenge-height", protocolCfg.layerZeroHeights.BlockChallengeHeight, "height of the layer zero edges of block challenges")
+	f.Uint64("protocol.big-step-challenge-height", protocolCfg.layerZeroHeights.BigStepChallengeHeight, "height of the layer zero edges of big step challenges")
+	f.Uint64("protocol.small-step-challenge-height", protocolCfg.layerZeroHeights.SmallStepChallengeHeight, "height of the layer zero edges of small step challenges")
+	f.Uint64("evil.validators", 1, "number of evil validators")
+	f.Uint64("evil.block-divergence-height", evil.blockDivergenceHeight, "block height at which the assertions of the evil validators diverge")
+	f.Int64("evil.block-height-offset", evil.blockHeightOffset, "offset of the position in batch at which the evil validators diverge")
+	f.Uint64("num-batches", defaultInboxParams().numBatchesPosted, "number of batches posted to the inbox")
+	f.Duration("block-time", defaultTimeParams().blockTime, "parent chain block time")
+	bold.StateProviderConfigAddOptions("state-provider-config", f)
+}
+
+// Flags can only be passed to the package defining them, so the simulation
+// parameters are passed after a "--" delimiter on the command line.
+func simulationArgs() []string {
+	for i, arg := range os.Args {
+		if arg == "--" {
+			return os.Args[i+1:]
+		}
+	}
+	return nil
+}
+
+func parseSimulationConfig(args []string) (*simulationConfig, error) {
+	f := pflag.NewFlagSet("simulation", pflag.ContinueOnError)
+	simulationConfigAddOptions(f)
+	k, err := confighelpers.BeginCommonParse(f, args)
+	if err != nil {
+		return nil, err
+	}
+	var cfg simulationConfig
+	if err := confighelpers.EndCommonParse(k, &cfg); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	// Unless set, the machine hashes are cached in a temporary directory
+	// rather than in the working directory.
+	if !f.Changed("state-provider-config.machine-leaves-cache-path") {
+		cfg.StateProviderConfig.MachineLeavesCachePath = ""
+	}
+	return &cfg, nil
+}
+
+// Serves the history commitments of the honest validator like the state
+// provider of a node does, caching the machine hashes it collects in the
+// challenge cache configured by its StateProviderConfig.
+type cachingStateProvider struct {
+	*stateprovider.L2StateBackend
+	*l2stateprovider.HistoryCommitmentProvider
+}
+
+func (p *cachingStateProvider) UpdateAPIDatabase(database db.Database) {
+	p.HistoryCommitmentProvider.UpdateAPIDatabase(database)
+}
+
+func newCachingStateProvider(
+	t *testing.T,
+	backend *stateprovider.L2StateBackend,
+	cfg *bold.StateProviderConfig,
+	protocolCfg *protocolParams,
+) *cachingStateProvider {
+	cache, err := challengecache.New(cfg.MachineLeavesCachePath, challengecache.WithMaxSize(cfg.MachineLeavesCacheMaxSize))
+	require.NoError(t, err)
+	collector := &cachingHashCollector{
+		collector: backend,
+		cache:     cache,
+	}
+	t.Cleanup(func() {
+		t.Logf("%s machine hashes cache at %s: %d hits, %d misses", cfg.ValidatorName, cfg.MachineLeavesCachePath, collector.hits.Load(), collector.misses.Load())
+	})
+	heights := []l2stateprovider.Height{l2stateprovider.Height(protocolCfg.layerZeroHeights.BlockChallengeHeight)}
+	for i := uint8(0); i < protocolCfg.numBigStepLevels; i++ {
+		heights = append(heights, l2stateprovider.Height(protocolCfg.layerZeroHeights.BigStepChallengeHeight))
+	}
+	heights = append(heights, l2stateprovider.Height(protocolCfg.layerZeroHeights.SmallStepChallengeHeight))
+	return &cachingStateProvider{
+		L2StateBackend:            backend,
+		HistoryCommitmentProvider: l2stateprovider.NewHistoryCommitmentProvider(backend, collector, backend, heights, backend, nil),
+	}
+}
+
+// Looks up machine hashes in the cache before collecting them, and stores
+// them after, the same way the BOLD state provider of a node does.
+type cachingHashCollector struct {
+	collector l2stateprovider.MachineHashCollector
+	cache     challengecache.HistoryCommitmentCacher
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+}
+
+func (c *cachingHashCollector) CollectMachineHashes(
+	ctx context.Context, cfg *l2stateprovider.HashCollectorConfig,
+) ([]common.Hash, error) {
+	stepHeights := make([]uint64, len(cfg.StepHeights))
+	for i, h := range cfg.StepHeights {
+		stepHeights[i] = uint64(h)
+	}
+	cacheKey := &challengecache.Key{
+		RollupBlockHash: cfg.AssertionMetadata.FromState.BlockHash,
+		WavmModuleRoot:  cfg.AssertionMetadata.WasmModuleRoot,
+		MessageHeight:   uint64(cfg.BlockChallengeHeight),
+		StepHeights:     stepHeights,
+	}
+	cachedRoots, err := c.cache.Get(cacheKey, cfg.NumDesiredHashes)
+	switch {
+	case err == nil:
+		c.hits.Add(1)
+		return cachedRoots, nil
+	case !errors.Is(err, challengecache.ErrNotFoundInCache):
+		return nil, err
+	}
+	c.misses.Add(1)
+	result, err := c.collector.CollectMachineHashes(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	// Do not save a history commitment of length 1 to the cache.
+	if len(result) > 1 {
+		if err := c.cache.Put(cacheKey, result); err != nil {
+			if !errors.Is(err, challengecache.ErrFileAlreadyExists) {
+				return nil, err
+			}
+		}
+	}
+	return result, nil
+}
+
+type validatorSimulationReport struct {
+	transactions  uint64
+	gasUsed       uint64
+	spent         *big.Int
+	edgesPerLevel map[uint8]uint64
+}
+
+// Waits for the first assertion confirmation, then logs what the challenge
+// cost each validator.
+func reportChallengeSimulation(
+	t *testing.T,
+	ctx context.Context,
+	addresses *setup.RollupAddresses,
+	backend protocol.ChainBackend,
+	honestValidatorAddress common.Address,
+) error {
+	start := time.Now()
+	startHeader, err := backend.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	rc, err := rollupgen.NewRollupCore(addresses.Rollup, backend)
+	require.NoError(t, err)
+	cmAddr, err := rc.ChallengeManager(&bind.CallOpts{Context: ctx})
+	require.NoError(t, err)
+
+	var confirmedAt uint64
+	for confirmedAt == 0 {
+		it, err := retry.UntilSucceeds(ctx, func() (*rollupgen.RollupCoreAssertionConfirmedIterator, error) {
+			return rc.FilterAssertionConfirmed(&bind.FilterOpts{Context: ctx}, nil)
+		})
+		if err != nil {
+			return err
+		}
+		if it.Next() {
+			confirmedAt = it.Event.Raw.BlockNumber
+		}
+		require.NoError(t, it.Close())
+		if confirmedAt != 0 {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+	duration := time.Since(start)
+
+	reports := collectSimulationReports(t, ctx, backend, startHeader.Number.Uint64(), []common.Address{addresses.Rollup, cmAddr}, cmAddr)
+	t.Logf("Assertion confirmed after %v (%d parent chain blocks)", duration.Round(time.Millisecond), confirmedAt-startHeader.Number.Uint64())
+	var levels []uint8
+	for _, report := range reports {
+		for level := range report.edgesPerLevel {
+			levels = append(levels, level)
+		}
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i] < levels[j] })
+	for sender, report := range reports {
+		name := "evil"
+		if sender == honestValidatorAddress {
+			name = "honest"
+		}
+		t.Logf("%s validator %v: %d transactions, %d gas, %s wei spent", name, sender, report.transactions, report.gasUsed, report.spent)
+		for i, level := range levels {
+			if i > 0 && levels[i-1] == level {
+				continue
+			}
+			t.Logf("  level %d: %d edges", level, report.edgesPerLevel[level])
+		}
+	}
+
+	honest, ok := reports[honestValidatorAddress]
+	require.True(t, ok, "honest validator sent no transactions")
+	require.NotZero(t, honest.gasUsed)
+	require.NotZero(t, honest.edgesPerLevel[0], "honest validator created no block level edges")
+	return nil
+}
+
+// Attributes the transactions emitting logs from the protocol contracts, and
+// the edges added to the challenge, to their senders.
+func collectSimulationReports(
+	t *testing.T,
+	ctx context.Context,
+	backend protocol.ChainBackend,
+	fromBlock uint64,
+	contracts []common.Address,
+	challengeManager common.Address,
+) map[common.Address]*validatorSimulationReport {
+	chainId, err := backend.ChainID(ctx)
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(chainId)
+	senderOf := func(txHash common.Hash) common.Address {
+		tx, _, err := backend.TransactionByHash(ctx, txHash)
+		require.NoError(t, err)
+		sender, err := types.Sender(signer, tx)
+		require.NoError(t, err)
+		return sender
+	}
+	reports := make(map[common.Address]*validatorSimulationReport)
+	reportOf := func(sender common.Address) *validatorSimulationReport {
+		report, ok := reports[sender]
+		if !ok {
+			report = &validatorSimulationReport{spent: new(big.Int), edgesPerLevel: make(map[uint8]uint64)}
+			reports[sender] = report
+		}
+		return report
+	}
+
+	logs, err := backend.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		Addresses: contracts,
+	})
+	require.NoError(t, err)
+	seen := make(map[common.Hash]struct{})
+	for _, l := range logs {
+		if _, ok := seen[l.TxHash]; ok {
+			continue
+		}
+		seen[l.TxHash] = struct{}{}
+		receipt, err := backend.TransactionReceipt(ctx, l.TxHash)
+		require.NoError(t, err)
+		report := reportOf(senderOf(l.TxHash))
+		report.transactions++
+		report.gasUsed += receipt.GasUsed
+		if receipt.EffectiveGasPrice != nil {
+			report.spent.Add(report.spent, new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice))
+		}
+	}
+
+	edgeChallengeManager, err := challengeV2gen.NewEdgeChallengeManager(challengeManager, backend)
+	require.NoError(t, err)
+	it, err := edgeChallengeManager.FilterEdgeAdded(&bind.FilterOpts{Context: ctx, Start: fromBlock}, nil, nil, nil)
+	require.NoError(t, err)
+	defer it.Close()
+	for it.Next() {
+		reportOf(senderOf(it.Event.Raw.TxHash)).edgesPerLevel[it.Event.Level]++
+	}
+	require.NoError(t, it.Error())
+	return reports
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/offchainlabs/nitro/bold/chain-abstraction"
 	"github.com/offchainlabs/nitro/bold/challenge-manager"
 	"github.com/offchainlabs/nitro/bold/challenge-manager/types"
+	"github.com/offchainlabs/nitro/bold/layer2-state-provider"
 	"github.com/offchainlabs/nitro/bold/testing"
 	"github.com/offchainlabs/nitro/bold/testing/endtoend/backend"
 	"github.com/offchainlabs/nitro/bold/testing/mocks/state-provider"
@@ -30,6 +31,7 @@ import (
 	"github.com/offchainlabs/nitro/solgen/go/bridgegen"
 	"github.com/offchainlabs/nitro/solgen/go/mocksgen"
 	"github.com/offchainlabs/nitro/solgen/go/rollupgen"
+	"github.com/offchainlabs/nitro/staker/bold"
 )
 
 type backendKind uint8
@@ -64,6 +66,26 @@ type e2eConfig struct {
 // Defines parameters related to the actors participating in the test.
 type actorParams struct {
 	numEvilValidators uint64
+	// Where the evil validators diverge from the honest one, see
+	// defaultEvilParams if nil.
+	evil *evilParams
+	// If set, the honest validator caches the machine hashes of its history
+	// commitments as configured, like the state provider of a node does.
+	honestStateProvider *bold.StateProviderConfig
+}
+
+// Defines how the assertions of the evil validators diverge from the honest
+// ones.
+type evilParams struct {
+	blockDivergenceHeight uint64
+	blockHeightOffset     int64
+}
+
+func defaultEvilParams() evilParams {
+	return evilParams{
+		blockDivergenceHeight: 1,
+		blockHeightOffset:     1,
+	}
 }
 
 // Configures intervals related to timings in the system.
@@ -233,6 +255,10 @@ func runEndToEndTest(t *testing.T, cfg *e2eConfig) {
 	}
 	honestStateManager, err := stateprovider.NewForSimpleMachine(t, baseStateManagerOpts...)
 	require.NoError(t, err)
+	var honestProvider l2stateprovider.Provider = honestStateManager
+	if cfg.actors.honestStateProvider != nil {
+		honestProvider = newCachingStateProvider(t, honestStateManager, cfg.actors.honestStateProvider, &cfg.protocol)
+	}
 
 	shp := &simpleHeaderProvider{b: bk, chs: make([]chan<- *gethtypes.Header, 0)}
 	shp.Start(ctx)
@@ -255,14 +281,16 @@ func runEndToEndTest(t *testing.T, cfg *e2eConfig) {
 		challengemanager.StackWithName(name),
 	)
 	honestChain := setupAssertionChain(t, ctx, bk.Client(), rollupAddr.Rollup, txOpts)
-	honestManager, err := challengemanager.NewChallengeStack(honestChain, honestStateManager, honestOpts...)
+	honestManager, err := challengemanager.NewChallengeStack(honestChain, honestProvider, honestOpts...)
 	require.NoError(t, err)
 
 	totalOpcodes := totalWasmOpcodes(&cfg.protocol.layerZeroHeights, cfg.protocol.numBigStepLevels)
 	t.Logf("Total wasm opcodes in test: %d", totalOpcodes)
 
-	assertionDivergenceHeight := uint64(1)
-	assertionBlockHeightDifference := int64(1)
+	evil := defaultEvilParams()
+	if cfg.actors.evil != nil {
+		evil = *cfg.actors.evil
+	}
 
 	evilChallengeManagers := make([]*challengemanager.Manager, cfg.actors.numEvilValidators)
 	for i := uint64(0); i < cfg.actors.numEvilValidators; i++ {
@@ -275,8 +303,8 @@ func runEndToEndTest(t *testing.T, cfg *e2eConfig) {
 		evilStateManagerOpts := append(
 			baseStateManagerOpts,
 			stateprovider.WithMachineDivergenceStep(machineDivergenceStep),
-			stateprovider.WithBlockDivergenceHeight(assertionDivergenceHeight),
-			stateprovider.WithDivergentBlockHeightOffset(assertionBlockHeightDifference),
+			stateprovider.WithBlockDivergenceHeight(evil.blockDivergenceHeight),
+			stateprovider.WithDivergentBlockHeightOffset(evil.blockHeightOffset),
 		)
 		evilStateManager, err := stateprovider.NewForSimpleMachine(t, evilStateManagerOpts...)
 		require.NoError(t, err)
@@ -25,6 +25,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/offchainlabs/nitro/arbutil"
 	"github.com/offchainlabs/nitro/broadcaster/message"
@@ -47,6 +48,9 @@ type FeedConfig struct {
 }
 
 func (fc *FeedConfig) Validate() error {
+	if err := fc.Input.Validate(); err != nil {
+		return err
+	}
 	return fc.Output.Validate()
 }
 
@@ -74,12 +78,24 @@ type Config struct {
 	SecondaryURL            []string                 `koanf:"secondary-url"`
 	Verify                  signature.VerifierConfig `koanf:"verify"`
 	EnableCompression       bool                     `koanf:"enable-compression" reload:"hot"`
+	EnableBinary            bool                     `koanf:"enable-binary" reload:"hot"`
+	Quorum                  int                      `koanf:"quorum"`
 }
 
 func (c *Config) Enable() bool {
 	return len(c.URL) > 0 && c.URL[0] != ""
 }
 
+func (c *Config) Validate() error {
+	if c.Quorum < 0 || c.Quorum > len(c.URL) {
+		return fmt.Errorf("feed input quorum %d must be between 0 and the number of primary feed URLs (%d)", c.Quorum, len(c.URL))
+	}
+	if c.Quorum > 0 && len(c.SecondaryURL) > 0 {
+		return errors.New("secondary feed URLs can't be used with a feed input quorum")
+	}
+	return nil
+}
+
 type ConfigFetcher func() *Config
 
 func ConfigAddOptions(prefix string, f *pflag.FlagSet) {
@@ -92,6 +108,8 @@ func ConfigAddOptions(prefix string, f *pflag.FlagSet) {
 	f.StringSlice(prefix+".secondary-url", DefaultConfig.SecondaryURL, "list of secondary URLs of sequencer feed source. Would be started in the order they appear in the list when primary feeds fails")
 	signature.FeedVerifierConfigAddOptions(prefix+".verify", f)
 	f.Bool(prefix+".enable-compression", DefaultConfig.EnableCompression, "enable per message deflate compression support")
+	f.Bool(prefix+".enable-binary", DefaultConfig.EnableBinary, "request the compact binary (RLP) encoding of feed messages, falling back to JSON if the feed server doesn't support it")
+	f.Int(prefix+".quorum", DefaultConfig.Quorum, "only forward a message once this many primary feeds delivered the same message and block hash for its sequence number (0 to forward the first delivered message)")
 }
 
 var DefaultConfig = Config{
@@ -104,6 +122,8 @@ var DefaultConfig = Config{
 	SecondaryURL:            []string{},
 	Timeout:                 20 * time.Second,
 	EnableCompression:       true,
+	EnableBinary:            false,
+	Quorum:                  0,
 }
 
 var DefaultTestConfig = Config{
@@ -116,6 +136,8 @@ var DefaultTestConfig = Config{
 	SecondaryURL:            []string{},
 	Timeout:                 200 * time.Millisecond,
 	EnableCompression:       true,
+	EnableBinary:            false,
+	Quorum:                  0,
 }
 
 type TransactionStreamerInterface interface {
@@ -224,18 +246,23 @@ func (bc *BroadcastClient) connect(ctx context.Context, nextSeqNum arbutil.Messa
 		return nil, nil
 	}
 
-	header := ws.HandshakeHeaderHTTP(http.Header{
+	config := bc.config()
+	httpHeader := http.Header{
 		wsbroadcastserver.HTTPHeaderFeedClientVersion:       []string{strconv.Itoa(wsbroadcastserver.FeedClientVersion)},
 		wsbroadcastserver.HTTPHeaderRequestedSequenceNumber: []string{strconv.FormatUint(uint64(nextSeqNum), 10)},
-	})
+	}
+	if config.EnableBinary {
+		httpHeader[wsbroadcastserver.HTTPHeaderFeedEncoding] = []string{message.EncodingRLP}
+	}
+	header := ws.HandshakeHeaderHTTP(httpHeader)
 
 	log.Info("connecting to arbitrum inbox message broadcaster", "url", bc.websocketUrl)
 	var foundChainId bool
 	var foundFeedServerVersion bool
 	var chainId uint64
 	var feedServerVersion uint64
+	feedEncoding := message.EncodingJSON
 
-	config := bc.config()
 	var extensions []httphead.Option
 	deflateExt := wsflate.DefaultParameters.Option()
 	if config.EnableCompression {
@@ -262,6 +289,8 @@ func (bc *BroadcastClient) connect(ctx context.Context, nextSeqNum arbutil.Messa
 					)
 					return ErrIncorrectFeedServerVersion
 				}
+			} else if headerName == wsbroadcastserver.HTTPHeaderFeedEncoding {
+				feedEncoding = headerValue
 			} else if headerName == wsbroadcastserver.HTTPHeaderChainId {
 				foundChainId = true
 				chainId, err = strconv.ParseUint(headerValue, 0, 64)
@@ -340,6 +369,9 @@ func (bc *BroadcastClient) connect(ctx context.Context, nextSeqNum arbutil.Messa
 	if !compressionNegotiated && config.EnableCompression {
 		log.Warn("Compression was not negotiated when connecting to feed server.")
 	}
+	if config.EnableBinary && feedEncoding != message.EncodingRLP {
+		log.Warn("Binary encoding was not negotiated when connecting to feed server, falling back to JSON.")
+	}
 	if compressionNegotiated && !config.EnableCompression {
 		err := conn.Close()
 		if err != nil {
@@ -365,7 +397,7 @@ func (bc *BroadcastClient) connect(ctx context.Context, nextSeqNum arbutil.Messa
 	bc.compression = compressionNegotiated
 	bc.firstReconnectAttempt = true
 	bc.connMutex.Unlock()
-	log.Info("Feed connected", "feedServerVersion", feedServerVersion, "chainId", chainId, "requestedSeqNum", nextSeqNum)
+	log.Info("Feed connected", "feedServerVersion", feedServerVersion, "chainId", chainId, "requestedSeqNum", nextSeqNum, "encoding", feedEncoding)
 
 	return earlyFrameData, nil
 }
@@ -440,7 +472,11 @@ func (bc *BroadcastClient) startBackgroundReader(earlyFrameData io.Reader) {
 
 			if msg != nil {
 				res := message.BroadcastMessage{}
-				err = json.Unmarshal(msg, &res)
+				if op == ws.OpBinary {
+					err = rlp.DecodeBytes(msg, &res)
+				} else {
+					err = json.Unmarshal(msg, &res)
+				}
 				if err != nil {
 					log.Error("error unmarshalling message", "msg", msg, "err", err)
 					continue
@@ -23,6 +23,7 @@ import (
 	"github.com/offchainlabs/nitro/arbos/arbostypes"
 	"github.com/offchainlabs/nitro/arbutil"
 	"github.com/offchainlabs/nitro/broadcaster"
+	"github.com/offchainlabs/nitro/broadcaster/archive"
 	"github.com/offchainlabs/nitro/broadcaster/message"
 	"github.com/offchainlabs/nitro/util/contracts"
 	"github.com/offchainlabs/nitro/util/signature"
@@ -101,6 +102,68 @@ func testReceiveMessages(t *testing.T, clientCompression bool, serverCompression
 
 }
 
+func TestReceiveMessagesWithBinaryEncoding(t *testing.T) {
+	t.Parallel()
+	t.Run("withoutCompression", func(t *testing.T) {
+		testReceiveMessagesWithBinaryEncoding(t, true, false)
+	})
+	t.Run("withCompression", func(t *testing.T) {
+		testReceiveMessagesWithBinaryEncoding(t, true, true)
+	})
+	t.Run("withServerBinaryDisabled", func(t *testing.T) {
+		testReceiveMessagesWithBinaryEncoding(t, false, true)
+	})
+}
+
+func testReceiveMessagesWithBinaryEncoding(t *testing.T, serverBinary bool, compression bool) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	broadcasterConfig := wsbroadcastserver.DefaultTestBroadcasterConfig
+	broadcasterConfig.EnableCompression = compression
+	broadcasterConfig.EnableBinary = serverBinary
+
+	messageCount := 100
+	chainId := uint64(9742)
+
+	privateKey, err := crypto.GenerateKey()
+	Require(t, err)
+	sequencerAddr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	dataSigner := signature.DataSignerFromPrivateKey(privateKey)
+
+	feedErrChan := make(chan error, 10)
+	b := broadcaster.NewBroadcaster(func() *wsbroadcastserver.BroadcasterConfig { return &broadcasterConfig }, chainId, feedErrChan, dataSigner)
+
+	Require(t, b.Initialize())
+	Require(t, b.Start(ctx))
+	defer b.StopAndWait()
+
+	// Messages sent before the clients connect are sent from the backlog
+	for i := 0; i < messageCount/2; i++ {
+		// #nosec G115
+		Require(t, b.BroadcastSingle(arbostypes.TestMessageWithMetadataAndRequestId, arbutil.MessageIndex(i), nil, nil))
+	}
+
+	var wg sync.WaitGroup
+	binaryConfig := DefaultTestConfig
+	binaryConfig.EnableCompression = compression
+	binaryConfig.EnableBinary = true
+	startMakeBroadcastClient(ctx, t, binaryConfig, b.ListenerAddr(), 0, messageCount, chainId, &wg, &sequencerAddr)
+	jsonConfig := DefaultTestConfig
+	jsonConfig.EnableCompression = compression
+	startMakeBroadcastClient(ctx, t, jsonConfig, b.ListenerAddr(), 1, messageCount, chainId, &wg, &sequencerAddr)
+
+	go func() {
+		for i := messageCount / 2; i < messageCount; i++ {
+			// #nosec G115
+			Require(t, b.BroadcastSingle(arbostypes.TestMessageWithMetadataAndRequestId, arbutil.MessageIndex(i), nil, nil))
+		}
+	}()
+
+	wg.Wait()
+}
+
 func TestInvalidSignature(t *testing.T) {
 	t.Parallel()
 	ctx, cancel := context.WithCancel(context.Background())
@@ -784,6 +847,92 @@ func TestBroadcasterSendsCachedMessagesOnClientConnect(t *testing.T) {
 	}
 }
 
+func TestBroadcasterServesHistoryOnClientConnect(t *testing.T) {
+	t.Parallel()
+	t.Run("unlimited", func(t *testing.T) {
+		// Messages 5 to 9 are only in the history
+		testBroadcasterServesHistory(t, -1, 5)
+	})
+	t.Run("withinCatchupLimit", func(t *testing.T) {
+		testBroadcasterServesHistory(t, 5, 5)
+	})
+	t.Run("overCatchupLimit", func(t *testing.T) {
+		// The client only gets the backlog
+		testBroadcasterServesHistory(t, 4, 10)
+	})
+}
+
+func testBroadcasterServesHistory(t *testing.T, maxCatchup int, expectedFirst arbutil.MessageIndex) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	settings := wsbroadcastserver.DefaultTestBroadcasterConfig
+	settings.ServeHistory = true
+	settings.LimitCatchup = maxCatchup >= 0
+	settings.MaxCatchup = maxCatchup
+
+	privateKey, err := crypto.GenerateKey()
+	Require(t, err)
+	sequencerAddr := crypto.PubkeyToAddress(privateKey.PublicKey)
+	dataSigner := signature.DataSignerFromPrivateKey(privateKey)
+
+	feedErrChan := make(chan error, 10)
+	chainId := uint64(8744)
+	b := broadcaster.NewBroadcaster(func() *wsbroadcastserver.BroadcasterConfig { return &settings }, chainId, feedErrChan, dataSigner)
+
+	history, err := archive.OpenDirectory(t.TempDir())
+	Require(t, err)
+	var archived []*message.BroadcastFeedMessage
+	for i := arbutil.MessageIndex(0); i < 10; i++ {
+		msg, err := b.NewBroadcastFeedMessage(arbostypes.EmptyTestMessageWithMetadata, i, nil, nil)
+		Require(t, err)
+		archived = append(archived, msg)
+	}
+	Require(t, history.Append(archived))
+	b.SetHistory(history)
+
+	Require(t, b.Initialize())
+	Require(t, b.Start(ctx))
+	defer b.StopAndWait()
+
+	Require(t, b.BroadcastSingle(arbostypes.EmptyTestMessageWithMetadata, 10, nil, nil))
+	Require(t, b.BroadcastSingle(arbostypes.EmptyTestMessageWithMetadata, 11, nil, nil))
+	for b.GetCachedMessageCount() != 2 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	ts := NewDummyTransactionStreamer(chainId, nil)
+	broadcastClient, err := newTestBroadcastClient(
+		DefaultTestConfig,
+		b.ListenerAddr(),
+		chainId,
+		5,
+		ts,
+		nil,
+		feedErrChan,
+		&sequencerAddr,
+		t,
+	)
+	Require(t, err)
+	broadcastClient.Start(ctx)
+	defer broadcastClient.StopAndWait()
+
+	for expected := expectedFirst; expected <= 11; expected++ {
+		timer := time.NewTimer(10 * time.Second)
+		select {
+		case receivedMsg := <-ts.messageReceiver:
+			if receivedMsg.SequenceNumber != expected {
+				t.Fatalf("expected message %d, got %d", expected, receivedMsg.SequenceNumber)
+			}
+		case err := <-feedErrChan:
+			t.Fatalf("feed error: %v", err)
+		case <-timer.C:
+			t.Fatalf("did not receive message %d", expected)
+		}
+		timer.Stop()
+	}
+}
+
 func connectAndGetCachedMessages(ctx context.Context, addr net.Addr, chainId uint64, t *testing.T, clientIndex int, feedErrChan chan error, sequencerAddr *common.Address, wg *sync.WaitGroup) {
 	ts := NewDummyTransactionStreamer(chainId, nil)
 	broadcastClient, err := newTestBroadcastClient(
@@ -6,6 +6,7 @@ package broadcastclients
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync/atomic"
 	"time"
 
@@ -44,11 +45,15 @@ type BroadcastClients struct {
 	primaryClients   []*broadcastclient.BroadcastClient
 	secondaryClients []*broadcastclient.BroadcastClient
 	secondaryURL     []string
-	makeClient       func(string, *Router, arbutil.MessageIndex) (*broadcastclient.BroadcastClient, error)
+	makeClient       func(string, broadcastclient.TransactionStreamerInterface, *Router, arbutil.MessageIndex) (*broadcastclient.BroadcastClient, error)
 
 	primaryRouter   *Router
 	secondaryRouter *Router
 
+	// quorum is nil unless messages must be agreed on by multiple primary feeds
+	quorum     *quorumTracker
+	quorumChan chan quorumMessage
+
 	// Use atomic access
 	connected         atomic.Int32
 	latestSequenceNum atomic.Uint64
@@ -82,14 +87,18 @@ func NewBroadcastClients(
 		secondaryClients: make([]*broadcastclient.BroadcastClient, 0, len(config.SecondaryURL)),
 		secondaryURL:     config.SecondaryURL,
 	}
+	if config.Quorum > 0 {
+		clients.quorum = newQuorumTracker(config.Quorum, l2ChainId)
+		clients.quorumChan = make(chan quorumMessage, ROUTER_QUEUE_SIZE)
+	}
 	clients.latestSequenceNum.Store(uint64(currentMessageCount))
-	clients.makeClient = func(url string, router *Router, seqNum arbutil.MessageIndex) (*broadcastclient.BroadcastClient, error) {
+	clients.makeClient = func(url string, txStreamer broadcastclient.TransactionStreamerInterface, router *Router, seqNum arbutil.MessageIndex) (*broadcastclient.BroadcastClient, error) {
 		return broadcastclient.NewBroadcastClient(
 			configFetcher,
 			url,
 			l2ChainId,
 			seqNum,
-			router,
+			txStreamer,
 			router.confirmedSequenceNumberChan,
 			fatalErrChan,
 			addrVerifier,
@@ -98,8 +107,12 @@ func NewBroadcastClients(
 	}
 
 	var lastClientErr error
-	for _, address := range config.URL {
-		client, err := clients.makeClient(address, clients.primaryRouter, currentMessageCount)
+	for i, address := range config.URL {
+		var clientTxStreamer broadcastclient.TransactionStreamerInterface = clients.primaryRouter
+		if clients.quorum != nil {
+			clientTxStreamer = &quorumSource{source: i, messageChan: clients.quorumChan}
+		}
+		client, err := clients.makeClient(address, clientTxStreamer, clients.primaryRouter, currentMessageCount)
 		if err != nil {
 			lastClientErr = err
 			log.Warn("init broadcast client failed", "address", address)
@@ -111,6 +124,9 @@ func NewBroadcastClients(
 		log.Error("no connected feed on startup, last error: %w", lastClientErr)
 		return nil, nil
 	}
+	if len(clients.primaryClients) < config.Quorum {
+		return nil, fmt.Errorf("only %d feeds started, fewer than the quorum of %d: %w", len(clients.primaryClients), config.Quorum, lastClientErr)
+	}
 
 	return &clients, nil
 }
@@ -142,6 +158,11 @@ func (bcs *BroadcastClients) Start(ctx context.Context) {
 		client.Start(ctx)
 	}
 
+	if bcs.quorum != nil {
+		bcs.startQuorum()
+		return
+	}
+
 	var lastConfirmed arbutil.MessageIndex
 	recentFeedItemsNew := make(map[arbutil.MessageIndex]time.Time, RECENT_FEED_INITIAL_MAP_SIZE)
 	recentFeedItemsOld := make(map[arbutil.MessageIndex]time.Time, RECENT_FEED_INITIAL_MAP_SIZE)
@@ -256,13 +277,62 @@ func (bcs *BroadcastClients) Start(ctx context.Context) {
 	})
 }
 
+// startQuorum forwards the messages agreed on by the quorum of primary feeds.
+// Secondary feeds aren't used in this mode.
+func (bcs *BroadcastClients) startQuorum() {
+	bcs.primaryRouter.LaunchThread(func(ctx context.Context) {
+		pruneTicker := time.NewTicker(RECENT_FEED_ITEM_TTL)
+		defer pruneTicker.Stop()
+
+		var lastConfirmed, lastForwarded arbutil.MessageIndex
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-pruneTicker.C:
+				bcs.quorum.prune(RECENT_FEED_ITEM_TTL)
+			case qm := <-bcs.quorumChan:
+				msg, err := bcs.quorum.add(qm.source, &qm.msg)
+				if err != nil {
+					log.Error("Error checking sequencer feed message quorum", "source", qm.source, "err", err)
+					continue
+				}
+				if msg == nil {
+					continue
+				}
+				lastForwarded = max(lastForwarded, msg.SequenceNumber)
+				if uint64(msg.SequenceNumber) > bcs.latestSequenceNum.Load() {
+					bcs.latestSequenceNum.Store(uint64(msg.SequenceNumber))
+				}
+				if err := bcs.primaryRouter.forwardTxStreamer.AddBroadcastMessages([]*message.BroadcastFeedMessage{msg}); err != nil {
+					if errors.Is(err, broadcastclient.TransactionStreamerBlockCreationStopped) {
+						log.Info("stopping block creation in broadcast clients because transaction streamer has stopped")
+						return
+					}
+					log.Error("Error routing message from Sequencer Feeds quorum", "err", err)
+				}
+			case cs := <-bcs.primaryRouter.confirmedSequenceNumberChan:
+				// Confirmations aren't agreed on, but they never go past the messages that were
+				cs = min(cs, lastForwarded)
+				if cs == lastConfirmed {
+					continue
+				}
+				lastConfirmed = cs
+				if bcs.primaryRouter.forwardConfirmationChan != nil {
+					bcs.primaryRouter.forwardConfirmationChan <- cs
+				}
+			}
+		}
+	})
+}
+
 func (bcs *BroadcastClients) startSecondaryFeed(ctx context.Context) {
 	pos := len(bcs.secondaryClients)
 	if pos < len(bcs.secondaryURL) {
 		url := bcs.secondaryURL[pos]
 
 		latestSeqNum := arbutil.MessageIndex(bcs.latestSequenceNum.Load())
-		client, err := bcs.makeClient(url, bcs.secondaryRouter, latestSeqNum)
+		client, err := bcs.makeClient(url, bcs.secondaryRouter, bcs.secondaryRouter, latestSeqNum)
 		if err != nil {
 			log.Warn("init broadcast secondary client failed", "address", url)
 			bcs.secondaryURL = append(bcs.secondaryURL[:pos], bcs.secondaryURL[pos+1:]...)
@@ -0,0 +1,136 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package broadcastclients
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+
+	"github.com/offchainlabs/nitro/arbutil"
+	"github.com/offchainlabs/nitro/broadcaster/message"
+)
+
+var (
+	quorumDivergenceCounter = metrics.NewRegisteredCounter("arb/feed/quorum/divergence", nil)
+	quorumTimeoutCounter    = metrics.NewRegisteredCounter("arb/feed/quorum/timeout", nil)
+	quorumPendingGauge      = metrics.NewRegisteredGauge("arb/feed/quorum/pending", nil)
+)
+
+type quorumMessage struct {
+	source int
+	msg    message.BroadcastFeedMessage
+}
+
+// quorumSource tags the messages of a single feed with its index, so the
+// quorum tracker can tell which feeds agree.
+type quorumSource struct {
+	source      int
+	messageChan chan quorumMessage
+}
+
+func (s *quorumSource) AddBroadcastMessages(feedMessages []*message.BroadcastFeedMessage) error {
+	for _, feedMessage := range feedMessages {
+		s.messageChan <- quorumMessage{source: s.source, msg: *feedMessage}
+	}
+	return nil
+}
+
+type quorumKey struct {
+	messageHash common.Hash
+	blockHash   common.Hash
+}
+
+type quorumVotes struct {
+	firstSeen time.Time
+	// bySource holds the latest message delivered by each feed
+	bySource  map[int]quorumKey
+	messages  map[quorumKey]*message.BroadcastFeedMessage
+	forwarded *quorumKey
+	diverged  bool
+}
+
+// quorumTracker only lets a message through once enough feeds delivered the
+// same message and block hash for its sequence number.
+type quorumTracker struct {
+	quorum  int
+	chainId uint64
+	pending map[arbutil.MessageIndex]*quorumVotes
+}
+
+func newQuorumTracker(quorum int, chainId uint64) *quorumTracker {
+	return &quorumTracker{
+		quorum:  quorum,
+		chainId: chainId,
+		pending: make(map[arbutil.MessageIndex]*quorumVotes, RECENT_FEED_INITIAL_MAP_SIZE),
+	}
+}
+
+// add records the message delivered by the feed source, and returns the
+// message to forward if it just reached the quorum. A different message
+// reaching the quorum for an already forwarded sequence number, i.e. a
+// reorg agreed on by the feeds, is forwarded too.
+func (q *quorumTracker) add(source int, msg *message.BroadcastFeedMessage) (*message.BroadcastFeedMessage, error) {
+	hash, err := msg.Hash(q.chainId)
+	if err != nil {
+		return nil, err
+	}
+	key := quorumKey{messageHash: hash}
+	if msg.BlockHash != nil {
+		key.blockHash = *msg.BlockHash
+	}
+	votes, ok := q.pending[msg.SequenceNumber]
+	if !ok {
+		votes = &quorumVotes{
+			firstSeen: time.Now(),
+			bySource:  make(map[int]quorumKey),
+			messages:  make(map[quorumKey]*message.BroadcastFeedMessage),
+		}
+		q.pending[msg.SequenceNumber] = votes
+		// #nosec G115
+		quorumPendingGauge.Update(int64(len(q.pending)))
+	}
+	votes.bySource[source] = key
+	if _, ok := votes.messages[key]; !ok {
+		votes.messages[key] = msg
+	}
+
+	agreeing := 0
+	distinct := make(map[quorumKey]struct{})
+	for _, sourceKey := range votes.bySource {
+		distinct[sourceKey] = struct{}{}
+		if sourceKey == key {
+			agreeing++
+		}
+	}
+	if len(distinct) > 1 && !votes.diverged {
+		votes.diverged = true
+		quorumDivergenceCounter.Inc(1)
+		log.Error("sequencer feeds diverged", "sequenceNumber", msg.SequenceNumber, "source", source, "messageHash", key.messageHash, "blockHash", key.blockHash, "distinctMessages", len(distinct))
+	}
+	if agreeing < q.quorum || (votes.forwarded != nil && *votes.forwarded == key) {
+		return nil, nil
+	}
+	votes.forwarded = &key
+	return votes.messages[key], nil
+}
+
+// prune removes the sequence numbers first seen more than ttl ago, warning
+// about the ones that never reached the quorum.
+func (q *quorumTracker) prune(ttl time.Duration) {
+	for seqNum, votes := range q.pending {
+		if time.Since(votes.firstSeen) <= ttl {
+			continue
+		}
+		if votes.forwarded == nil {
+			quorumTimeoutCounter.Inc(1)
+			log.Warn("sequencer feed message didn't reach quorum", "sequenceNumber", seqNum, "feeds", len(votes.bySource), "quorum", q.quorum)
+		}
+		delete(q.pending, seqNum)
+	}
+	// #nosec G115
+	quorumPendingGauge.Update(int64(len(q.pending)))
+}
@@ -0,0 +1,77 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package broadcastclients
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/offchainlabs/nitro/arbos/arbostypes"
+	"github.com/offchainlabs/nitro/arbutil"
+	"github.com/offchainlabs/nitro/broadcaster/message"
+)
+
+func quorumTestMessage(seqNum arbutil.MessageIndex, l2msg byte, blockHash common.Hash) *message.BroadcastFeedMessage {
+	return &message.BroadcastFeedMessage{
+		SequenceNumber: seqNum,
+		Message: arbostypes.MessageWithMetadata{
+			Message: &arbostypes.L1IncomingMessage{
+				Header: &arbostypes.L1IncomingMessageHeader{
+					Kind:      arbostypes.L1MessageType_L2Message,
+					L1BaseFee: big.NewInt(0),
+				},
+				L2msg: []byte{l2msg},
+			},
+		},
+		BlockHash: &blockHash,
+	}
+}
+
+func TestQuorumTracker(t *testing.T) {
+	t.Parallel()
+	tracker := newQuorumTracker(2, 1234)
+	add := func(source int, msg *message.BroadcastFeedMessage, expectForwarded bool) {
+		t.Helper()
+		forwarded, err := tracker.add(source, msg)
+		Require(t, err)
+		if expectForwarded && forwarded == nil {
+			t.Fatalf("expected message %d from source %d to be forwarded", msg.SequenceNumber, source)
+		} else if !expectForwarded && forwarded != nil {
+			t.Fatalf("expected message %d from source %d not to be forwarded", msg.SequenceNumber, source)
+		}
+	}
+
+	good := quorumTestMessage(1, 1, common.Hash{1})
+	add(0, good, false)
+	// A repeated message from the same feed doesn't count towards the quorum
+	add(0, good, false)
+	add(1, quorumTestMessage(1, 1, common.Hash{1}), true)
+	add(2, good, false)
+
+	// A compromised feed can't get a different message or block hash through
+	add(0, quorumTestMessage(2, 1, common.Hash{1}), false)
+	add(1, quorumTestMessage(2, 2, common.Hash{1}), false)
+	add(2, quorumTestMessage(2, 1, common.Hash{2}), false)
+	if !tracker.pending[2].diverged {
+		t.Fatal("expected divergence to be detected")
+	}
+	add(2, quorumTestMessage(2, 1, common.Hash{1}), true)
+
+	// A reorg agreed on by the feeds is forwarded
+	add(0, quorumTestMessage(1, 3, common.Hash{3}), false)
+	add(1, quorumTestMessage(1, 3, common.Hash{3}), true)
+
+	add(0, quorumTestMessage(3, 1, common.Hash{1}), false)
+	tracker.prune(time.Hour)
+	if len(tracker.pending) != 3 {
+		t.Fatalf("expected 3 pending sequence numbers, got %d", len(tracker.pending))
+	}
+	tracker.prune(0)
+	if len(tracker.pending) != 0 {
+		t.Fatalf("expected no pending sequence numbers, got %d", len(tracker.pending))
+	}
+}
@@ -0,0 +1,405 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package archive
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/offchainlabs/nitro/arbutil"
+	m "github.com/offchainlabs/nitro/broadcaster/message"
+)
+
+// Source provides feed messages older than the ones kept in the broadcaster's
+// backlog, to catch up clients that were offline for longer than the backlog.
+type Source interface {
+	GetMessageCount() (arbutil.MessageIndex, error)
+	// GetFeedMessages returns the feed messages from start to end, inclusive.
+	GetFeedMessages(start, end arbutil.MessageIndex) ([]*m.BroadcastFeedMessage, error)
+}
+
+// Iterator reads consecutive feed messages from a Source.
+type Iterator interface {
+	// Next returns the feed messages following the ones returned so far, up to
+	// last, inclusive.
+	Next(last arbutil.MessageIndex) ([]*m.BroadcastFeedMessage, error)
+}
+
+// NewIterator returns an Iterator over the feed messages of src from start
+// onwards. Archive directories are iterated file by file, so that each file
+// is only read once, e.g. when catching up a client.
+func NewIterator(src Source, start arbutil.MessageIndex) Iterator {
+	if d, ok := src.(*Directory); ok {
+		return d.newIterator(start)
+	}
+	return &sourceIterator{src: src, next: start}
+}
+
+var ErrNotArchived = errors.New("feed message not archived")
+
+type Config struct {
+	Directory    string `koanf:"directory"`
+	FileMessages uint64 `koanf:"file-messages"`
+}
+
+var DefaultConfig = Config{
+	Directory:    "",
+	FileMessages: 1024,
+}
+
+func ConfigAddOptions(prefix string, f *pflag.FlagSet) {
+	f.String(prefix+".directory", DefaultConfig.Directory, "directory of the feed message archive (empty to disable archiving)")
+	f.Uint64(prefix+".file-messages", DefaultConfig.FileMessages, "number of feed messages stored in each archive file")
+}
+
+func (c *Config) Validate() error {
+	if c.Directory != "" && c.FileMessages == 0 {
+		return errors.New("archive file-messages must be greater than 0")
+	}
+	return nil
+}
+
+const fileSuffix = ".rlp.gz"
+
+type archiveFile struct {
+	first arbutil.MessageIndex
+	last  arbutil.MessageIndex
+}
+
+func (f archiveFile) name() string {
+	// Zero padded so that the files are sorted by sequence number
+	return fmt.Sprintf("feed-%020d-%020d%s", f.first, f.last, fileSuffix)
+}
+
+func parseFileName(name string) (archiveFile, bool) {
+	parts := strings.Split(strings.TrimSuffix(name, fileSuffix), "-")
+	if len(parts) != 3 || parts[0] != "feed" {
+		return archiveFile{}, false
+	}
+	first, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return archiveFile{}, false
+	}
+	last, err := strconv.ParseUint(parts[2], 10, 64)
+	if err != nil {
+		return archiveFile{}, false
+	}
+	file := archiveFile{first: arbutil.MessageIndex(first), last: arbutil.MessageIndex(last)}
+	return file, file.name() == name
+}
+
+// Directory is an archive of feed messages stored in a directory. Each file
+// holds a gzipped RLP encoded broadcast message with a range of consecutive
+// feed messages. There may be gaps between files, e.g. if the archiving relay
+// was disconnected, in which case the missing messages can't be served.
+type Directory struct {
+	dir   string
+	mutex sync.RWMutex
+	files []archiveFile
+}
+
+// OpenDirectory opens the feed message archive in dir, creating the directory if needed.
+func OpenDirectory(dir string) (*Directory, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	d := &Directory{dir: dir}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		file, ok := parseFileName(entry.Name())
+		if !ok {
+			log.Debug("ignoring unknown file in feed archive", "dir", dir, "file", entry.Name())
+			continue
+		}
+		if file.last < file.first {
+			return nil, fmt.Errorf("invalid feed archive file %s", entry.Name())
+		}
+		d.files = append(d.files, file)
+	}
+	sort.Slice(d.files, func(i, j int) bool { return d.files[i].first < d.files[j].first })
+	for i := 1; i < len(d.files); i++ {
+		if d.files[i].first <= d.files[i-1].last {
+			return nil, fmt.Errorf("overlapping feed archive files %s and %s", d.files[i-1].name(), d.files[i].name())
+		}
+	}
+	return d, nil
+}
+
+// FirstMessage returns the sequence number of the first archived message, if any.
+func (d *Directory) FirstMessage() (arbutil.MessageIndex, bool) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	if len(d.files) == 0 {
+		return 0, false
+	}
+	return d.files[0].first, true
+}
+
+// GetMessageCount returns the sequence number following the last archived message.
+func (d *Directory) GetMessageCount() (arbutil.MessageIndex, error) {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	if len(d.files) == 0 {
+		return 0, nil
+	}
+	return d.files[len(d.files)-1].last + 1, nil
+}
+
+func (d *Directory) GetFeedMessages(start, end arbutil.MessageIndex) ([]*m.BroadcastFeedMessage, error) {
+	return d.newIterator(start).Next(end)
+}
+
+func (d *Directory) newIterator(start arbutil.MessageIndex) *directoryIterator {
+	return &directoryIterator{dir: d, next: start}
+}
+
+// directoryIterator keeps the messages of the last file it read, so that
+// reading consecutive ranges of messages decodes each file once.
+type directoryIterator struct {
+	dir  *Directory
+	next arbutil.MessageIndex
+	file archiveFile
+	msgs []*m.BroadcastFeedMessage
+}
+
+func (it *directoryIterator) Next(last arbutil.MessageIndex) ([]*m.BroadcastFeedMessage, error) {
+	if last < it.next {
+		return nil, fmt.Errorf("invalid feed message range %d to %d", it.next, last)
+	}
+	msgs := make([]*m.BroadcastFeedMessage, 0, last-it.next+1)
+	for it.next <= last {
+		if it.msgs == nil || it.next < it.file.first || it.next > it.file.last {
+			if err := it.readFile(); err != nil {
+				return nil, err
+			}
+		}
+		for _, msg := range it.msgs[it.next-it.file.first:] {
+			if msg.SequenceNumber > last {
+				break
+			}
+			msgs = append(msgs, msg)
+		}
+		it.next = min(last, it.file.last) + 1
+	}
+	return msgs, nil
+}
+
+// readFile reads the file holding the next message.
+func (it *directoryIterator) readFile() error {
+	it.dir.mutex.RLock()
+	defer it.dir.mutex.RUnlock()
+	files := it.dir.files
+	i := sort.Search(len(files), func(i int) bool { return files[i].last >= it.next })
+	if i >= len(files) || files[i].first > it.next {
+		return fmt.Errorf("%w: %d", ErrNotArchived, it.next)
+	}
+	msgs, err := it.dir.readFile(files[i])
+	if err != nil {
+		return err
+	}
+	it.file = files[i]
+	it.msgs = msgs
+	return nil
+}
+
+// sourceIterator reads each range of messages from its source.
+type sourceIterator struct {
+	src  Source
+	next arbutil.MessageIndex
+}
+
+func (it *sourceIterator) Next(last arbutil.MessageIndex) ([]*m.BroadcastFeedMessage, error) {
+	msgs, err := it.src.GetFeedMessages(it.next, last)
+	if err != nil {
+		return nil, err
+	}
+	it.next = last + 1
+	return msgs, nil
+}
+
+func (d *Directory) readFile(file archiveFile) ([]*m.BroadcastFeedMessage, error) {
+	f, err := os.Open(filepath.Join(d.dir, file.name()))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	reader, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	var bm m.BroadcastMessage
+	if err := rlp.Decode(reader, &bm); err != nil {
+		return nil, fmt.Errorf("error decoding feed archive file %s: %w", file.name(), err)
+	}
+	if err := checkConsecutive(bm.Messages); err != nil {
+		return nil, fmt.Errorf("invalid feed archive file %s: %w", file.name(), err)
+	}
+	if len(bm.Messages) == 0 || bm.Messages[0].SequenceNumber != file.first || bm.Messages[len(bm.Messages)-1].SequenceNumber != file.last {
+		return nil, fmt.Errorf("feed archive file %s doesn't match its messages", file.name())
+	}
+	return bm.Messages, nil
+}
+
+// Append archives consecutive feed messages in a new file. If messages from
+// the same sequence number onwards were already archived, e.g. because of a
+// reorg, they're replaced.
+func (d *Directory) Append(msgs []*m.BroadcastFeedMessage) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	if err := checkConsecutive(msgs); err != nil {
+		return err
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if err := d.truncate(msgs[0].SequenceNumber); err != nil {
+		return err
+	}
+	file := archiveFile{first: msgs[0].SequenceNumber, last: msgs[len(msgs)-1].SequenceNumber}
+	if err := d.writeFile(file, msgs); err != nil {
+		return err
+	}
+	d.files = append(d.files, file)
+	return nil
+}
+
+// Insert archives consecutive feed messages in a new file, e.g. to fill a gap
+// in the archive. Unlike Append, none of the messages may already be archived.
+func (d *Directory) Insert(msgs []*m.BroadcastFeedMessage) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	if err := checkConsecutive(msgs); err != nil {
+		return err
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	file := archiveFile{first: msgs[0].SequenceNumber, last: msgs[len(msgs)-1].SequenceNumber}
+	i := sort.Search(len(d.files), func(i int) bool { return d.files[i].first > file.last })
+	if i > 0 && d.files[i-1].last >= file.first {
+		return fmt.Errorf("feed messages %d to %d overlap archive file %s", file.first, file.last, d.files[i-1].name())
+	}
+	if err := d.writeFile(file, msgs); err != nil {
+		return err
+	}
+	d.files = slices.Insert(d.files, i, file)
+	return nil
+}
+
+// truncate removes the archived messages from the given sequence number onwards.
+func (d *Directory) truncate(from arbutil.MessageIndex) error {
+	for len(d.files) > 0 {
+		last := d.files[len(d.files)-1]
+		if last.last < from {
+			return nil
+		}
+		if last.first < from {
+			// Keep the part of the file before the truncated messages
+			msgs, err := d.readFile(last)
+			if err != nil {
+				return err
+			}
+			kept := archiveFile{first: last.first, last: from - 1}
+			if err := d.writeFile(kept, msgs[:from-last.first]); err != nil {
+				return err
+			}
+			d.files[len(d.files)-1] = kept
+		} else {
+			d.files = d.files[:len(d.files)-1]
+		}
+		log.Info("truncating feed archive", "file", last.name(), "from", from)
+		if err := os.Remove(filepath.Join(d.dir, last.name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Directory) writeFile(file archiveFile, msgs []*m.BroadcastFeedMessage) error {
+	f, err := os.CreateTemp(d.dir, file.name()+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Nothing to remove if the file was renamed
+		_ = os.Remove(f.Name())
+	}()
+	writer := gzip.NewWriter(f)
+	err = rlp.Encode(writer, &m.BroadcastMessage{Version: m.V1, Messages: msgs})
+	if err == nil {
+		err = writer.Close()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error writing feed archive file %s: %w", file.name(), err)
+	}
+	return os.Rename(f.Name(), filepath.Join(d.dir, file.name()))
+}
+
+func checkConsecutive(msgs []*m.BroadcastFeedMessage) error {
+	for i, msg := range msgs {
+		if msg == nil {
+			return errors.New("nil feed message")
+		}
+		if i > 0 && msg.SequenceNumber != msgs[i-1].SequenceNumber+1 {
+			return fmt.Errorf("feed message %d doesn't follow %d", msg.SequenceNumber, msgs[i-1].SequenceNumber)
+		}
+	}
+	return nil
+}
+
+// Export archives the feed messages from start to end, inclusive, read from
+// src into files of fileMessages messages. None of them may already be archived.
+func Export(ctx context.Context, src Source, dst *Directory, start, end arbutil.MessageIndex, fileMessages uint64) error {
+	if fileMessages == 0 {
+		return errors.New("file messages must be greater than 0")
+	}
+	for first := start; first <= end; {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		last := end
+		if uint64(end-first) >= fileMessages {
+			last = first + arbutil.MessageIndex(fileMessages) - 1
+		}
+		msgs, err := src.GetFeedMessages(first, last)
+		if err != nil {
+			return fmt.Errorf("error reading feed messages %d to %d: %w", first, last, err)
+		}
+		if err := dst.Insert(msgs); err != nil {
+			return fmt.Errorf("error archiving feed messages %d to %d: %w", first, last, err)
+		}
+		log.Info("archived feed messages", "first", first, "last", last)
+		if last == end {
+			break
+		}
+		first = last + 1
+	}
+	return nil
+}
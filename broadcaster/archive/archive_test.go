@@ -0,0 +1,155 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package archive
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/offchainlabs/nitro/arbos/arbostypes"
+	"github.com/offchainlabs/nitro/arbutil"
+	m "github.com/offchainlabs/nitro/broadcaster/message"
+)
+
+func testMessages(first, last arbutil.MessageIndex, l2msg byte) []*m.BroadcastFeedMessage {
+	var msgs []*m.BroadcastFeedMessage
+	for i := first; i <= last; i++ {
+		msgs = append(msgs, &m.BroadcastFeedMessage{
+			SequenceNumber: i,
+			Message: arbostypes.MessageWithMetadata{
+				Message: &arbostypes.L1IncomingMessage{
+					Header: &arbostypes.L1IncomingMessageHeader{
+						Kind:      arbostypes.L1MessageType_L2Message,
+						L1BaseFee: big.NewInt(0),
+					},
+					L2msg: []byte{l2msg},
+				},
+				DelayedMessagesRead: uint64(i),
+			},
+		})
+	}
+	return msgs
+}
+
+func checkMessages(t *testing.T, src Source, first, last arbutil.MessageIndex, l2msg byte) {
+	t.Helper()
+	msgs, err := src.GetFeedMessages(first, last)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != int(last-first+1) {
+		t.Fatalf("expected %d messages, got %d", last-first+1, len(msgs))
+	}
+	for i, msg := range msgs {
+		if msg.SequenceNumber != first+arbutil.MessageIndex(i) {
+			t.Fatalf("expected message %d, got %d", first+arbutil.MessageIndex(i), msg.SequenceNumber)
+		}
+		if msg.Message.Message.L2msg[0] != l2msg || msg.Message.DelayedMessagesRead != uint64(msg.SequenceNumber) {
+			t.Fatalf("unexpected contents for message %d", msg.SequenceNumber)
+		}
+	}
+}
+
+func TestDirectory(t *testing.T) {
+	dir := t.TempDir()
+	d, err := OpenDirectory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, first := range []arbutil.MessageIndex{10, 15, 20} {
+		if err := d.Append(testMessages(first, first+4, 1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkMessages(t, d, 12, 22, 1)
+
+	// A reorg replaces the messages from its first message onwards
+	if err := d.Append(testMessages(17, 18, 2)); err != nil {
+		t.Fatal(err)
+	}
+	checkMessages(t, d, 10, 16, 1)
+	checkMessages(t, d, 17, 18, 2)
+	if count, err := d.GetMessageCount(); err != nil || count != 19 {
+		t.Fatalf("expected message count 19, got %d (err %v)", count, err)
+	}
+
+	if err := d.Insert(testMessages(8, 10, 3)); err == nil {
+		t.Fatal("expected error inserting already archived messages")
+	}
+	if err := d.Insert(testMessages(0, 4, 3)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.GetFeedMessages(3, 12); !errors.Is(err, ErrNotArchived) {
+		t.Fatalf("expected ErrNotArchived for a gap in the archive, got %v", err)
+	}
+
+	reopened, err := OpenDirectory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first, ok := reopened.FirstMessage(); !ok || first != 0 {
+		t.Fatalf("expected first message 0, got %d", first)
+	}
+	checkMessages(t, reopened, 0, 4, 3)
+	checkMessages(t, reopened, 10, 16, 1)
+	checkMessages(t, reopened, 17, 18, 2)
+
+	exported, err := OpenDirectory(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Export(context.Background(), reopened, exported, 11, 18, 3); err != nil {
+		t.Fatal(err)
+	}
+	checkMessages(t, exported, 11, 16, 1)
+	checkMessages(t, exported, 17, 18, 2)
+	if len(exported.files) != 3 {
+		t.Fatalf("expected 3 exported files, got %d", len(exported.files))
+	}
+}
+
+func TestDirectoryIterator(t *testing.T) {
+	dir := t.TempDir()
+	d, err := OpenDirectory(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, first := range []arbutil.MessageIndex{10, 15} {
+		if err := d.Append(testMessages(first, first+4, 1)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	it := NewIterator(d, 11)
+	msgs, err := it.Next(12)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(msgs) != 2 || msgs[0].SequenceNumber != 11 || msgs[1].SequenceNumber != 12 {
+		t.Fatalf("expected messages 11 to 12, got %d messages", len(msgs))
+	}
+
+	// The first file was read once, so the iterator doesn't need it anymore
+	if err := os.Remove(filepath.Join(dir, d.files[0].name())); err != nil {
+		t.Fatal(err)
+	}
+	msgs, err = it.Next(16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, msg := range msgs {
+		if msg.SequenceNumber != 13+arbutil.MessageIndex(i) {
+			t.Fatalf("expected message %d, got %d", 13+arbutil.MessageIndex(i), msg.SequenceNumber)
+		}
+	}
+	if len(msgs) != 4 {
+		t.Fatalf("expected 4 messages, got %d", len(msgs))
+	}
+	if _, err := it.Next(20); !errors.Is(err, ErrNotArchived) {
+		t.Fatalf("expected ErrNotArchived past the end of the archive, got %v", err)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package archive
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/offchainlabs/nitro/arbnode/db-schema"
+	"github.com/offchainlabs/nitro/arbos/arbostypes"
+	"github.com/offchainlabs/nitro/arbutil"
+	m "github.com/offchainlabs/nitro/broadcaster/message"
+)
+
+// DatabaseSource reads feed messages from a node's arbitrumdata database,
+// e.g. to export them to an archive. The messages aren't signed.
+type DatabaseSource struct {
+	db ethdb.KeyValueReader
+}
+
+func NewDatabaseSource(db ethdb.KeyValueReader) *DatabaseSource {
+	return &DatabaseSource{db: db}
+}
+
+func dbKey(prefix []byte, pos uint64) []byte {
+	key := make([]byte, len(prefix)+8)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], pos)
+	return key
+}
+
+func (s *DatabaseSource) GetMessageCount() (arbutil.MessageIndex, error) {
+	countBytes, err := s.db.Get(dbschema.MessageCountKey)
+	if err != nil {
+		return 0, err
+	}
+	var count uint64
+	if err := rlp.DecodeBytes(countBytes, &count); err != nil {
+		return 0, err
+	}
+	return arbutil.MessageIndex(count), nil
+}
+
+func (s *DatabaseSource) GetFeedMessages(start, end arbutil.MessageIndex) ([]*m.BroadcastFeedMessage, error) {
+	msgs := make([]*m.BroadcastFeedMessage, 0, end-start+1)
+	for msgIdx := start; msgIdx <= end; msgIdx++ {
+		msg, err := s.getFeedMessage(msgIdx)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+func (s *DatabaseSource) getFeedMessage(msgIdx arbutil.MessageIndex) (*m.BroadcastFeedMessage, error) {
+	data, err := s.db.Get(dbKey(dbschema.MessagePrefix, uint64(msgIdx)))
+	if err != nil {
+		return nil, err
+	}
+	var message arbostypes.MessageWithMetadata
+	if err := rlp.DecodeBytes(data, &message); err != nil {
+		return nil, err
+	}
+	feedMessage := &m.BroadcastFeedMessage{
+		SequenceNumber: msgIdx,
+		Message:        message,
+	}
+	// Block hashes and metadata are only stored for some messages
+	data, err = s.db.Get(dbKey(dbschema.BlockHashInputFeedPrefix, uint64(msgIdx)))
+	if err == nil {
+		var blockHash struct {
+			BlockHash *common.Hash `rlp:"nil"`
+		}
+		if err := rlp.DecodeBytes(data, &blockHash); err != nil {
+			return nil, err
+		}
+		feedMessage.BlockHash = blockHash.BlockHash
+	} else if !rawdb.IsDbErrNotFound(err) {
+		return nil, err
+	}
+	blockMetadata, err := s.db.Get(dbKey(dbschema.BlockMetadataInputFeedPrefix, uint64(msgIdx)))
+	if err == nil {
+		feedMessage.BlockMetadata = blockMetadata
+	} else if !rawdb.IsDbErrNotFound(err) {
+		return nil, err
+	}
+	return feedMessage, nil
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/offchainlabs/nitro/arbos/arbostypes"
 	"github.com/offchainlabs/nitro/arbutil"
+	"github.com/offchainlabs/nitro/broadcaster/archive"
 	"github.com/offchainlabs/nitro/broadcaster/backlog"
 	m "github.com/offchainlabs/nitro/broadcaster/message"
 	"github.com/offchainlabs/nitro/util/signature"
@@ -151,6 +152,12 @@ func (b *Broadcaster) GetCachedMessageCount() int {
 	return int(b.backlog.Count())
 }
 
+// SetHistory sets the source of the messages older than the backlog. It must
+// be called before Start.
+func (b *Broadcaster) SetHistory(history archive.Source) {
+	b.server.SetHistory(history)
+}
+
 func (b *Broadcaster) Initialize() error {
 	return b.server.Initialize()
 }
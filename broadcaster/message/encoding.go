@@ -0,0 +1,89 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package message
+
+import (
+	"errors"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/offchainlabs/nitro/arbos/arbostypes"
+	"github.com/offchainlabs/nitro/arbutil"
+)
+
+// Feed encodings negotiated at websocket upgrade. JSON messages are sent as
+// text frames and RLP messages as binary frames.
+const (
+	EncodingJSON = "json"
+	EncodingRLP  = "rlp"
+)
+
+// rlpBroadcastMessage is the RLP representation of BroadcastMessage.
+type rlpBroadcastMessage struct {
+	Version                        uint64
+	Messages                       []*rlpBroadcastFeedMessage
+	ConfirmedSequenceNumberMessage *ConfirmedSequenceNumberMessage `rlp:"nil"`
+}
+
+type rlpBroadcastFeedMessage struct {
+	SequenceNumber arbutil.MessageIndex
+	Message        arbostypes.MessageWithMetadata
+	BlockHash      *common.Hash `rlp:"nil"`
+	Signature      []byte
+	BlockMetadata  []byte
+}
+
+// EncodeRLP is used to encode BroadcastMessage for the binary feed encoding.
+func (m *BroadcastMessage) EncodeRLP(w io.Writer) error {
+	if m.Version < 0 {
+		return errors.New("negative broadcast message version")
+	}
+	enc := rlpBroadcastMessage{
+		Version:                        uint64(m.Version),
+		Messages:                       make([]*rlpBroadcastFeedMessage, 0, len(m.Messages)),
+		ConfirmedSequenceNumberMessage: m.ConfirmedSequenceNumberMessage,
+	}
+	for _, msg := range m.Messages {
+		if msg == nil {
+			return errors.New("nil broadcast feed message")
+		}
+		enc.Messages = append(enc.Messages, &rlpBroadcastFeedMessage{
+			SequenceNumber: msg.SequenceNumber,
+			Message:        msg.Message,
+			BlockHash:      msg.BlockHash,
+			Signature:      msg.Signature,
+			BlockMetadata:  msg.BlockMetadata,
+		})
+	}
+	return rlp.Encode(w, &enc)
+}
+
+// DecodeRLP is used to decode BroadcastMessage from the binary feed encoding.
+func (m *BroadcastMessage) DecodeRLP(s *rlp.Stream) error {
+	var dec rlpBroadcastMessage
+	if err := s.Decode(&dec); err != nil {
+		return err
+	}
+	m.Version = int(dec.Version) // #nosec G115
+	m.ConfirmedSequenceNumberMessage = dec.ConfirmedSequenceNumberMessage
+	m.Messages = nil
+	for _, msg := range dec.Messages {
+		feedMsg := &BroadcastFeedMessage{
+			SequenceNumber: msg.SequenceNumber,
+			Message:        msg.Message,
+			BlockHash:      msg.BlockHash,
+		}
+		// Match JSON decoding, which leaves empty fields nil.
+		if len(msg.Signature) > 0 {
+			feedMsg.Signature = msg.Signature
+		}
+		if len(msg.BlockMetadata) > 0 {
+			feedMsg.BlockMetadata = msg.BlockMetadata
+		}
+		m.Messages = append(m.Messages, feedMsg)
+	}
+	return nil
+}
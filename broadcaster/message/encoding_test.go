@@ -0,0 +1,100 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package message
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/offchainlabs/nitro/arbos/arbostypes"
+)
+
+func TestBroadcastMessageRLPEncoding(t *testing.T) {
+	t.Parallel()
+	requestId := common.Hash{0: 0x01}
+	batchGasCost := uint64(1000)
+	for _, tc := range []struct {
+		name string
+		msg  *BroadcastMessage
+	}{
+		{
+			name: "feed messages",
+			msg: &BroadcastMessage{
+				Version: V1,
+				Messages: []*BroadcastFeedMessage{
+					{
+						SequenceNumber: 12345,
+						Message: arbostypes.MessageWithMetadata{
+							Message: &arbostypes.L1IncomingMessage{
+								Header: &arbostypes.L1IncomingMessageHeader{
+									Kind:        arbostypes.L1MessageType_BatchPostingReport,
+									BlockNumber: 5,
+									Timestamp:   6,
+									RequestId:   &requestId,
+									L1BaseFee:   big.NewInt(7),
+								},
+								L2msg:              []byte{0xde, 0xad, 0xbe, 0xef},
+								LegacyBatchGasCost: &batchGasCost,
+							},
+							DelayedMessagesRead: 3333,
+						},
+						BlockHash:     &common.Hash{0: 0xff},
+						Signature:     []byte{0x01, 0x02},
+						BlockMetadata: []byte{0, 2},
+					},
+					{
+						SequenceNumber: 12346,
+						Message: arbostypes.MessageWithMetadata{
+							Message: &arbostypes.L1IncomingMessage{
+								Header: &arbostypes.L1IncomingMessageHeader{
+									Kind:      arbostypes.L1MessageType_L2Message,
+									L1BaseFee: big.NewInt(0),
+								},
+								L2msg: []byte{0x04},
+							},
+						},
+					},
+				},
+				ConfirmedSequenceNumberMessage: &ConfirmedSequenceNumberMessage{SequenceNumber: 0},
+			},
+		},
+		{
+			name: "confirmed sequence number only",
+			msg: &BroadcastMessage{
+				Version:                        V1,
+				ConfirmedSequenceNumberMessage: &ConfirmedSequenceNumberMessage{SequenceNumber: 42},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded, err := rlp.EncodeToBytes(tc.msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var decoded BroadcastMessage
+			if err := rlp.DecodeBytes(encoded, &decoded); err != nil {
+				t.Fatal(err)
+			}
+			want, err := json.Marshal(tc.msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			have, err := json.Marshal(&decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(want, have) {
+				t.Fatalf("RLP round trip changed the message, got %s, want %s", have, want)
+			}
+			if len(encoded) >= len(want) {
+				t.Errorf("RLP encoding of %d bytes isn't smaller than JSON encoding of %d bytes", len(encoded), len(want))
+			}
+		})
+	}
+}
@@ -130,6 +130,19 @@ func mainImpl() int {
 			log.Error("Error creating new auctioneer", "error", err)
 			return 1
 		}
+		if nodeConfig.AuctioneerServer.BidHistoryAPI.Enable {
+			stack, err := node.New(&stackConf)
+			if err != nil {
+				pflag.Usage()
+				log.Crit("failed to initialize geth stack", "err", err)
+			}
+			auctioneer.RegisterBidHistoryAPI(stack)
+			err = stack.Start()
+			if err != nil {
+				fatalErrChan <- fmt.Errorf("error starting stack: %w", err)
+			}
+			defer stack.Close()
+		}
 		auctioneer.Start(ctx)
 	} else if nodeConfig.BidValidator.Enable {
 		log.Info("Running Arbitrum express lane bid validator", "revision", vcsRevision, "vcs.time", vcsTime)
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"math/big"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/pflag"
 
@@ -48,6 +50,10 @@ func mainImpl() error {
 		return err
 	}
 
+	if bidderClientConfig.Strategy.Enable {
+		return runBiddingEngine(ctx, bidderClient, &bidderClientConfig.Strategy)
+	}
+
 	if bidderClientConfig.DepositGwei > 0 && bidderClientConfig.BidGwei > 0 {
 		return errors.New("--deposit-gwei and --bid-gwei can't both be set, either make a deposit or a bid")
 	}
@@ -69,7 +75,33 @@ func mainImpl() error {
 		return err
 	}
 
-	return errors.New("select one of --deposit-gwei or --bid-gwei")
+	return errors.New("select one of --deposit-gwei, --bid-gwei or --strategy.enable")
+}
+
+func runBiddingEngine(ctx context.Context, bidderClient *timeboost.BidderClient, config *timeboost.BidStrategyConfig) error {
+	strategy, err := timeboost.NewBidStrategy(config)
+	if err != nil {
+		return err
+	}
+	engine, err := timeboost.NewBiddingEngine(bidderClient, config, strategy)
+	if err != nil {
+		return err
+	}
+	bidderClient.Start(ctx)
+	engine.Start(ctx)
+	log.Info("Bidding engine started", "strategy", config.Name, "bidder", bidderClient.Address())
+
+	sigint := make(chan os.Signal, 1)
+	signal.Notify(sigint, os.Interrupt, syscall.SIGTERM)
+	select {
+	case <-sigint:
+		log.Info("shutting down because of sigint")
+	case <-engine.GetContext().Done():
+		log.Info("bidding engine finished")
+	}
+	engine.StopAndWait()
+	bidderClient.StopAndWait()
+	return nil
 }
 
 func parseBidderClientArgs(ctx context.Context, args []string) (*timeboost.BidderClientConfig, error) {
@@ -0,0 +1,135 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+// challenge-cache manages the BoLD machine hashes cache of a validator
+// (--node.bold.state-provider-config.machine-leaves-cache-path) while the
+// validator is stopped: it verifies the integrity of the cached hashes, and
+// exports them to import into the cache of a standby validator, so that it
+// doesn't have to recompute them if it takes over during a challenge.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/cmd/util/confighelpers"
+	"github.com/offchainlabs/nitro/staker/challenge-cache"
+)
+
+type CacheConfig struct {
+	Dir     string `koanf:"dir"`
+	MaxSize uint64 `koanf:"max-size"`
+	Repair  bool   `koanf:"repair"`
+	File    string `koanf:"file"`
+}
+
+func printSampleUsage(name string) {
+	fmt.Printf("Sample usage: %s [verify|export|import] --dir ~/.arbitrum/arb1/nitro/machine-hashes-cache [--file cache.tar.gz] \n", name)
+}
+
+func main() {
+	if err := mainImpl(os.Args); err != nil {
+		log.Error("Error running challenge-cache", "err", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func mainImpl(args []string) error {
+	if len(args) < 2 {
+		printSampleUsage(args[0])
+		return errors.New("missing command")
+	}
+	command := strings.ToLower(args[1])
+	config, err := parseCacheArgs(command, args[2:])
+	if err != nil {
+		confighelpers.PrintErrorAndExit(err, printSampleUsage)
+		return err
+	}
+	ctx := context.Background()
+	cache, err := challengecache.New(config.Dir, challengecache.WithMaxSize(config.MaxSize))
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "verify":
+		result, err := cache.Verify(ctx, config.Repair)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d verified, %d without checksum, %d corrupted\n", result.Verified, result.Unchecked, len(result.Corrupted))
+		for _, path := range result.Corrupted {
+			fmt.Println(path)
+		}
+		if len(result.Corrupted) > 0 && !config.Repair {
+			return errors.New("corrupted files found, rerun with --repair to remove them")
+		}
+		return nil
+	case "export":
+		if config.File == "-" {
+			return cache.Export(ctx, os.Stdout)
+		}
+		f, err := os.Create(config.File)
+		if err != nil {
+			return err
+		}
+		if err := cache.Export(ctx, f); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	case "import":
+		var r io.Reader = os.Stdin
+		if config.File != "-" {
+			f, err := os.Open(config.File)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		numImported, err := cache.Import(ctx, r)
+		fmt.Printf("%d files imported\n", numImported)
+		return err
+	}
+	return nil
+}
+
+func parseCacheArgs(command string, args []string) (*CacheConfig, error) {
+	f := pflag.NewFlagSet("challenge-cache "+command, pflag.ContinueOnError)
+	f.String("dir", "", "path to the machine hashes cache")
+	f.Uint64("max-size", 0, "max size in bytes of the cache, evicting the least recently used hashes beyond it (0 = unlimited)")
+	switch command {
+	case "verify":
+		f.Bool("repair", false, "remove the corrupted files, to be recomputed when needed")
+	case "export":
+		f.String("file", "-", "file to write the archive to (- for stdout)")
+	case "import":
+		f.String("file", "-", "archive to import (- for stdin)")
+	default:
+		return nil, fmt.Errorf("unknown command '%s', valid commands are 'verify', 'export' and 'import'", command)
+	}
+
+	k, err := confighelpers.BeginCommonParse(f, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var config CacheConfig
+	if err := confighelpers.EndCommonParse(k, &config); err != nil {
+		return nil, err
+	}
+	if config.Dir == "" {
+		return nil, errors.New("--dir must be set")
+	}
+	return &config, nil
+}
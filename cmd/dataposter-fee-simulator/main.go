@@ -0,0 +1,125 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+// dataposter-fee-simulator replays recorded parent chain headers through the
+// data poster fee strategy, to tune its configuration offline.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/offchainlabs/nitro/arbnode/dataposter"
+	"github.com/offchainlabs/nitro/cmd/util/confighelpers"
+	"github.com/offchainlabs/nitro/util/arbmath"
+)
+
+type FeeSimulatorConfig struct {
+	HeadersFile  string                      `koanf:"headers-file"`
+	PostInterval time.Duration               `koanf:"post-interval"`
+	GasLimit     uint64                      `koanf:"gas-limit"`
+	NumBlobs     uint64                      `koanf:"num-blobs"`
+	BalanceEth   float64                     `koanf:"balance-eth"`
+	DataPoster   dataposter.DataPosterConfig `koanf:"data-poster"`
+}
+
+var DefaultFeeSimulatorConfig = FeeSimulatorConfig{
+	PostInterval: time.Minute,
+	GasLimit:     1_000_000,
+	NumBlobs:     0,
+	BalanceEth:   10,
+	DataPoster:   dataposter.DefaultDataPosterConfig,
+}
+
+func FeeSimulatorConfigAddOptions(f *pflag.FlagSet) {
+	f.String("headers-file", DefaultFeeSimulatorConfig.HeadersFile, "JSON file with the array of recorded parent chain headers to replay, each with number, timestamp, baseFeePerGas, and optionally blobBaseFee and priorityFee")
+	f.Duration("post-interval", DefaultFeeSimulatorConfig.PostInterval, "time between two simulated batches being posted")
+	f.Uint64("gas-limit", DefaultFeeSimulatorConfig.GasLimit, "gas limit of the simulated batch transactions")
+	f.Uint64("num-blobs", DefaultFeeSimulatorConfig.NumBlobs, "number of blobs of the simulated batch transactions (0 to post calldata)")
+	f.Float64("balance-eth", DefaultFeeSimulatorConfig.BalanceEth, "starting balance of the simulated batch poster in ETH")
+	dataposter.DataPosterConfigAddOptions("data-poster", f, DefaultFeeSimulatorConfig.DataPoster)
+}
+
+func printSampleUsage(name string) {
+	fmt.Printf("Sample usage: %s --headers-file headers.json --data-poster.target-price-gwei 30 \n", name)
+}
+
+func main() {
+	if err := mainImpl(); err != nil {
+		log.Error("Error running dataposter-fee-simulator", "err", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func mainImpl() error {
+	config, err := parseFeeSimulatorArgs(os.Args[1:])
+	if err != nil {
+		confighelpers.PrintErrorAndExit(err, printSampleUsage)
+		return err
+	}
+	if config.HeadersFile == "" {
+		return errors.New("--headers-file must be set")
+	}
+	data, err := os.ReadFile(config.HeadersFile)
+	if err != nil {
+		return err
+	}
+	var headers []*dataposter.SimulatedHeader
+	if err := json.Unmarshal(data, &headers); err != nil {
+		return fmt.Errorf("error parsing headers file: %w", err)
+	}
+	strategy, err := dataposter.NewFormulaFeeStrategy(func() *dataposter.DataPosterConfig { return &config.DataPoster })
+	if err != nil {
+		return err
+	}
+	result, err := dataposter.SimulateFeeStrategy(strategy, &config.DataPoster, &dataposter.FeeSimulationConfig{
+		PostInterval: config.PostInterval,
+		GasLimit:     config.GasLimit,
+		NumBlobs:     config.NumBlobs,
+		Balance:      arbmath.FloatToBig(config.BalanceEth * params.Ether),
+	}, headers)
+	if err != nil {
+		return err
+	}
+	output, err := json.MarshalIndent(struct {
+		*dataposter.FeeSimulationResult
+		AverageLatency time.Duration `json:"averageLatency"`
+	}{result, result.AverageLatency()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+func parseFeeSimulatorArgs(args []string) (*FeeSimulatorConfig, error) {
+	f := pflag.NewFlagSet("", pflag.ContinueOnError)
+
+	FeeSimulatorConfigAddOptions(f)
+
+	k, err := confighelpers.BeginCommonParse(f, args)
+	if err != nil {
+		return nil, err
+	}
+
+	err = confighelpers.ApplyOverrides(f, k)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg FeeSimulatorConfig
+	if err := confighelpers.EndCommonParse(k, &cfg); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
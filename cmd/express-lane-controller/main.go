@@ -0,0 +1,195 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/cmd/util/confighelpers"
+	"github.com/offchainlabs/nitro/timeboost"
+)
+
+const usage = "Usage: express-lane-controller [transfer|set-transferor|presign|send-presigned|verify] ..."
+
+func printSampleUsage(name string) {
+	fmt.Printf("Sample usage: %s transfer --help \n", name)
+}
+
+func main() {
+	if err := mainImpl(); err != nil {
+		log.Error("Error running express-lane-controller", "err", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func mainImpl() error {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	if len(os.Args) < 2 {
+		return errors.New(usage)
+	}
+	command := strings.ToLower(os.Args[1])
+	config, err := parseControllerManagerArgs(os.Args[2:])
+	if err != nil {
+		confighelpers.PrintErrorAndExit(err, printSampleUsage)
+		return err
+	}
+	manager, err := timeboost.NewControllerManager(ctx, func() *timeboost.ControllerManagerConfig { return config })
+	if err != nil {
+		return err
+	}
+
+	switch command {
+	case "transfer":
+		return transfer(ctx, manager, config)
+	case "set-transferor":
+		return setTransferor(ctx, manager, config)
+	case "presign":
+		return presign(ctx, manager, config)
+	case "send-presigned":
+		return sendPresigned(ctx, manager, config)
+	case "verify":
+		newController, err := parseAddress("new-controller", config.NewController)
+		if err != nil {
+			return err
+		}
+		if err := manager.VerifyController(ctx, config.Round, newController); err != nil {
+			return err
+		}
+		log.Info("Sequencer observed the express lane controller", "round", config.Round, "controller", newController)
+		return nil
+	default:
+		return fmt.Errorf("unknown command '%s', %s", os.Args[1], usage)
+	}
+}
+
+func parseAddress(name string, address string) (common.Address, error) {
+	if !common.IsHexAddress(address) {
+		return common.Address{}, fmt.Errorf("--%s must be a valid address, got %q", name, address)
+	}
+	return common.HexToAddress(address), nil
+}
+
+func transfer(ctx context.Context, manager *timeboost.ControllerManager, config *timeboost.ControllerManagerConfig) error {
+	newController, err := parseAddress("new-controller", config.NewController)
+	if err != nil {
+		return err
+	}
+	round := config.Round
+	if round == 0 {
+		round = manager.RoundTimingInfo().RoundNumber()
+	}
+	receipt, err := manager.TransferControl(ctx, round, newController)
+	if err != nil {
+		return err
+	}
+	log.Info("Transferred express lane control", "round", round, "newController", newController, "tx", receipt.TxHash)
+	return manager.VerifyController(ctx, round, newController)
+}
+
+func setTransferor(ctx context.Context, manager *timeboost.ControllerManager, config *timeboost.ControllerManagerConfig) error {
+	transferor, err := parseAddress("transferor", config.Transferor)
+	if err != nil {
+		return err
+	}
+	receipt, err := manager.SetTransferor(ctx, transferor, config.FixedUntilRound)
+	if err != nil {
+		return err
+	}
+	log.Info("Set express lane transferor", "transferor", transferor, "fixedUntilRound", config.FixedUntilRound, "tx", receipt.TxHash)
+	return nil
+}
+
+func presign(ctx context.Context, manager *timeboost.ControllerManager, config *timeboost.ControllerManagerConfig) error {
+	if config.PresignedFile == "" {
+		return errors.New("--presigned-file must be set")
+	}
+	transfers, err := timeboost.ParseControllerTransfers(config.Transfers)
+	if err != nil {
+		return err
+	}
+	if len(transfers) == 0 {
+		return errors.New("--transfers must contain at least one transfer")
+	}
+	var startNonce *uint64
+	if config.StartNonce >= 0 {
+		nonce := uint64(config.StartNonce)
+		startNonce = &nonce
+	}
+	presigned, err := manager.PresignTransfers(ctx, transfers, startNonce)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(presigned, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(config.PresignedFile, data, 0600); err != nil {
+		return err
+	}
+	log.Info("Pre-signed express lane transfers", "count", len(presigned), "file", config.PresignedFile)
+	return nil
+}
+
+func sendPresigned(ctx context.Context, manager *timeboost.ControllerManager, config *timeboost.ControllerManagerConfig) error {
+	if config.PresignedFile == "" {
+		return errors.New("--presigned-file must be set")
+	}
+	data, err := os.ReadFile(config.PresignedFile)
+	if err != nil {
+		return err
+	}
+	var presigned []timeboost.PresignedTransfer
+	if err := json.Unmarshal(data, &presigned); err != nil {
+		return err
+	}
+	if err := manager.SendPresignedTransfers(ctx, presigned); err != nil {
+		return err
+	}
+	for _, transfer := range presigned {
+		// Only the current and next rounds are tracked by the sequencer
+		if transfer.Round < manager.RoundTimingInfo().RoundNumber() {
+			continue
+		}
+		if err := manager.VerifyController(ctx, transfer.Round, transfer.NewController); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseControllerManagerArgs(args []string) (*timeboost.ControllerManagerConfig, error) {
+	f := pflag.NewFlagSet("", pflag.ContinueOnError)
+
+	timeboost.ControllerManagerConfigAddOptions(f)
+
+	k, err := confighelpers.BeginCommonParse(f, args)
+	if err != nil {
+		return nil, err
+	}
+
+	err = confighelpers.ApplyOverrides(f, k)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg timeboost.ControllerManagerConfig
+	if err := confighelpers.EndCommonParse(k, &cfg); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
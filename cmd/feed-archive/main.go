@@ -0,0 +1,171 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+// feed-archive exports the messages of a node's database to a feed archive,
+// and imports archives into another one, to be served by a relay to feed
+// clients that are behind its backlog.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+
+	"github.com/offchainlabs/nitro/arbutil"
+	"github.com/offchainlabs/nitro/broadcaster/archive"
+	"github.com/offchainlabs/nitro/cmd/util/confighelpers"
+)
+
+type ExportConfig struct {
+	Data     string `koanf:"data"`
+	DBEngine string `koanf:"db-engine"`
+	Start    uint64 `koanf:"start"`
+	End      uint64 `koanf:"end"`
+}
+
+type ImportConfig struct {
+	Source string `koanf:"source"`
+}
+
+type FeedArchiveConfig struct {
+	Archive archive.Config `koanf:"archive"`
+	Export  ExportConfig   `koanf:"export"`
+	Import  ImportConfig   `koanf:"import"`
+}
+
+var DefaultFeedArchiveConfig = FeedArchiveConfig{
+	Archive: archive.DefaultConfig,
+	Export: ExportConfig{
+		DBEngine: "pebble",
+	},
+}
+
+func FeedArchiveConfigAddOptions(f *pflag.FlagSet) {
+	archive.ConfigAddOptions("archive", f)
+	f.String("export.data", DefaultFeedArchiveConfig.Export.Data, "arbitrumdata database directory of the node to export the messages of")
+	f.String("export.db-engine", DefaultFeedArchiveConfig.Export.DBEngine, "backing database implementation of the node ('leveldb' or 'pebble')")
+	f.Uint64("export.start", DefaultFeedArchiveConfig.Export.Start, "sequence number of the first message to export")
+	f.Uint64("export.end", DefaultFeedArchiveConfig.Export.End, "sequence number of the last message to export (0 to export up to the node's last message)")
+	f.String("import.source", DefaultFeedArchiveConfig.Import.Source, "directory of the feed archive to import the messages of")
+}
+
+func (c *FeedArchiveConfig) Validate() error {
+	if c.Archive.Directory == "" {
+		return errors.New("--archive.directory must be set")
+	}
+	if (c.Export.Data == "") == (c.Import.Source == "") {
+		return errors.New("exactly one of --export.data and --import.source must be set")
+	}
+	if c.Export.End != 0 && c.Export.End < c.Export.Start {
+		return fmt.Errorf("--export.end %d is before --export.start %d", c.Export.End, c.Export.Start)
+	}
+	return c.Archive.Validate()
+}
+
+func printSampleUsage(name string) {
+	fmt.Printf("Sample usage: %s --archive.directory /data/feed-archive --export.data /data/nitro/arbitrumdata \n", name)
+}
+
+func main() {
+	if err := mainImpl(); err != nil {
+		log.Error("Error running feed-archive", "err", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func mainImpl() error {
+	config, err := parseFeedArchiveArgs(os.Args[1:])
+	if err != nil {
+		confighelpers.PrintErrorAndExit(err, printSampleUsage)
+		return err
+	}
+	ctx := context.Background()
+	dst, err := archive.OpenDirectory(config.Archive.Directory)
+	if err != nil {
+		return err
+	}
+	if config.Export.Data != "" {
+		return exportDatabase(ctx, &config.Export, dst, config.Archive.FileMessages)
+	}
+	return importArchive(ctx, &config.Import, dst, config.Archive.FileMessages)
+}
+
+func exportDatabase(ctx context.Context, config *ExportConfig, dst *archive.Directory, fileMessages uint64) error {
+	db, err := node.OpenDatabase(node.InternalOpenOptions{
+		DbEngine:  config.DBEngine,
+		Directory: config.Data,
+		DatabaseOptions: node.DatabaseOptions{
+			MetricsNamespace: "arbitrumdata/",
+			ReadOnly:         true,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	src := archive.NewDatabaseSource(db)
+	end := arbutil.MessageIndex(config.End)
+	if end == 0 {
+		count, err := src.GetMessageCount()
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return errors.New("no messages in the database")
+		}
+		end = count - 1
+	}
+	return archive.Export(ctx, src, dst, arbutil.MessageIndex(config.Start), end, fileMessages)
+}
+
+// importArchive imports the messages of the source archive following the ones
+// already in the destination archive.
+func importArchive(ctx context.Context, config *ImportConfig, dst *archive.Directory, fileMessages uint64) error {
+	src, err := archive.OpenDirectory(config.Source)
+	if err != nil {
+		return err
+	}
+	start, ok := src.FirstMessage()
+	if !ok {
+		return errors.New("no messages in the source archive")
+	}
+	count, err := src.GetMessageCount()
+	if err != nil {
+		return err
+	}
+	dstCount, err := dst.GetMessageCount()
+	if err != nil {
+		return err
+	}
+	start = max(start, dstCount)
+	if start >= count {
+		log.Info("all messages of the source archive are already archived", "count", count)
+		return nil
+	}
+	return archive.Export(ctx, src, dst, start, count-1, fileMessages)
+}
+
+func parseFeedArchiveArgs(args []string) (*FeedArchiveConfig, error) {
+	f := pflag.NewFlagSet("", pflag.ContinueOnError)
+
+	FeedArchiveConfigAddOptions(f)
+
+	k, err := confighelpers.BeginCommonParse(f, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg FeedArchiveConfig
+	if err := confighelpers.EndCommonParse(k, &cfg); err != nil {
+		return nil, err
+	}
+
+	return &cfg, cfg.Validate()
+}
@@ -0,0 +1,202 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+// mel-snapshot exports the message extraction (MEL) state of a node at a parent
+// chain block, along with its unread delayed messages, into a snapshot file,
+// and imports it into the database of a fresh node, so that it can start
+// message extraction from that block instead of from the rollup's deployment.
+// Snapshots are verified against the parent chain both ways. The hash printed
+// on export is to be published along with the snapshot, and passed on import
+// with --state-hash, as the parent chain doesn't commit to the message count
+// and accumulator of the state.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/node"
+
+	"github.com/offchainlabs/nitro/arbnode"
+	"github.com/offchainlabs/nitro/arbnode/mel/runner"
+	"github.com/offchainlabs/nitro/cmd/chaininfo"
+	"github.com/offchainlabs/nitro/cmd/conf"
+	"github.com/offchainlabs/nitro/cmd/dbconv/dbconv"
+	"github.com/offchainlabs/nitro/cmd/util/confighelpers"
+)
+
+type SnapshotConfig struct {
+	DB               dbconv.DBConfig `koanf:"db"`
+	Chain            conf.L2Config   `koanf:"chain"`
+	ParentChainURL   string          `koanf:"parent-chain-url"`
+	ParentChainBlock uint64          `koanf:"parent-chain-block"`
+	File             string          `koanf:"file"`
+	StateHash        string          `koanf:"state-hash"`
+}
+
+var DBConfigDefault = dbconv.DBConfig{
+	DBEngine:  conf.PersistentConfigDefault.DBEngine,
+	Handles:   conf.PersistentConfigDefault.Handles,
+	Cache:     2048, // 2048 MB
+	Namespace: "arbitrumdata/",
+	Pebble:    conf.PebbleConfigDefault,
+}
+
+func printSampleUsage(name string) {
+	fmt.Printf("Sample usage: %s export --db.data ~/.arbitrum/arb1/nitro/arbitrumdata --chain.name arb1 --parent-chain-url <url> --parent-chain-block <number> [--file snapshot.rlp] \n", name)
+	fmt.Printf("              %s import --db.data ~/.arbitrum/arb1/nitro/arbitrumdata --chain.name arb1 --parent-chain-url <url> --state-hash <hash> [--file snapshot.rlp] \n", name)
+}
+
+func main() {
+	if err := mainImpl(os.Args); err != nil {
+		log.Error("Error running mel-snapshot", "err", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func mainImpl(args []string) error {
+	if len(args) < 2 {
+		printSampleUsage(args[0])
+		return errors.New("missing command")
+	}
+	command := strings.ToLower(args[1])
+	config, err := parseSnapshotArgs(command, args[2:])
+	if err != nil {
+		confighelpers.PrintErrorAndExit(err, printSampleUsage)
+		return err
+	}
+	ctx := context.Background()
+	rollupAddrs, err := chaininfo.GetRollupAddressesConfig(config.Chain.ID, config.Chain.Name, config.Chain.InfoFiles, config.Chain.InfoJson)
+	if err != nil {
+		return err
+	}
+	client, err := ethclient.DialContext(ctx, config.ParentChainURL)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	sequencerInbox, err := arbnode.NewSequencerInbox(client, rollupAddrs.SequencerInbox, 0)
+	if err != nil {
+		return err
+	}
+	delayedBridge, err := arbnode.NewDelayedBridge(client, rollupAddrs.Bridge, 0)
+	if err != nil {
+		return err
+	}
+	verifier := &melrunner.SnapshotVerifier{
+		ParentChainReader: client,
+		RollupAddrs:       &rollupAddrs,
+		SequencerInbox:    sequencerInbox,
+		DelayedBridge:     delayedBridge,
+		StateHash:         common.HexToHash(config.StateHash),
+	}
+	db, err := openDB(&config.DB, command == "export")
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	melDB := melrunner.NewDatabase(db)
+
+	switch command {
+	case "export":
+		var w io.Writer = os.Stdout
+		var f *os.File
+		if config.File != "-" {
+			f, err = os.Create(config.File)
+			if err != nil {
+				return err
+			}
+			w = f
+		}
+		stateHash, err := melDB.ExportSnapshot(ctx, config.ParentChainBlock, w, verifier)
+		if f != nil {
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "exported mel state at parent chain block %d with hash %v\n", config.ParentChainBlock, stateHash)
+		return nil
+	case "import":
+		var r io.Reader = os.Stdin
+		if config.File != "-" {
+			f, err := os.Open(config.File)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+		state, err := melDB.ImportSnapshot(ctx, r, verifier)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("imported mel state at parent chain block %d (%v), start message extraction from it\n", state.ParentChainBlockNumber, state.ParentChainBlockHash)
+		return nil
+	}
+	return nil
+}
+
+func openDB(config *dbconv.DBConfig, readonly bool) (ethdb.Database, error) {
+	return node.OpenDatabase(node.InternalOpenOptions{
+		DbEngine:  config.DBEngine,
+		Directory: config.Data,
+		DatabaseOptions: node.DatabaseOptions{
+			MetricsNamespace:   config.Namespace,
+			Cache:              config.Cache,
+			Handles:            config.Handles,
+			ReadOnly:           readonly,
+			PebbleExtraOptions: config.Pebble.ExtraOptions("arbitrumdata"),
+		},
+	})
+}
+
+func parseSnapshotArgs(command string, args []string) (*SnapshotConfig, error) {
+	f := pflag.NewFlagSet("mel-snapshot "+command, pflag.ContinueOnError)
+	dbconv.DBConfigAddOptions("db", f, &DBConfigDefault)
+	conf.L2ConfigAddOptions("chain", f)
+	f.String("parent-chain-url", "", "URL of the parent chain node to verify the snapshot against")
+	switch command {
+	case "export":
+		f.Uint64("parent-chain-block", 0, "parent chain block of the mel state to export")
+		f.String("file", "-", "file to write the snapshot to (- for stdout)")
+	case "import":
+		f.String("file", "-", "snapshot to import (- for stdin)")
+		f.String("state-hash", "", "hash of the snapshot's mel state, as printed when exporting it")
+	default:
+		return nil, fmt.Errorf("unknown command '%s', valid commands are 'export' and 'import'", command)
+	}
+
+	k, err := confighelpers.BeginCommonParse(f, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var config SnapshotConfig
+	if err := confighelpers.EndCommonParse(k, &config); err != nil {
+		return nil, err
+	}
+	if config.DB.Data == "" {
+		return nil, errors.New("--db.data must be set")
+	}
+	if config.ParentChainURL == "" {
+		return nil, errors.New("--parent-chain-url must be set")
+	}
+	if command == "import" && config.StateHash == "" {
+		return nil, errors.New("--state-hash must be set")
+	}
+	return &config, nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/cmd/util"
+	"github.com/offchainlabs/nitro/cmd/util/confighelpers"
+	"github.com/offchainlabs/nitro/retryable_redeemer"
+)
+
+func printSampleUsage(name string) {
+	fmt.Printf("Sample usage: %s --wallet.private-key <key> --arbitrum-node-endpoint <url> \n", name)
+}
+
+func main() {
+	if err := mainImpl(); err != nil {
+		log.Error("Error running retryable-redeemer", "err", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func mainImpl() error {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	config, err := parseRetryableRedeemerArgs(os.Args[1:])
+	if err != nil {
+		confighelpers.PrintErrorAndExit(err, printSampleUsage)
+		return err
+	}
+
+	client, err := ethclient.DialContext(ctx, config.ArbitrumNodeEndpoint)
+	if err != nil {
+		return err
+	}
+	chainId, err := client.ChainID(ctx)
+	if err != nil {
+		return err
+	}
+	txOpts, _, err := util.OpenWallet("retryable-redeemer", &config.Wallet, chainId)
+	if err != nil {
+		return fmt.Errorf("opening wallet: %w", err)
+	}
+
+	redeemer, err := retryableredeemer.NewRetryableRedeemer(client, txOpts, config)
+	if err != nil {
+		return err
+	}
+	if err := redeemer.Start(ctx); err != nil {
+		return err
+	}
+	log.Info("Retryable redeemer started", "redeemer", txOpts.From)
+
+	sigint := make(chan os.Signal, 1)
+	signal.Notify(sigint, os.Interrupt, syscall.SIGTERM)
+	<-sigint
+	log.Info("shutting down because of sigint")
+	redeemer.StopAndWait()
+	return nil
+}
+
+func parseRetryableRedeemerArgs(args []string) (*retryableredeemer.Config, error) {
+	f := pflag.NewFlagSet("", pflag.ContinueOnError)
+
+	retryableredeemer.ConfigAddOptions(f)
+
+	k, err := confighelpers.BeginCommonParse(f, args)
+	if err != nil {
+		return nil, err
+	}
+
+	err = confighelpers.ApplyOverrides(f, k)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg retryableredeemer.Config
+	if err := confighelpers.EndCommonParse(k, &cfg); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,443 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+// validation-replay validates recorded block inputs, as written by
+// inputs.Writer, with both the JIT and the arbitrator, and compares the
+// resulting global states. On a mismatch the arbitrator machine is bisected to
+// the first step that differs from a reference. By default the reference is a
+// second local run of the arbitrator, which tells a nondeterministic
+// arbitrator apart from a JIT diverging from it. With --reference.* it's the
+// arbitrator of another module root or validation server instead.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/cmd/util/confighelpers"
+	"github.com/offchainlabs/nitro/util/rpcclient"
+	"github.com/offchainlabs/nitro/validator"
+	"github.com/offchainlabs/nitro/validator/client"
+	"github.com/offchainlabs/nitro/validator/server_api"
+	"github.com/offchainlabs/nitro/validator/server_arb"
+	"github.com/offchainlabs/nitro/validator/server_common"
+	"github.com/offchainlabs/nitro/validator/server_jit"
+)
+
+type ReferenceConfig struct {
+	ModuleRoot       string                 `koanf:"module-root"`
+	ValidationServer rpcclient.ClientConfig `koanf:"validation-server"`
+}
+
+type ReplayConfig struct {
+	Inputs         []string                           `koanf:"inputs"`
+	WasmRootPath   string                             `koanf:"wasm-root-path"`
+	ModuleRoot     string                             `koanf:"module-root"`
+	UseJit         bool                               `koanf:"use-jit"`
+	Jit            server_jit.JitSpawnerConfig        `koanf:"jit"`
+	Arbitrator     server_arb.ArbitratorSpawnerConfig `koanf:"arbitrator"`
+	Reference      ReferenceConfig                    `koanf:"reference"`
+	BisectHashes   uint64                             `koanf:"bisect-hashes"`
+	StopOnMismatch bool                               `koanf:"stop-on-mismatch"`
+}
+
+var DefaultReplayConfig = ReplayConfig{
+	UseJit:     true,
+	Jit:        server_jit.DefaultJitSpawnerConfig,
+	Arbitrator: server_arb.DefaultArbitratorSpawnerConfig,
+	Reference: ReferenceConfig{
+		ValidationServer: rpcclient.ClientConfig{
+			URL:                       "",
+			Retries:                   3,
+			RetryErrors:               "websocket: close.*|dial tcp .*|.*i/o timeout|.*connection reset by peer|.*connection refused",
+			ArgLogLimit:               2048,
+			WebsocketMessageSizeLimit: 256 * 1024 * 1024,
+		},
+	},
+	BisectHashes: 64,
+}
+
+func ReplayConfigAddOptions(f *pflag.FlagSet) {
+	f.StringSlice("inputs", DefaultReplayConfig.Inputs, "block input JSON files to validate, or directories to validate all the block_inputs*.json files of")
+	f.String("wasm-root-path", DefaultReplayConfig.WasmRootPath, "path to machine folders, each containing wasm files (machine.wavm.br, replay.wasm)")
+	f.String("module-root", DefaultReplayConfig.ModuleRoot, "WASM module root to validate with (empty for the latest one)")
+	f.Bool("use-jit", DefaultReplayConfig.UseJit, "also validate with the JIT, and compare its global state with the arbitrator's")
+	server_jit.JitSpawnerConfigAddOptions("jit", f)
+	server_arb.ArbitratorSpawnerConfigAddOptions("arbitrator", f)
+	f.String("reference.module-root", DefaultReplayConfig.Reference.ModuleRoot, "WASM module root of the local arbitrator machine to bisect against on mismatch")
+	rpcclient.RPCClientAddOptions("reference.validation-server", f, &DefaultReplayConfig.Reference.ValidationServer)
+	f.Uint64("bisect-hashes", DefaultReplayConfig.BisectHashes, "number of machine hashes compared in each round of the bisection")
+	f.Bool("stop-on-mismatch", DefaultReplayConfig.StopOnMismatch, "stop at the first input with mismatching global states")
+}
+
+func (c *ReplayConfig) Validate() error {
+	if len(c.Inputs) == 0 {
+		return errors.New("--inputs must be set")
+	}
+	for _, root := range []string{c.ModuleRoot, c.Reference.ModuleRoot} {
+		if root != "" && !common.IsHexHash(root) {
+			return fmt.Errorf("invalid module root %s", root)
+		}
+	}
+	if c.Reference.ModuleRoot != "" && c.Reference.ValidationServer.URL != "" {
+		return errors.New("at most one of --reference.module-root and --reference.validation-server.url can be set")
+	}
+	if !c.UseJit && c.Reference.ModuleRoot == "" && c.Reference.ValidationServer.URL == "" {
+		return errors.New("nothing to compare the arbitrator with: enable --use-jit or set a reference")
+	}
+	if c.BisectHashes < 2 {
+		return errors.New("--bisect-hashes must be at least 2")
+	}
+	return nil
+}
+
+func printSampleUsage(name string) {
+	fmt.Printf("Sample usage: %s --inputs ~/.arbitrum/validation-inputs \n", name)
+	fmt.Printf("              %s --inputs ~/.arbitrum/validation-inputs --reference.module-root 0x... \n", name)
+}
+
+func main() {
+	if err := mainImpl(); err != nil {
+		log.Error("Error running validation-replay", "err", err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func mainImpl() error {
+	config, err := parseReplayArgs(os.Args[1:])
+	if err != nil {
+		confighelpers.PrintErrorAndExit(err, printSampleUsage)
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	files, err := inputFiles(config.Inputs)
+	if err != nil {
+		return err
+	}
+	locator, err := server_common.NewMachineLocator(config.WasmRootPath)
+	if err != nil {
+		return err
+	}
+	moduleRoot := locator.LatestWasmModuleRoot()
+	if config.ModuleRoot != "" {
+		moduleRoot = common.HexToHash(config.ModuleRoot)
+	}
+	arbSpawner, err := server_arb.NewArbitratorSpawner(locator, func() *server_arb.ArbitratorSpawnerConfig { return &config.Arbitrator })
+	if err != nil {
+		return err
+	}
+	if err := arbSpawner.Start(ctx); err != nil {
+		return err
+	}
+	defer arbSpawner.Stop()
+	var jitSpawner *server_jit.JitSpawner
+	fatalErrChan := make(chan error, 10)
+	if config.UseJit {
+		jitSpawner, err = server_jit.NewJitSpawner(locator, func() *server_jit.JitSpawnerConfig { return &config.Jit }, fatalErrChan)
+		if err != nil {
+			return err
+		}
+		if err := jitSpawner.Start(ctx); err != nil {
+			return err
+		}
+		defer jitSpawner.Stop()
+	}
+
+	// The reference is either another module root run by the local
+	// arbitrator, the arbitrator of another validation server, or by default
+	// the same machine run again by the local arbitrator
+	var refSpawner validator.ExecutionSpawner = arbSpawner
+	refModuleRoot := moduleRoot
+	localReference := false
+	if config.Reference.ModuleRoot != "" {
+		refModuleRoot = common.HexToHash(config.Reference.ModuleRoot)
+	} else if config.Reference.ValidationServer.URL != "" {
+		execClient := client.NewExecutionClient(func() *rpcclient.ClientConfig { return &config.Reference.ValidationServer }, nil)
+		if err := execClient.Start(ctx); err != nil {
+			return fmt.Errorf("error connecting to the reference validation server: %w", err)
+		}
+		defer execClient.Stop()
+		refSpawner = execClient
+	} else {
+		localReference = true
+	}
+
+	replayer := &replayer{
+		config:         config,
+		arbSpawner:     arbSpawner,
+		jitSpawner:     jitSpawner,
+		refSpawner:     refSpawner,
+		moduleRoot:     moduleRoot,
+		refModuleRoot:  refModuleRoot,
+		localReference: localReference,
+	}
+	mismatches := 0
+	for _, file := range files {
+		select {
+		case err := <-fatalErrChan:
+			return err
+		default:
+		}
+		matched, err := replayer.replayFile(ctx, file)
+		if err != nil {
+			return fmt.Errorf("error replaying %s: %w", file, err)
+		}
+		if !matched {
+			mismatches++
+			if config.StopOnMismatch {
+				break
+			}
+		}
+	}
+	fmt.Printf("Replayed %d block inputs with module root %v: %d mismatches\n", len(files), moduleRoot, mismatches)
+	if mismatches > 0 {
+		return fmt.Errorf("%d block inputs had mismatching global states", mismatches)
+	}
+	return nil
+}
+
+// inputFiles expands the directories of the inputs to the block input files
+// they contain, sorted by name.
+func inputFiles(inputs []string) ([]string, error) {
+	var files []string
+	for _, input := range inputs {
+		info, err := os.Stat(input)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, input)
+			continue
+		}
+		err = filepath.WalkDir(input, func(path string, entry os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			name := entry.Name()
+			if !entry.IsDir() && strings.HasPrefix(name, "block_inputs") && strings.HasSuffix(name, ".json") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+type replayer struct {
+	config        *ReplayConfig
+	arbSpawner    *server_arb.ArbitratorSpawner
+	jitSpawner    *server_jit.JitSpawner
+	refSpawner    validator.ExecutionSpawner
+	moduleRoot    common.Hash
+	refModuleRoot common.Hash
+	// localReference is set if the reference is the arbitrator itself, in
+	// which case it's only run to bisect JIT mismatches.
+	localReference bool
+}
+
+// replayFile validates the block input of the file, and returns whether all
+// the resulting global states matched.
+func (r *replayer) replayFile(ctx context.Context, file string) (bool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	var inputJson server_api.InputJSON
+	if err := json.Unmarshal(data, &inputJson); err != nil {
+		return false, err
+	}
+	input, err := server_api.ValidationInputFromJson(&inputJson)
+	if err != nil {
+		return false, err
+	}
+	arbState, err := r.arbSpawner.Launch(input, r.moduleRoot).Await(ctx)
+	if err != nil {
+		return false, fmt.Errorf("arbitrator validation failed: %w", err)
+	}
+	matched := true
+	if r.jitSpawner != nil {
+		jitState, err := r.jitSpawner.Launch(input, r.moduleRoot).Await(ctx)
+		if err != nil {
+			return false, fmt.Errorf("JIT validation failed: %w", err)
+		}
+		if jitState != arbState {
+			matched = false
+			fmt.Printf("%s: JIT global state %v doesn't match arbitrator global state %v\n", file, jitState, arbState)
+		}
+	}
+	if !r.localReference {
+		refState, err := r.refSpawner.Launch(input, r.refModuleRoot).Await(ctx)
+		if err != nil {
+			return false, fmt.Errorf("reference validation failed: %w", err)
+		}
+		if refState != arbState {
+			matched = false
+			fmt.Printf("%s: reference global state %v doesn't match arbitrator global state %v\n", file, refState, arbState)
+		}
+	}
+	if matched {
+		fmt.Printf("%s: input %d validated to %v\n", file, input.Id, arbState)
+		return true, nil
+	}
+	return false, r.bisectFile(ctx, file, input)
+}
+
+func (r *replayer) bisectFile(ctx context.Context, file string, input *validator.ValidationInput) error {
+	run, err := r.arbSpawner.CreateExecutionRun(r.moduleRoot, input, false).Await(ctx)
+	if err != nil {
+		return err
+	}
+	defer run.Close()
+	refRun, err := r.refSpawner.CreateExecutionRun(r.refModuleRoot, input, false).Await(ctx)
+	if err != nil {
+		return err
+	}
+	defer refRun.Close()
+	step, err := bisect(ctx, run, refRun, r.config.BisectHashes)
+	if err != nil {
+		return err
+	}
+	if step == nil && r.localReference {
+		fmt.Printf("%s: the arbitrator is deterministic, so the JIT diverges from it\n", file)
+		return nil
+	}
+	if step == nil {
+		fmt.Printf("%s: the arbitrator machine hashes match the reference's at every step\n", file)
+		return nil
+	}
+	if r.localReference {
+		fmt.Printf("%s: the arbitrator isn't deterministic, two runs of its machine differ\n", file)
+	}
+	if step.position == 0 {
+		fmt.Printf("%s: the initial machine hashes differ\n", file)
+	} else {
+		fmt.Printf("%s: machine hashes first differ at step %d, after hash %v at step %d\n", file, step.position, step.lastEqual, step.position-1)
+	}
+	fmt.Printf("  arbitrator: hash %v status %v global state %v\n", step.result.Hash, step.result.Status, step.result.GlobalState)
+	fmt.Printf("  reference:  hash %v status %v global state %v\n", step.refResult.Hash, step.refResult.Status, step.refResult.GlobalState)
+	return nil
+}
+
+type differingStep struct {
+	position  uint64
+	lastEqual common.Hash
+	result    *validator.MachineStepResult
+	refResult *validator.MachineStepResult
+}
+
+// hashAt returns the hash of the i-th step of the hashes. The hashes of a
+// machine are cut short once it stops running, after which its hash no
+// longer changes.
+func hashAt(hashes []common.Hash, i uint64) common.Hash {
+	return hashes[min(i, uint64(len(hashes)-1))]
+}
+
+// bisect finds the first machine step after which the hashes of the runs
+// differ, comparing hashesPerRound evenly spaced hashes at each round. It
+// returns nil if the runs end with the same machine hash.
+func bisect(ctx context.Context, run, refRun validator.ExecutionRun, hashesPerRound uint64) (*differingStep, error) {
+	last, err := run.GetLastStep().Await(ctx)
+	if err != nil {
+		return nil, err
+	}
+	refLast, err := refRun.GetLastStep().Await(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if last.Hash == refLast.Hash && last.Position == refLast.Position {
+		return nil, nil
+	}
+	first, err := run.GetStepAt(0).Await(ctx)
+	if err != nil {
+		return nil, err
+	}
+	refFirst, err := refRun.GetStepAt(0).Await(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if first.Hash != refFirst.Hash {
+		return &differingStep{position: 0, result: first, refResult: refFirst}, nil
+	}
+	// The hashes at start are equal, and the ones at end differ
+	start := uint64(0)
+	end := max(last.Position, refLast.Position)
+	lastEqual := first.Hash
+	for end-start > 1 {
+		stepSize := (end - start + hashesPerRound - 1) / hashesPerRound
+		hashes, err := run.GetMachineHashesWithStepSize(start, stepSize, hashesPerRound).Await(ctx)
+		if err != nil {
+			return nil, err
+		}
+		refHashes, err := refRun.GetMachineHashesWithStepSize(start, stepSize, hashesPerRound).Await(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(hashes) == 0 || len(refHashes) == 0 {
+			return nil, errors.New("no machine hashes returned")
+		}
+		if hashes[0] != refHashes[0] {
+			return nil, fmt.Errorf("machine hashes at step %d changed during bisection", start)
+		}
+		lastEqual = hashes[0]
+		next := end
+		equal := start
+		for i := uint64(1); i < hashesPerRound; i++ {
+			position := start + i*stepSize
+			if position >= end {
+				break
+			}
+			if hashAt(hashes, i) != hashAt(refHashes, i) {
+				next = position
+				break
+			}
+			equal = position
+			lastEqual = hashAt(hashes, i)
+		}
+		start, end = equal, next
+	}
+	result, err := run.GetStepAt(end).Await(ctx)
+	if err != nil {
+		return nil, err
+	}
+	refResult, err := refRun.GetStepAt(end).Await(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &differingStep{position: end, lastEqual: lastEqual, result: result, refResult: refResult}, nil
+}
+
+func parseReplayArgs(args []string) (*ReplayConfig, error) {
+	f := pflag.NewFlagSet("", pflag.ContinueOnError)
+
+	ReplayConfigAddOptions(f)
+
+	k, err := confighelpers.BeginCommonParse(f, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg ReplayConfig
+	if err := confighelpers.EndCommonParse(k, &cfg); err != nil {
+		return nil, err
+	}
+
+	return &cfg, cfg.Validate()
+}
@@ -0,0 +1,102 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package main
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/offchainlabs/nitro/util/containers"
+	"github.com/offchainlabs/nitro/validator"
+)
+
+// mockExecutionRun has the machine hash of a step be its position, plus an
+// offset from the diverge step onwards, until it stops at the last step.
+type mockExecutionRun struct {
+	last    uint64
+	diverge uint64
+	offset  uint64
+}
+
+func (r *mockExecutionRun) hash(position uint64) common.Hash {
+	position = min(position, r.last)
+	if position >= r.diverge {
+		position += r.offset
+	}
+	return common.BigToHash(new(big.Int).SetUint64(position + 1))
+}
+
+func (r *mockExecutionRun) GetStepAt(position uint64) containers.PromiseInterface[*validator.MachineStepResult] {
+	if position == ^uint64(0) {
+		position = r.last
+	}
+	return containers.NewReadyPromise(&validator.MachineStepResult{Hash: r.hash(position), Position: min(position, r.last)}, nil)
+}
+
+func (r *mockExecutionRun) GetMachineHashesWithStepSize(machineStartIndex, stepSize, maxIterations uint64) containers.PromiseInterface[[]common.Hash] {
+	hashes := []common.Hash{r.hash(machineStartIndex)}
+	for i := uint64(1); i < maxIterations; i++ {
+		position := machineStartIndex + i*stepSize
+		hashes = append(hashes, r.hash(position))
+		if position >= r.last {
+			break
+		}
+	}
+	return containers.NewReadyPromise(hashes, nil)
+}
+
+func (r *mockExecutionRun) GetLastStep() containers.PromiseInterface[*validator.MachineStepResult] {
+	return r.GetStepAt(^uint64(0))
+}
+
+func (r *mockExecutionRun) GetProofAt(uint64) containers.PromiseInterface[[]byte] {
+	return containers.NewReadyPromise[[]byte](nil, nil)
+}
+
+func (r *mockExecutionRun) PrepareRange(uint64, uint64) containers.PromiseInterface[struct{}] {
+	return containers.NewReadyPromise(struct{}{}, nil)
+}
+
+func (r *mockExecutionRun) Close()                               {}
+func (r *mockExecutionRun) CheckAlive(ctx context.Context) error { return nil }
+
+func TestBisect(t *testing.T) {
+	ctx := context.Background()
+	neverDiverges := ^uint64(0)
+	for _, tc := range []struct {
+		run, refRun *mockExecutionRun
+		expected    uint64
+	}{
+		{&mockExecutionRun{last: 100_000, diverge: neverDiverges}, &mockExecutionRun{last: 100_000, diverge: 12_345, offset: 1_000_000}, 12_345},
+		{&mockExecutionRun{last: 100_000, diverge: neverDiverges}, &mockExecutionRun{last: 100_000, diverge: 1, offset: 1_000_000}, 1},
+		{&mockExecutionRun{last: 100_000, diverge: neverDiverges}, &mockExecutionRun{last: 100_000, diverge: 100_000, offset: 1_000_000}, 100_000},
+		// The reference machine stops early
+		{&mockExecutionRun{last: 100_000, diverge: neverDiverges}, &mockExecutionRun{last: 777, diverge: neverDiverges}, 778},
+		{&mockExecutionRun{last: 10, diverge: neverDiverges}, &mockExecutionRun{last: 10, diverge: 0, offset: 1_000_000}, 0},
+	} {
+		for _, hashesPerRound := range []uint64{2, 3, 64} {
+			step, err := bisect(ctx, tc.run, tc.refRun, hashesPerRound)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if step == nil || step.position != tc.expected {
+				t.Fatalf("expected runs to differ at step %d with %d hashes per round, got %+v", tc.expected, hashesPerRound, step)
+			}
+			if step.position > 0 && step.lastEqual != tc.run.hash(step.position-1) {
+				t.Fatalf("unexpected last equal hash at step %d", step.position)
+			}
+		}
+	}
+
+	step, err := bisect(ctx, &mockExecutionRun{last: 100, diverge: neverDiverges}, &mockExecutionRun{last: 100, diverge: neverDiverges}, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if step != nil {
+		t.Fatalf("expected identical runs not to differ, got step %d", step.position)
+	}
+}
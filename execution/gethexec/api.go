@@ -55,6 +55,19 @@ func (a *ArbAPI) CheckPublisherHealth(ctx context.Context) error {
 	return a.txPublisher.CheckHealth(ctx)
 }
 
+// SendBundle submits transactions to be included contiguously in a single block,
+// or not at all. It returns the bundle hash once the bundle has been sequenced.
+func (a *ArbAPI) SendBundle(ctx context.Context, args SendBundleArgs) (common.Hash, error) {
+	bundle, err := args.ToBundle()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := a.txPublisher.PublishBundle(ctx, bundle); err != nil {
+		return common.Hash{}, err
+	}
+	return bundle.Hash(), nil
+}
+
 func (a *ArbAPI) GetRawBlockMetadata(ctx context.Context, fromBlock, toBlock rpc.BlockNumber) ([]NumberAndBlockMetadata, error) {
 	if a.bulkBlockMetadataFetcher == nil {
 		return nil, errors.New("arb_getRawBlockMetadata is not available")
@@ -75,11 +88,12 @@ func (a *ArbTimeboostAuctioneerAPI) SubmitAuctionResolutionTransaction(ctx conte
 }
 
 type ArbTimeboostAPI struct {
-	txPublisher TransactionPublisher
+	txPublisher  TransactionPublisher
+	txPreChecker *TxPreChecker
 }
 
-func NewArbTimeboostAPI(publisher TransactionPublisher) *ArbTimeboostAPI {
-	return &ArbTimeboostAPI{publisher}
+func NewArbTimeboostAPI(publisher TransactionPublisher, txPreChecker *TxPreChecker) *ArbTimeboostAPI {
+	return &ArbTimeboostAPI{publisher, txPreChecker}
 }
 
 func (a *ArbTimeboostAPI) SendExpressLaneTransaction(ctx context.Context, msg *timeboost.JsonExpressLaneSubmission) error {
@@ -93,6 +107,15 @@ func (a *ArbTimeboostAPI) SendExpressLaneTransaction(ctx context.Context, msg *t
 	return a.txPublisher.PublishExpressLaneTransaction(ctx, goMsg)
 }
 
+// RoundController returns the express lane controller of the round as seen by
+// this node, including any transfers of control made after the auction.
+func (a *ArbTimeboostAPI) RoundController(ctx context.Context, round hexutil.Uint64) (common.Address, error) {
+	if a.txPreChecker == nil || a.txPreChecker.ExpressLaneTracker() == nil {
+		return common.Address{}, errors.New("timeboost is not enabled on this node")
+	}
+	return a.txPreChecker.ExpressLaneTracker().RoundController(uint64(round))
+}
+
 type ArbDebugAPI struct {
 	blockchain        *core.BlockChain
 	blockRangeBound   uint64
@@ -17,6 +17,7 @@ type TransactionPublisher interface {
 	PublishAuctionResolutionTransaction(ctx context.Context, tx *types.Transaction) error
 	PublishExpressLaneTransaction(ctx context.Context, msg *timeboost.ExpressLaneSubmission) error
 	PublishTransaction(ctx context.Context, tx *types.Transaction, options *arbitrum_types.ConditionalOptions) error
+	PublishBundle(ctx context.Context, bundle *Bundle) error
 	CheckHealth(ctx context.Context) error
 	Initialize(context.Context) error
 	Start(context.Context) error
@@ -45,6 +46,10 @@ func (a *ArbInterface) PublishTransaction(ctx context.Context, tx *types.Transac
 	return a.txPublisher.PublishTransaction(ctx, tx, options)
 }
 
+func (a *ArbInterface) PublishBundle(ctx context.Context, bundle *Bundle) error {
+	return a.txPublisher.PublishBundle(ctx, bundle)
+}
+
 func (a *ArbInterface) PublishExpressLaneTransaction(ctx context.Context, msg *timeboost.JsonExpressLaneSubmission) error {
 	goMsg, err := timeboost.JsonSubmissionToGo(msg)
 	if err != nil {
@@ -0,0 +1,346 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package gethexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/arbitrum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+
+	"github.com/offchainlabs/nitro/arbos/arbosState"
+	"github.com/offchainlabs/nitro/execution"
+)
+
+var (
+	bundleAcceptedCounter = metrics.NewRegisteredCounter("arb/sequencer/bundle/accepted", nil)
+	bundleRejectedCounter = metrics.NewRegisteredCounter("arb/sequencer/bundle/rejected", nil)
+	bundleExpiredCounter  = metrics.NewRegisteredCounter("arb/sequencer/bundle/expired", nil)
+)
+
+var (
+	ErrBundleDisabled      = errors.New("bundles are not accepted by this sequencer")
+	ErrBundleEmpty         = errors.New("bundle has no transactions")
+	ErrBundleTooLarge      = errors.New("bundle too large")
+	ErrBundleBlockRange    = errors.New("bundle target block range already passed")
+	ErrBundleNotIncludable = errors.New("bundle dropped: not all transactions could be included")
+)
+
+// Bundle is an ordered list of transactions that the sequencer either places
+// contiguously in a single block, or drops entirely.
+type Bundle struct {
+	Txs []*types.Transaction
+	// MinBlockNumber and MaxBlockNumber bound the L2 block the bundle may be
+	// included in, zero meaning unbounded.
+	MinBlockNumber uint64
+	MaxBlockNumber uint64
+	// RevertingTxHashes are the transactions that are allowed to revert
+	// without the whole bundle being dropped.
+	RevertingTxHashes []common.Hash
+}
+
+func (b *Bundle) Hash() common.Hash {
+	hashes := make([]byte, 0, len(b.Txs)*common.HashLength)
+	for _, tx := range b.Txs {
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+	return crypto.Keccak256Hash(hashes)
+}
+
+func (b *Bundle) mayRevert(txHash common.Hash) bool {
+	for _, hash := range b.RevertingTxHashes {
+		if hash == txHash {
+			return true
+		}
+	}
+	return false
+}
+
+// SendBundleArgs is the JSON representation of a Bundle accepted by arb_sendBundle.
+type SendBundleArgs struct {
+	Txs               []hexutil.Bytes `json:"txs"`
+	MinBlockNumber    *hexutil.Uint64 `json:"minBlockNumber,omitempty"`
+	MaxBlockNumber    *hexutil.Uint64 `json:"maxBlockNumber,omitempty"`
+	RevertingTxHashes []common.Hash   `json:"revertingTxHashes,omitempty"`
+}
+
+func (a *SendBundleArgs) ToBundle() (*Bundle, error) {
+	bundle := &Bundle{
+		Txs:               make([]*types.Transaction, 0, len(a.Txs)),
+		RevertingTxHashes: a.RevertingTxHashes,
+	}
+	for i, encoded := range a.Txs {
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(encoded); err != nil {
+			return nil, fmt.Errorf("failed to decode bundle transaction %d: %w", i, err)
+		}
+		bundle.Txs = append(bundle.Txs, tx)
+	}
+	if a.MinBlockNumber != nil {
+		bundle.MinBlockNumber = uint64(*a.MinBlockNumber)
+	}
+	if a.MaxBlockNumber != nil {
+		bundle.MaxBlockNumber = uint64(*a.MaxBlockNumber)
+	}
+	if bundle.MaxBlockNumber != 0 && bundle.MinBlockNumber > bundle.MaxBlockNumber {
+		return nil, fmt.Errorf("bundle minBlockNumber %d is greater than maxBlockNumber %d", bundle.MinBlockNumber, bundle.MaxBlockNumber)
+	}
+	return bundle, nil
+}
+
+func BundleToArgs(bundle *Bundle) (*SendBundleArgs, error) {
+	args := &SendBundleArgs{
+		Txs:               make([]hexutil.Bytes, 0, len(bundle.Txs)),
+		RevertingTxHashes: bundle.RevertingTxHashes,
+	}
+	for _, tx := range bundle.Txs {
+		encoded, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		args.Txs = append(args.Txs, encoded)
+	}
+	if bundle.MinBlockNumber != 0 {
+		args.MinBlockNumber = (*hexutil.Uint64)(&bundle.MinBlockNumber)
+	}
+	if bundle.MaxBlockNumber != 0 {
+		args.MaxBlockNumber = (*hexutil.Uint64)(&bundle.MaxBlockNumber)
+	}
+	return args, nil
+}
+
+type bundleQueueItem struct {
+	bundle          *Bundle
+	txSizes         []int
+	resultChan      chan<- error
+	returnedResult  *atomic.Bool
+	ctx             context.Context
+	firstAppearance time.Time
+}
+
+func (i *bundleQueueItem) returnResult(err error) {
+	if i.returnedResult.Swap(true) {
+		return
+	}
+	i.resultChan <- err
+	close(i.resultChan)
+}
+
+func (s *Sequencer) PublishBundle(parentCtx context.Context, bundle *Bundle) error {
+	_, forwarder := s.GetPauseAndForwarder()
+	if forwarder != nil {
+		err := forwarder.PublishBundle(parentCtx, bundle)
+		if !errors.Is(err, ErrNoSequencer) {
+			return err
+		}
+	}
+
+	config := s.config()
+	if config.MaxBundleSize == 0 {
+		return ErrBundleDisabled
+	}
+	if len(bundle.Txs) == 0 {
+		return ErrBundleEmpty
+	}
+	if len(bundle.Txs) > config.MaxBundleSize {
+		return fmt.Errorf("%w: %d transactions, maximum is %d", ErrBundleTooLarge, len(bundle.Txs), config.MaxBundleSize)
+	}
+	txSizes := make([]int, 0, len(bundle.Txs))
+	totalSize := 0
+	for _, tx := range bundle.Txs {
+		if err := s.checkTxAllowed(tx); err != nil {
+			return err
+		}
+		txBytes, err := tx.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		txSizes = append(txSizes, len(txBytes))
+		totalSize += len(txBytes)
+	}
+	if totalSize > config.MaxTxDataSize {
+		return fmt.Errorf("%w: %d bytes, maximum is %d", ErrBundleTooLarge, totalSize, config.MaxTxDataSize)
+	}
+
+	queueTimeout := config.QueueTimeout
+	queueCtx, cancelFunc := ctxWithTimeout(parentCtx, queueTimeout)
+	defer cancelFunc()
+
+	resultChan := make(chan error, 1)
+	item := bundleQueueItem{
+		bundle:          bundle,
+		txSizes:         txSizes,
+		resultChan:      resultChan,
+		returnedResult:  &atomic.Bool{},
+		ctx:             queueCtx,
+		firstAppearance: time.Now(),
+	}
+	select {
+	case s.bundleQueue <- item:
+	case <-queueCtx.Done():
+		return queueCtx.Err()
+	}
+
+	abortCtx, cancel := ctxWithTimeout(parentCtx, queueTimeout*2)
+	defer cancel()
+	select {
+	case res := <-resultChan:
+		return res
+	case <-abortCtx.Done():
+		return abortCtx.Err()
+	}
+}
+
+// takeReadyBundle moves newly submitted bundles into the pending list and
+// returns the first one that may be included in the given block, dropping the
+// ones whose target range has passed. Only called from createBlock.
+func (s *Sequencer) takeReadyBundle(blockNumber uint64) *bundleQueueItem {
+drain:
+	for {
+		select {
+		case item := <-s.bundleQueue:
+			s.pendingBundles = append(s.pendingBundles, item)
+		default:
+			break drain
+		}
+	}
+	var ready *bundleQueueItem
+	remaining := s.pendingBundles[:0]
+	for i := range s.pendingBundles {
+		item := s.pendingBundles[i]
+		if err := item.ctx.Err(); err != nil {
+			item.returnResult(err)
+			continue
+		}
+		if item.bundle.MaxBlockNumber != 0 && blockNumber > item.bundle.MaxBlockNumber {
+			bundleExpiredCounter.Inc(1)
+			item.returnResult(fmt.Errorf("%w: next block %d, maxBlockNumber %d", ErrBundleBlockRange, blockNumber, item.bundle.MaxBlockNumber))
+			continue
+		}
+		if ready == nil && blockNumber >= item.bundle.MinBlockNumber {
+			ready = &item
+			continue
+		}
+		remaining = append(remaining, item)
+	}
+	s.pendingBundles = remaining
+	return ready
+}
+
+// sequenceBundle creates a block holding only the bundle's transactions.
+// The block is discarded, and the bundle dropped, unless every transaction is
+// included and none reverted other than those the bundle allows to revert.
+// Only called from createBlock, returns true if a block was created.
+func (s *Sequencer) sequenceBundle(ctx context.Context, item *bundleQueueItem) bool {
+	forwarder, err := s.getForwarder(ctx)
+	if err != nil {
+		s.pendingBundles = append(s.pendingBundles, *item)
+		return false
+	}
+	if forwarder != nil {
+		s.LaunchUntrackedThread(func() {
+			item.returnResult(forwarder.PublishBundle(item.ctx, item.bundle))
+		})
+		return false
+	}
+
+	config := s.config()
+	header := s.makeSequencingHeader(config)
+	if header == nil {
+		s.pendingBundles = append(s.pendingBundles, *item)
+		return true
+	}
+
+	queueItems := make([]txQueueItem, 0, len(item.bundle.Txs))
+	for i, tx := range item.bundle.Txs {
+		queueItems = append(queueItems, txQueueItem{
+			tx:              tx,
+			txSize:          item.txSizes[i],
+			resultChan:      make(chan error, 1),
+			returnedResult:  &atomic.Bool{},
+			ctx:             item.ctx,
+			firstAppearance: item.firstAppearance,
+		})
+	}
+	s.nonceCache.Resize(config.NonceCacheSize)
+	s.nonceCache.BeginNewBlock()
+	hooks := s.makeSequencingHooks(queueItems)
+	hooks.PostTxFilter = func(header *types.Header, statedb *state.StateDB, arbos *arbosState.ArbosState, tx *types.Transaction, sender common.Address, dataGas uint64, result *core.ExecutionResult) error {
+		if result.Err != nil && !item.bundle.mayRevert(tx.Hash()) {
+			return arbitrum.NewRevertReason(result)
+		}
+		return s.postTxFilter(header, statedb, arbos, tx, sender, dataGas, result)
+	}
+	hooks.BlockFilter = func(_ *types.Header, _ *state.StateDB, _ types.Transactions, _ types.Receipts) error {
+		if hooks.sequencedQueueItemsCount != len(queueItems) {
+			return fmt.Errorf("%w: only %d of %d transactions fit in the block", ErrBundleNotIncludable, hooks.sequencedQueueItemsCount, len(queueItems))
+		}
+		for i, txErr := range hooks.TxErrors {
+			if txErr != nil {
+				return fmt.Errorf("%w: transaction %d (%v): %w", ErrBundleNotIncludable, i, queueItems[i].tx.Hash(), txErr)
+			}
+		}
+		return nil
+	}
+
+	block, err := s.execEngine.SequenceTransactions(header, &hooks.SequencingHooks, nil)
+	if errors.Is(err, execution.ErrRetrySequencer) || errors.Is(err, context.Canceled) {
+		log.Warn("error sequencing bundle, will retry", "bundle", item.bundle.Hash(), "err", err)
+		s.pendingBundles = append(s.pendingBundles, *item)
+		return false
+	}
+	if err != nil {
+		bundleRejectedCounter.Inc(1)
+		log.Debug("dropped bundle", "bundle", item.bundle.Hash(), "err", err)
+		item.returnResult(err)
+		return false
+	}
+	if block == nil {
+		bundleRejectedCounter.Inc(1)
+		item.returnResult(ErrBundleNotIncludable)
+		return false
+	}
+	successfulBlocksCounter.Inc(1)
+	bundleAcceptedCounter.Inc(1)
+	s.nonceCache.Finalize(block)
+	item.returnResult(nil)
+	return true
+}
+
+// drainBundles forwards or fails all bundles still held by the sequencer.
+func (s *Sequencer) drainBundles(forwarder *TxForwarder) {
+drain:
+	for {
+		select {
+		case item := <-s.bundleQueue:
+			s.pendingBundles = append(s.pendingBundles, item)
+		default:
+			break drain
+		}
+	}
+	for _, item := range s.pendingBundles {
+		if forwarder == nil {
+			item.returnResult(ErrNoSequencer)
+			continue
+		}
+		if err := forwarder.PublishBundle(item.ctx, item.bundle); err != nil {
+			log.Warn("failed to forward bundle while shutting down", "bundle", item.bundle.Hash(), "err", err)
+			item.returnResult(err)
+		} else {
+			item.returnResult(nil)
+		}
+	}
+	s.pendingBundles = nil
+}
@@ -67,6 +67,12 @@ func NewExpressLaneTracker(
 	}
 }
 
+// UseLogs makes the tracker follow the events of the auction contract instead
+// of polling its resolved rounds. It must be called before Start.
+func (t *ExpressLaneTracker) UseLogs() {
+	t.useLogs = true
+}
+
 func (t *ExpressLaneTracker) Start(ctxIn context.Context) {
 	if t.useLogs {
 		t.startViaLogIterator(ctxIn)
@@ -207,6 +213,30 @@ func (t *ExpressLaneTracker) startViaLogIterator(ctxIn context.Context) {
 				log.Error("Error occurred while iterating auction resolutions", "error", it.Error())
 			}
 
+			// Controllers can transfer control of a resolved round, which is
+			// emitted after the resolution so it overrides the auction winner.
+			setIt, err := t.auctionContract.FilterSetExpressLaneController(filterOpts, nil, nil, nil)
+			if err != nil {
+				log.Error("Could not filter express lane controller transfer events", "error", err)
+				continue
+			}
+			for setIt.Next() {
+				if setIt.Event.PreviousExpressLaneController == (common.Address{}) {
+					// Set by the auction resolution handled above
+					continue
+				}
+				log.Info(
+					"SetExpressLaneController: Express lane control transferred",
+					"round", setIt.Event.Round,
+					"previousController", setIt.Event.PreviousExpressLaneController,
+					"newController", setIt.Event.NewExpressLaneController,
+				)
+				t.roundControl.Store(setIt.Event.Round, setIt.Event.NewExpressLaneController)
+			}
+			if setIt.Error() != nil {
+				log.Error("Error occurred while iterating express lane controller transfers", "error", setIt.Error())
+			}
+
 			fromBlock = toBlock + 1
 		}
 	})
@@ -234,11 +264,27 @@ func (t *ExpressLaneTracker) startViaContractPolling(ctxIn context.Context) {
 			case <-ticker.C:
 			}
 
-			record, ok := t.readLatestResolvedRound(ctx)
+			records, ok := t.readResolvedRounds(ctx)
 			if !ok {
 				continue
 			}
+			// The controller of an already seen round only changes when it's transferred
+			for _, record := range records {
+				if record.round > highestSeenRound {
+					continue
+				}
+				if current, ok := t.roundControl.Load(record.round); ok && current != record.controller {
+					log.Info(
+						"SetExpressLaneController: Express lane control transferred",
+						"round", record.round,
+						"previousController", current,
+						"newController", record.controller,
+					)
+					t.roundControl.Store(record.round, record.controller)
+				}
+			}
 
+			record := records[0]
 			if record.round > highestSeenRound {
 				highestSeenRound = record.round
 
@@ -308,9 +354,8 @@ type resolvedRecord struct {
 	controller common.Address
 }
 
-// returns the latest resolved round information
-// assuming the first round in the 2 round array is always the most recent round
-func (t *ExpressLaneTracker) readLatestResolvedRound(parentCtx context.Context) (resolvedRecord, bool) {
+// returns the information of both resolved rounds, the first one always being the most recent round
+func (t *ExpressLaneTracker) readResolvedRounds(parentCtx context.Context) ([]resolvedRecord, bool) {
 	// Per-call timeout shorter than poll interval to avoid a slow node stalling the loop
 	timeout := t.pollInterval / 2
 	if timeout <= 0 {
@@ -319,19 +364,23 @@ func (t *ExpressLaneTracker) readLatestResolvedRound(parentCtx context.Context)
 	ctx, cancel := context.WithTimeout(parentCtx, timeout)
 	defer cancel()
 
-	r0, _, err := t.auctionContract.ResolvedRounds(&bind.CallOpts{Context: ctx})
+	r0, r1, err := t.auctionContract.ResolvedRounds(&bind.CallOpts{Context: ctx})
 	if err != nil {
 		log.Warn("ExpressLaneTracker: resolvedRounds call failed", "err", err)
-		return resolvedRecord{}, false
+		return nil, false
 	}
 
 	controller := r0.ExpressLaneController // adjust if binding fields differ
 	round := r0.Round
 	if controller == (common.Address{}) || round == 0 {
 		log.Warn("ExpressLaneTracker: empty resolved round", "round", round, "controller", controller)
-		return resolvedRecord{}, false
+		return nil, false
+	}
+	records := []resolvedRecord{{round: round, controller: controller}}
+	if r1.ExpressLaneController != (common.Address{}) && r1.Round != 0 {
+		records = append(records, resolvedRecord{round: r1.Round, controller: r1.ExpressLaneController})
 	}
-	return resolvedRecord{round: round, controller: controller}, true
+	return records, true
 }
 
 // elapsedSinceAuctionClose returns how long ago the auction for `round` closed.
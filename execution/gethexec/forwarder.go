@@ -152,6 +152,29 @@ func (f *TxForwarder) PublishTransaction(inctx context.Context, tx *types.Transa
 	return errors.New("failed to publish transaction to any of the forwarding targets")
 }
 
+func (f *TxForwarder) PublishBundle(inctx context.Context, bundle *Bundle) error {
+	if !f.enabled.Load() {
+		return ErrNoSequencer
+	}
+	args, err := BundleToArgs(bundle)
+	if err != nil {
+		return err
+	}
+	ctx, cancelFunc := f.ctxWithTimeout()
+	defer cancelFunc()
+	for pos, rpcClient := range f.rpcClients {
+		err := rpcClient.CallContext(ctx, nil, "arb_sendBundle", args)
+		if err != nil {
+			log.Warn("error forwarding bundle to a backup target", "target", f.targets[pos], "err", err)
+		}
+		if err == nil || !f.tryNewForwarderErrors.MatchString(err.Error()) {
+			return err
+		}
+	}
+	log.Error("Failed to publish bundle to any of the forwarding targets", "numTargets", len(f.rpcClients))
+	return errors.New("failed to publish bundle to any of the forwarding targets")
+}
+
 func (f *TxForwarder) PublishExpressLaneTransaction(inctx context.Context, msg *timeboost.ExpressLaneSubmission) error {
 	if !f.enabled.Load() {
 		return ErrNoSequencer
@@ -298,6 +321,10 @@ func (f *TxDropper) PublishTransaction(ctx context.Context, tx *types.Transactio
 	return txDropperErr
 }
 
+func (f *TxDropper) PublishBundle(ctx context.Context, bundle *Bundle) error {
+	return txDropperErr
+}
+
 func (f *TxDropper) PublishExpressLaneTransaction(ctx context.Context, msg *timeboost.ExpressLaneSubmission) error {
 	return txDropperErr
 }
@@ -349,6 +376,14 @@ func (f *RedisTxForwarder) PublishTransaction(ctx context.Context, tx *types.Tra
 	return forwarder.PublishTransaction(ctx, tx, options)
 }
 
+func (f *RedisTxForwarder) PublishBundle(ctx context.Context, bundle *Bundle) error {
+	forwarder := f.getForwarder()
+	if forwarder == nil {
+		return ErrNoSequencer
+	}
+	return forwarder.PublishBundle(ctx, bundle)
+}
+
 func (f *RedisTxForwarder) PublishExpressLaneTransaction(ctx context.Context, msg *timeboost.ExpressLaneSubmission) error {
 	forwarder := f.getForwarder()
 	if forwarder == nil {
@@ -332,6 +332,14 @@ func CreateExecutionNode(
 		Service:   NewArbAPI(txPublisher, bulkBlockMetadataFetcher, execEngine),
 		Public:    false,
 	}}
+	if sequencer != nil && config.Sequencer.EnableQueueIntrospection {
+		apis = append(apis, rpc.API{
+			Namespace: "arb",
+			Version:   "1.0",
+			Service:   NewArbSequencerQueueAPI(sequencer),
+			Public:    false,
+		})
+	}
 	apis = append(apis, rpc.API{
 		Namespace:     "auctioneer",
 		Version:       "1.0",
@@ -342,7 +350,7 @@ func CreateExecutionNode(
 	apis = append(apis, rpc.API{
 		Namespace: "timeboost",
 		Version:   "1.0",
-		Service:   NewArbTimeboostAPI(txPublisher),
+		Service:   NewArbTimeboostAPI(txPublisher, txPreChecker),
 		Public:    false,
 	})
 	apis = append(apis, rpc.API{
@@ -355,6 +363,16 @@ func CreateExecutionNode(
 		),
 		Public: false,
 	})
+	retryablesAPI, err := NewArbRetryablesAPI(l2BlockChain, chainDB, filterSystem, config.RPC.ArbDebug.BlockRangeBound, config.RPC.ArbDebug.TimeoutQueueBound)
+	if err != nil {
+		return nil, err
+	}
+	apis = append(apis, rpc.API{
+		Namespace: "arb",
+		Version:   "1.0",
+		Service:   retryablesAPI,
+		Public:    false,
+	})
 	apis = append(apis, rpc.API{
 		Namespace: "arbtrace",
 		Version:   "1.0",
@@ -0,0 +1,361 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package gethexec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/ethdb"
+
+	"github.com/offchainlabs/nitro/arbos/arbosState"
+	"github.com/offchainlabs/nitro/arbos/retryables"
+	"github.com/offchainlabs/nitro/arbos/util"
+	"github.com/offchainlabs/nitro/solgen/go/precompilesgen"
+)
+
+const (
+	RetryableStatusPending  = "pending"
+	RetryableStatusRedeemed = "redeemed"
+	RetryableStatusCanceled = "canceled"
+	RetryableStatusExpired  = "expired"
+	// the retryable was deleted, but its redeem history isn't fully known to this node
+	RetryableStatusUnknown = "unknown"
+)
+
+var errRetryableNotFound = errors.New("retryable not found")
+
+// RetryableRedeemAttempt is a redeem of a retryable scheduled either by its
+// submission (the auto-redeem) or by a call to ArbRetryableTx.redeem.
+type RetryableRedeemAttempt struct {
+	RetryTxHash common.Hash    `json:"retryTxHash"`
+	SequenceNum uint64         `json:"sequenceNum"`
+	ScheduledBy common.Hash    `json:"scheduledBy"`
+	BlockNumber uint64         `json:"blockNumber"`
+	DonatedGas  uint64         `json:"donatedGas"`
+	GasDonor    common.Address `json:"gasDonor"`
+	// nil if the retry tx isn't known to this node
+	Receipt *types.Receipt `json:"receipt,omitempty"`
+}
+
+type RetryableInfo struct {
+	TicketId    common.Hash     `json:"ticketId"`
+	Status      string          `json:"status"`
+	From        common.Address  `json:"from"`
+	To          *common.Address `json:"to"`
+	Beneficiary common.Address  `json:"beneficiary"`
+	Callvalue   *hexutil.Big    `json:"callvalue"`
+	Calldata    hexutil.Bytes   `json:"calldata"`
+	// only set while the retryable is pending
+	NumTries           *hexutil.Uint64 `json:"numTries,omitempty"`
+	Timeout            *hexutil.Uint64 `json:"timeout,omitempty"`
+	TimeoutWindowsLeft *hexutil.Uint64 `json:"timeoutWindowsLeft,omitempty"`
+	// only set by arb_getRetryable, if the submission is known to this node
+	CreationBlock *hexutil.Uint64          `json:"creationBlock,omitempty"`
+	Redeems       []RetryableRedeemAttempt `json:"redeems,omitempty"`
+	CanceledBy    *common.Hash             `json:"canceledBy,omitempty"`
+	// only set by arb_getRetryable, the blocks searched for the redeem history
+	HistoryFromBlock *hexutil.Uint64 `json:"historyFromBlock,omitempty"`
+	HistoryToBlock   *hexutil.Uint64 `json:"historyToBlock,omitempty"`
+}
+
+type RetryablesFilter struct {
+	From        *common.Address `json:"from"`
+	To          *common.Address `json:"to"`
+	Beneficiary *common.Address `json:"beneficiary"`
+	Limit       *hexutil.Uint64 `json:"limit"`
+	// the position in the timeout queue to continue from, as returned by a previous page
+	Cursor *hexutil.Uint64 `json:"cursor"`
+}
+
+type RetryablesPage struct {
+	Retryables []*RetryableInfo `json:"retryables"`
+	// the position in the timeout queue the next page starts at, nil once the whole queue was scanned
+	NextCursor *hexutil.Uint64 `json:"nextCursor,omitempty"`
+}
+
+func (f *RetryablesFilter) matches(info *RetryableInfo) bool {
+	if f.From != nil && *f.From != info.From {
+		return false
+	}
+	if f.To != nil && (info.To == nil || *f.To != *info.To) {
+		return false
+	}
+	if f.Beneficiary != nil && *f.Beneficiary != info.Beneficiary {
+		return false
+	}
+	return true
+}
+
+// ArbRetryablesAPI explains the lifecycle of retryables, so that users don't
+// have to decode ArbOS storage to tell why a deposit didn't execute.
+type ArbRetryablesAPI struct {
+	blockchain        *core.BlockChain
+	chainDb           ethdb.Database
+	filterSystem      *filters.FilterSystem
+	blockRangeBound   uint64
+	timeoutQueueBound uint64
+
+	redeemScheduledID common.Hash
+	canceledID        common.Hash
+}
+
+func NewArbRetryablesAPI(blockchain *core.BlockChain, chainDb ethdb.Database, filterSystem *filters.FilterSystem, blockRangeBound uint64, timeoutQueueBound uint64) (*ArbRetryablesAPI, error) {
+	retryableAbi, err := precompilesgen.ArbRetryableTxMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &ArbRetryablesAPI{
+		blockchain:        blockchain,
+		chainDb:           chainDb,
+		filterSystem:      filterSystem,
+		blockRangeBound:   blockRangeBound,
+		timeoutQueueBound: timeoutQueueBound,
+		redeemScheduledID: retryableAbi.Events["RedeemScheduled"].ID,
+		canceledID:        retryableAbi.Events["Canceled"].ID,
+	}, nil
+}
+
+// GetRetryable returns the retryable with the given ticket id, along with the
+// history of its redeem attempts. Retryables which are no longer pending are
+// described from their submission transaction. The history is searched over at
+// most BlockRangeBound blocks, from the submission if it's known to this node,
+// or else up to the head block.
+func (api *ArbRetryablesAPI) GetRetryable(ctx context.Context, ticketId common.Hash) (*RetryableInfo, error) {
+	state, header, err := api.headState()
+	if err != nil {
+		return nil, err
+	}
+	info, err := pendingRetryableInfo(state, ticketId, header.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	// the ticket id is the hash of the submission
+	fromBlock := api.blockchain.Config().ArbitrumChainParams.GenesisBlockNum
+	toBlock := header.Number.Uint64()
+	submission, _, creationBlock, _ := rawdb.ReadTransaction(api.chainDb, ticketId)
+	if submission != nil {
+		if info == nil {
+			info, err = submittedRetryableInfo(ticketId, submission)
+			if err != nil {
+				return nil, err
+			}
+		}
+		info.CreationBlock = (*hexutil.Uint64)(&creationBlock)
+		fromBlock = creationBlock
+	}
+	if info == nil {
+		return nil, errRetryableNotFound
+	}
+	truncated := false
+	if api.blockRangeBound != 0 && toBlock >= fromBlock && toBlock-fromBlock >= api.blockRangeBound {
+		truncated = true
+		if submission != nil {
+			toBlock = fromBlock + api.blockRangeBound - 1
+		} else {
+			fromBlock = toBlock - api.blockRangeBound + 1
+		}
+	}
+	info.HistoryFromBlock = (*hexutil.Uint64)(&fromBlock)
+	info.HistoryToBlock = (*hexutil.Uint64)(&toBlock)
+
+	logs, err := api.filterSystem.NewRangeFilter(
+		int64(fromBlock), // #nosec G115
+		int64(toBlock),   // #nosec G115
+		[]common.Address{types.ArbRetryableTxAddress},
+		[][]common.Hash{{api.redeemScheduledID, api.canceledID}, {ticketId}},
+	).Logs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	redeemed := false
+	receiptsMissing := false
+	for _, txLog := range logs {
+		if txLog.Topics[0] == api.canceledID {
+			info.CanceledBy = &txLog.TxHash
+			continue
+		}
+		event, err := util.ParseRedeemScheduledLog(txLog)
+		if err != nil {
+			return nil, err
+		}
+		attempt := RetryableRedeemAttempt{
+			RetryTxHash: event.RetryTxHash,
+			SequenceNum: event.SequenceNum,
+			ScheduledBy: txLog.TxHash,
+			BlockNumber: txLog.BlockNumber,
+			DonatedGas:  event.DonatedGas,
+			GasDonor:    event.GasDonor,
+			Receipt:     api.receipt(event.RetryTxHash),
+		}
+		if attempt.Receipt == nil {
+			receiptsMissing = true
+		} else if attempt.Receipt.Status == types.ReceiptStatusSuccessful {
+			redeemed = true
+		}
+		info.Redeems = append(info.Redeems, attempt)
+	}
+	if info.Status != RetryableStatusPending {
+		switch {
+		case redeemed:
+			info.Status = RetryableStatusRedeemed
+		case info.CanceledBy != nil:
+			info.Status = RetryableStatusCanceled
+		case receiptsMissing || truncated:
+			// a redeem which isn't known to have failed might have succeeded
+			info.Status = RetryableStatusUnknown
+		default:
+			info.Status = RetryableStatusExpired
+		}
+	}
+	return info, nil
+}
+
+// ListRetryables returns a page of the pending retryables matching the filter,
+// in the order they time out. A page scans at most TimeoutQueueBound entries
+// of the timeout queue, and the next one continues from its NextCursor. Kept
+// alive retryables have an entry in the queue per lifetime, so they may be
+// listed by several pages. The redeem history isn't looked up, see GetRetryable.
+func (api *ArbRetryablesAPI) ListRetryables(ctx context.Context, filter RetryablesFilter) (*RetryablesPage, error) {
+	state, header, err := api.headState()
+	if err != nil {
+		return nil, err
+	}
+	return listPendingRetryables(ctx, state, header.Time, filter, api.timeoutQueueBound)
+}
+
+// The cursor is the storage offset of a timeout queue entry rather than its
+// index, so that a page continues where the previous one stopped even if
+// retryables were reaped from the front of the queue in between.
+func listPendingRetryables(ctx context.Context, state *arbosState.ArbosState, currentTime uint64, filter RetryablesFilter, timeoutQueueBound uint64) (*RetryablesPage, error) {
+	var limit uint64
+	if filter.Limit != nil {
+		limit = uint64(*filter.Limit)
+	}
+	var start uint64
+	if filter.Cursor != nil {
+		start = uint64(*filter.Cursor)
+	}
+	page := &RetryablesPage{Retryables: []*RetryableInfo{}}
+	seen := make(map[common.Hash]struct{})
+	var scanned uint64
+	err := state.RetryableState().TimeoutQueue.ForEachFromOffset(start, func(offset uint64, ticketId common.Hash) (bool, error) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		if timeoutQueueBound != 0 && scanned >= timeoutQueueBound {
+			page.NextCursor = (*hexutil.Uint64)(&offset)
+			return true, nil
+		}
+		scanned++
+		if _, ok := seen[ticketId]; ok {
+			return false, nil
+		}
+		seen[ticketId] = struct{}{}
+		info, err := pendingRetryableInfo(state, ticketId, currentTime)
+		if err != nil || info == nil || !filter.matches(info) {
+			return false, err
+		}
+		page.Retryables = append(page.Retryables, info)
+		if limit != 0 && uint64(len(page.Retryables)) >= limit {
+			next := offset + 1
+			page.NextCursor = (*hexutil.Uint64)(&next)
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func (api *ArbRetryablesAPI) headState() (*arbosState.ArbosState, *types.Header, error) {
+	head := api.blockchain.CurrentBlock()
+	if head == nil {
+		return nil, nil, errors.New("no head block")
+	}
+	return stateAndHeader(api.blockchain, head.Number.Uint64())
+}
+
+func (api *ArbRetryablesAPI) receipt(txHash common.Hash) *types.Receipt {
+	tx, blockHash, _, index := rawdb.ReadTransaction(api.chainDb, txHash)
+	if tx == nil {
+		return nil
+	}
+	receipts := api.blockchain.GetReceiptsByHash(blockHash)
+	if index >= uint64(len(receipts)) {
+		return nil
+	}
+	return receipts[index]
+}
+
+func pendingRetryableInfo(state *arbosState.ArbosState, ticketId common.Hash, currentTime uint64) (*RetryableInfo, error) {
+	retryable, err := state.RetryableState().OpenRetryable(ticketId, currentTime)
+	if err != nil || retryable == nil {
+		return nil, err
+	}
+	info := &RetryableInfo{
+		TicketId: ticketId,
+		Status:   RetryableStatusPending,
+	}
+	if info.From, err = retryable.From(); err != nil {
+		return nil, err
+	}
+	if info.To, err = retryable.To(); err != nil {
+		return nil, err
+	}
+	if info.Beneficiary, err = retryable.Beneficiary(); err != nil {
+		return nil, err
+	}
+	callvalue, err := retryable.Callvalue()
+	if err != nil {
+		return nil, err
+	}
+	info.Callvalue = (*hexutil.Big)(callvalue)
+	if info.Calldata, err = retryable.Calldata(); err != nil {
+		return nil, err
+	}
+	numTries, err := retryable.NumTries()
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := retryable.CalculateTimeout()
+	if err != nil {
+		return nil, err
+	}
+	windowsLeft, err := retryable.TimeoutWindowsLeft()
+	if err != nil {
+		return nil, err
+	}
+	// the next timeout, which gets extended as long as there are windows left
+	timeout -= windowsLeft * retryables.RetryableLifetimeSeconds
+	info.NumTries = (*hexutil.Uint64)(&numTries)
+	info.Timeout = (*hexutil.Uint64)(&timeout)
+	info.TimeoutWindowsLeft = (*hexutil.Uint64)(&windowsLeft)
+	return info, nil
+}
+
+func submittedRetryableInfo(ticketId common.Hash, submission *types.Transaction) (*RetryableInfo, error) {
+	inner, ok := submission.GetInner().(*types.ArbitrumSubmitRetryableTx)
+	if !ok {
+		return nil, fmt.Errorf("%w: %v is not a retryable submission", errRetryableNotFound, ticketId)
+	}
+	return &RetryableInfo{
+		TicketId:    ticketId,
+		From:        inner.From,
+		To:          inner.RetryTo,
+		Beneficiary: inner.Beneficiary,
+		Callvalue:   (*hexutil.Big)(inner.RetryValue),
+		Calldata:    inner.RetryData,
+	}, nil
+}
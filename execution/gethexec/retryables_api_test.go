@@ -0,0 +1,76 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package gethexec
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/offchainlabs/nitro/arbos/arbosState"
+	"github.com/offchainlabs/nitro/arbos/util"
+)
+
+func TestListPendingRetryablesWhileReaping(t *testing.T) {
+	ctx := context.Background()
+	state, statedb := arbosState.NewArbosMemoryBackedArbOSState()
+	retryableState := state.RetryableState()
+	from := common.HexToAddress("0x1234")
+	to := common.HexToAddress("0x5678")
+	beneficiary := common.HexToAddress("0x9abc")
+	ticketIds := []common.Hash{{1}, {2}, {3}}
+	for i, ticketId := range ticketIds {
+		// #nosec G115
+		_, err := retryableState.CreateRetryable(ticketId, uint64(10*(i+1)), from, &to, big.NewInt(0), beneficiary, nil)
+		require.NoError(t, err)
+	}
+	listed := func(page *RetryablesPage) []common.Hash {
+		var ids []common.Hash
+		for _, info := range page.Retryables {
+			ids = append(ids, info.TicketId)
+		}
+		return ids
+	}
+
+	limit := hexutil.Uint64(1)
+	page, err := listPendingRetryables(ctx, state, 0, RetryablesFilter{Beneficiary: &beneficiary, Limit: &limit}, 0)
+	require.NoError(t, err)
+	require.Equal(t, ticketIds[:1], listed(page))
+	require.NotNil(t, page.NextCursor)
+
+	// Reaping the first retryable mustn't make the next page skip the second one
+	evm := vm.NewEVM(vm.BlockContext{}, statedb, &params.ChainConfig{}, vm.Config{})
+	require.NoError(t, retryableState.TryToReapOneRetryable(15, evm, util.TracingDuringEVM))
+	size, err := retryableState.TimeoutQueue.Size()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), size)
+
+	page, err = listPendingRetryables(ctx, state, 15, RetryablesFilter{Beneficiary: &beneficiary, Limit: &limit, Cursor: page.NextCursor}, 0)
+	require.NoError(t, err)
+	require.Equal(t, ticketIds[1:2], listed(page))
+	require.NotNil(t, page.NextCursor)
+
+	page, err = listPendingRetryables(ctx, state, 15, RetryablesFilter{Beneficiary: &beneficiary, Cursor: page.NextCursor}, 1)
+	require.NoError(t, err)
+	require.Equal(t, ticketIds[2:], listed(page))
+	require.Nil(t, page.NextCursor)
+
+	// A cursor before the front of the queue starts from the front
+	start := hexutil.Uint64(0)
+	page, err = listPendingRetryables(ctx, state, 15, RetryablesFilter{Cursor: &start}, 1)
+	require.NoError(t, err)
+	require.Equal(t, ticketIds[1:2], listed(page))
+	require.NotNil(t, page.NextCursor)
+	page, err = listPendingRetryables(ctx, state, 15, RetryablesFilter{Cursor: page.NextCursor}, 1)
+	require.NoError(t, err)
+	require.Equal(t, ticketIds[2:], listed(page))
+	require.Nil(t, page.NextCursor)
+}
@@ -79,6 +79,9 @@ type SequencerConfig struct {
 	ExpectedSurplusSoftThreshold string          `koanf:"expected-surplus-soft-threshold" reload:"hot"`
 	ExpectedSurplusHardThreshold string          `koanf:"expected-surplus-hard-threshold" reload:"hot"`
 	EnableProfiling              bool            `koanf:"enable-profiling" reload:"hot"`
+	OrderingPolicy               string          `koanf:"ordering-policy" reload:"hot"`
+	MaxBundleSize                int             `koanf:"max-bundle-size" reload:"hot"`
+	EnableQueueIntrospection     bool            `koanf:"enable-queue-introspection"`
 	Timeboost                    TimeboostConfig `koanf:"timeboost"`
 	Dangerous                    DangerousConfig `koanf:"dangerous"`
 	expectedSurplusSoftThreshold int
@@ -148,6 +151,12 @@ func (c *SequencerConfig) Validate() error {
 	if c.MaxTxDataSize > arbostypes.MaxL2MessageSize-50000 {
 		return errors.New("max-tx-data-size too large for MaxL2MessageSize")
 	}
+	if _, err := NewTxOrderingPolicy(c.OrderingPolicy); err != nil {
+		return err
+	}
+	if c.MaxBundleSize < 0 {
+		return errors.New("max-bundle-size cannot be negative")
+	}
 	if c.Timeboost.Enable {
 		if len(c.Timeboost.AuctionContractAddress) > 0 && !common.IsHexAddress(c.Timeboost.AuctionContractAddress) {
 			return fmt.Errorf("invalid timeboost.auction-contract-address \"%v\"", c.Timeboost.AuctionContractAddress)
@@ -192,6 +201,9 @@ var DefaultSequencerConfig = SequencerConfig{
 	ExpectedSurplusSoftThreshold: "default",
 	ExpectedSurplusHardThreshold: "default",
 	EnableProfiling:              false,
+	OrderingPolicy:               FifoOrderingPolicyName,
+	MaxBundleSize:                0,
+	EnableQueueIntrospection:     false,
 	Timeboost:                    DefaultTimeboostConfig,
 	Dangerous:                    DefaultDangerousConfig,
 }
@@ -221,6 +233,9 @@ func SequencerConfigAddOptions(prefix string, f *pflag.FlagSet) {
 	f.String(prefix+".expected-surplus-soft-threshold", DefaultSequencerConfig.ExpectedSurplusSoftThreshold, "if expected surplus is lower than this value, warnings are posted")
 	f.String(prefix+".expected-surplus-hard-threshold", DefaultSequencerConfig.ExpectedSurplusHardThreshold, "if expected surplus is lower than this value, new incoming transactions will be denied")
 	f.Bool(prefix+".enable-profiling", DefaultSequencerConfig.EnableProfiling, "enable CPU profiling and tracing")
+	f.Int(prefix+".max-bundle-size", DefaultSequencerConfig.MaxBundleSize, "maximum number of transactions in a bundle submitted via arb_sendBundle (0 = bundles disabled)")
+	f.Bool(prefix+".enable-queue-introspection", DefaultSequencerConfig.EnableQueueIntrospection, "track queued transactions and expose them via arb_sequencerQueueStatus and arb_sequencerQueueContent")
+	f.String(prefix+".ordering-policy", DefaultSequencerConfig.OrderingPolicy, "order in which queued transactions are sequenced into a block. Allowed values- "+txOrderingPolicyNames())
 }
 
 func TimeboostAddOptions(prefix string, f *pflag.FlagSet) {
@@ -250,7 +265,8 @@ type txQueueItem struct {
 	ctx             context.Context
 	firstAppearance time.Time
 	isTimeboosted   bool
-	blockStamp      uint64 // block number at which timeboosted tx was added to the txQueue
+	blockStamp      uint64          // block number at which timeboosted tx was added to the txQueue
+	tracker         *txQueueTracker // nil unless queue introspection is enabled
 }
 
 func (i *txQueueItem) returnResultMaybeLog(err error, outputLog bool) {
@@ -260,6 +276,7 @@ func (i *txQueueItem) returnResultMaybeLog(err error, outputLog bool) {
 		}
 		return
 	}
+	i.tracker.remove(i.tx.Hash())
 	i.resultChan <- err
 	close(i.resultChan)
 }
@@ -386,6 +403,7 @@ func (c nonceFailureCache) Add(err NonceError, queueItem txQueueItem) {
 	if evicted {
 		nonceFailureCacheOverflowCounter.Inc(1)
 	}
+	queueItem.tracker.nonceGap(queueItem.tx.Hash(), expiry)
 }
 
 type synchronizedTxQueue struct {
@@ -394,6 +412,7 @@ type synchronizedTxQueue struct {
 }
 
 func (q *synchronizedTxQueue) Push(item txQueueItem) {
+	item.tracker.setState(item.tx.Hash(), queuedTxStateRetry)
 	q.mutex.Lock()
 	q.queue.Push(item)
 	q.mutex.Unlock()
@@ -418,6 +437,9 @@ type Sequencer struct {
 	execEngine         *ExecutionEngine
 	txQueue            chan txQueueItem
 	txRetryQueue       synchronizedTxQueue
+	bundleQueue        chan bundleQueueItem
+	pendingBundles     []bundleQueueItem // only accessed from createBlock
+	queueTracker       *txQueueTracker
 	l1Reader           *headerreader.HeaderReader
 	config             SequencerConfigFetcher
 	senderWhitelist    map[common.Address]struct{}
@@ -426,6 +448,7 @@ type Sequencer struct {
 	expressLaneService *expressLaneService
 	onForwarderSet     chan struct{}
 	parentChain        *parent.ParentChain
+	orderingPolicy     TxOrderingPolicy // only accessed from createBlock
 
 	L1BlockAndTimeMutex sync.Mutex
 	l1BlockNumber       atomic.Uint64
@@ -461,6 +484,7 @@ func NewSequencer(execEngine *ExecutionEngine, l1Reader *headerreader.HeaderRead
 	s := &Sequencer{
 		execEngine:      execEngine,
 		txQueue:         make(chan txQueueItem, config.QueueSize),
+		bundleQueue:     make(chan bundleQueueItem, config.QueueSize),
 		l1Reader:        l1Reader,
 		config:          configFetcher,
 		senderWhitelist: senderWhitelist,
@@ -474,6 +498,9 @@ func NewSequencer(execEngine *ExecutionEngine, l1Reader *headerreader.HeaderRead
 		},
 		timeboostAuctionResolutionTxQueue: make(chan txQueueItem, 10), // There should never be more than 1 outstanding auction resolutions
 	}
+	if config.EnableQueueIntrospection {
+		s.queueTracker = newTxQueueTracker()
+	}
 	s.nonceFailures = &nonceFailureCache{
 		containers.NewLruCacheWithOnEvict(config.NonceCacheSize, s.onNonceFailureEvict),
 		func() time.Duration { return configFetcher().NonceFailureCacheExpiry },
@@ -666,21 +693,8 @@ func (s *Sequencer) publishTransactionToQueue(queueCtx context.Context, tx *type
 	sequencerBacklogGauge.Inc(1)
 	defer sequencerBacklogGauge.Dec(1)
 
-	if len(s.senderWhitelist) > 0 {
-		signer := types.LatestSigner(s.execEngine.bc.Config())
-		sender, err := types.Sender(signer, tx)
-		if err != nil {
-			return err
-		}
-		_, authorized := s.senderWhitelist[sender]
-		if !authorized {
-			return errors.New("transaction sender is not on the whitelist")
-		}
-	}
-	if tx.Type() >= types.ArbitrumDepositTxType || tx.Type() == types.BlobTxType {
-		// Should be unreachable for Arbitrum types due to UnmarshalBinary not accepting Arbitrum internal txs
-		// and we want to disallow BlobTxType since Arbitrum doesn't support EIP-4844 txs yet.
-		return types.ErrTxTypeNotSupported
+	if err := s.checkTxAllowed(tx); err != nil {
+		return err
 	}
 
 	txBytes, err := tx.MarshalBinary()
@@ -709,16 +723,42 @@ func (s *Sequencer) publishTransactionToQueue(queueCtx context.Context, tx *type
 		firstAppearance: time.Now(),
 		isTimeboosted:   isExpressLaneController,
 		blockStamp:      blockStamp,
+		tracker:         s.queueTracker,
+	}
+	if s.queueTracker != nil {
+		s.queueTracker.add(&queueItem, s.senderForTracking(tx))
 	}
 	select {
 	case s.txQueue <- queueItem:
 	case <-queueCtx.Done():
+		s.queueTracker.remove(tx.Hash())
 		return queueCtx.Err()
 	}
 
 	return nil
 }
 
+// checkTxAllowed enforces the sender whitelist and the supported tx types
+func (s *Sequencer) checkTxAllowed(tx *types.Transaction) error {
+	if len(s.senderWhitelist) > 0 {
+		signer := types.LatestSigner(s.execEngine.bc.Config())
+		sender, err := types.Sender(signer, tx)
+		if err != nil {
+			return err
+		}
+		_, authorized := s.senderWhitelist[sender]
+		if !authorized {
+			return errors.New("transaction sender is not on the whitelist")
+		}
+	}
+	if tx.Type() >= types.ArbitrumDepositTxType || tx.Type() == types.BlobTxType {
+		// Should be unreachable for Arbitrum types due to UnmarshalBinary not accepting Arbitrum internal txs
+		// and we want to disallow BlobTxType since Arbitrum doesn't support EIP-4844 txs yet.
+		return types.ErrTxTypeNotSupported
+	}
+	return nil
+}
+
 func (s *Sequencer) preTxFilter(_ *params.ChainConfig, header *types.Header, statedb *state.StateDB, _ *arbosState.ArbosState, tx *types.Transaction, options *arbitrum_types.ConditionalOptions, sender common.Address, l1Info *arbos.L1Info) error {
 	if s.nonceCache.Caching() {
 		stateNonce := s.nonceCache.Get(header, statedb, sender)
@@ -964,6 +1004,23 @@ func (s *Sequencer) makeSequencingHooks(items []txQueueItem) *fullSequencingHook
 	return res
 }
 
+// getOrderingPolicy returns the policy selected by the config, recreating it if
+// the config was hot-reloaded with a different policy.
+func (s *Sequencer) getOrderingPolicy(config *SequencerConfig) TxOrderingPolicy {
+	if s.orderingPolicy != nil && s.orderingPolicy.Name() == config.OrderingPolicy {
+		return s.orderingPolicy
+	}
+	policy, err := NewTxOrderingPolicy(config.OrderingPolicy)
+	if err != nil {
+		log.Error("invalid sequencer ordering policy, falling back to fifo", "err", err)
+		policy = fifoOrderingPolicy{}
+	} else if s.orderingPolicy != nil {
+		log.Info("sequencer ordering policy changed", "old", s.orderingPolicy.Name(), "new", policy.Name())
+	}
+	s.orderingPolicy = policy
+	return policy
+}
+
 func (s *Sequencer) expireNonceFailures() *time.Timer {
 	defer nonceFailureCacheSizeGauge.Update(int64(s.nonceFailures.Len()))
 	for {
@@ -1110,6 +1167,11 @@ func (s *Sequencer) createBlock(ctx context.Context) (returnValue bool) {
 		}
 	}()
 
+	// Bundles are sequenced in dedicated blocks, ahead of the regular queue
+	if bundle := s.takeReadyBundle(lastBlock.Number.Uint64() + 1); bundle != nil {
+		return s.sequenceBundle(ctx, bundle)
+	}
+
 	var startOfReadingFromTxQueue time.Time
 
 	for {
@@ -1145,6 +1207,10 @@ func (s *Sequencer) createBlock(ctx context.Context) (returnValue bool) {
 				case queueItem = <-s.txQueue:
 				case queueItem = <-s.timeboostAuctionResolutionTxQueue:
 					log.Debug("Popped the auction resolution tx", "txHash", queueItem.tx.Hash())
+				case bundle := <-s.bundleQueue:
+					// Pick the bundle up on the next call, where it is checked against its target block range
+					s.pendingBundles = append(s.pendingBundles, bundle)
+					return false
 				case <-nextNonceExpiryChan:
 					// No need to stop the previous timer since it already elapsed
 					nextNonceExpiryTimer = s.expireNonceFailures()
@@ -1211,6 +1277,7 @@ func (s *Sequencer) createBlock(ctx context.Context) (returnValue bool) {
 	s.nonceCache.Resize(config.NonceCacheSize) // Would probably be better in a config hook but this is basically free
 	s.nonceCache.BeginNewBlock()
 	queueItems = s.precheckNonces(queueItems)
+	queueItems = orderQueueItems(s.getOrderingPolicy(config), queueItems, types.LatestSigner(s.execEngine.bc.Config()), lastBlock.BaseFee)
 	timeboostedTxs := make(map[common.Hash]struct{})
 	hooks := s.makeSequencingHooks(queueItems)
 	hooks.ConditionalOptionsForTx = make([]*arbitrum_types.ConditionalOptions, len(queueItems))
@@ -1225,35 +1292,14 @@ func (s *Sequencer) createBlock(ctx context.Context) (returnValue bool) {
 		return false
 	}
 
-	timestamp := time.Now().Unix()
-	s.L1BlockAndTimeMutex.Lock()
-	l1Block := s.l1BlockNumber.Load()
-	l1Timestamp := s.l1Timestamp
-	s.L1BlockAndTimeMutex.Unlock()
-
-	if s.l1Reader != nil && (l1Block == 0 || math.Abs(float64(l1Timestamp)-float64(timestamp)) > config.MaxAcceptableTimestampDelta.Seconds()) {
+	header := s.makeSequencingHeader(config)
+	if header == nil {
 		for _, queueItem := range queueItems {
 			s.txRetryQueue.Push(queueItem)
 		}
-		// #nosec G115
-		log.Error(
-			"cannot sequence: unknown L1 block or L1 timestamp too far from local clock time",
-			"l1Block", l1Block,
-			"l1Timestamp", time.Unix(int64(l1Timestamp), 0),
-			"localTimestamp", time.Unix(timestamp, 0),
-		)
 		return true
 	}
 
-	header := &arbostypes.L1IncomingMessageHeader{
-		Kind:        arbostypes.L1MessageType_L2Message,
-		Poster:      l1pricing.BatchPosterAddress,
-		BlockNumber: l1Block,
-		Timestamp:   arbmath.SaturatingUCast[uint64](timestamp),
-		RequestId:   nil,
-		L1BaseFee:   nil,
-	}
-
 	start := time.Now()
 	var (
 		block *types.Block
@@ -1343,6 +1389,36 @@ func (s *Sequencer) createBlock(ctx context.Context) (returnValue bool) {
 	return madeBlock
 }
 
+// makeSequencingHeader returns the header of the next sequenced message, or nil
+// if the latest parent chain block isn't known or too far from the local clock.
+func (s *Sequencer) makeSequencingHeader(config *SequencerConfig) *arbostypes.L1IncomingMessageHeader {
+	timestamp := time.Now().Unix()
+	s.L1BlockAndTimeMutex.Lock()
+	l1Block := s.l1BlockNumber.Load()
+	l1Timestamp := s.l1Timestamp
+	s.L1BlockAndTimeMutex.Unlock()
+
+	if s.l1Reader != nil && (l1Block == 0 || math.Abs(float64(l1Timestamp)-float64(timestamp)) > config.MaxAcceptableTimestampDelta.Seconds()) {
+		// #nosec G115
+		log.Error(
+			"cannot sequence: unknown L1 block or L1 timestamp too far from local clock time",
+			"l1Block", l1Block,
+			"l1Timestamp", time.Unix(int64(l1Timestamp), 0),
+			"localTimestamp", time.Unix(timestamp, 0),
+		)
+		return nil
+	}
+
+	return &arbostypes.L1IncomingMessageHeader{
+		Kind:        arbostypes.L1MessageType_L2Message,
+		Poster:      l1pricing.BatchPosterAddress,
+		BlockNumber: l1Block,
+		Timestamp:   arbmath.SaturatingUCast[uint64](timestamp),
+		RequestId:   nil,
+		L1BaseFee:   nil,
+	}
+}
+
 func (s *Sequencer) updateLatestParentChainBlock(header *types.Header) {
 	s.L1BlockAndTimeMutex.Lock()
 	defer s.L1BlockAndTimeMutex.Unlock()
@@ -1560,6 +1636,10 @@ func (s *Sequencer) StopAndWait() {
 	if s.config().Timeboost.Enable && s.expressLaneService != nil {
 		s.expressLaneService.StopAndWait()
 	}
+	if len(s.pendingBundles) > 0 || len(s.bundleQueue) > 0 {
+		_, forwarder := s.GetPauseAndForwarder()
+		s.drainBundles(forwarder)
+	}
 	if s.txRetryQueue.Len() == 0 &&
 		len(s.txQueue) == 0 &&
 		s.nonceFailures.Len() == 0 &&
@@ -0,0 +1,259 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package gethexec
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	queuedTxStateQueued   = "queued"
+	queuedTxStateRetry    = "retry"
+	queuedTxStateNonceGap = "nonce-gap"
+)
+
+type trackedTx struct {
+	hash            common.Hash
+	sender          common.Address
+	nonce           uint64
+	state           string
+	timeboosted     bool
+	firstAppearance time.Time
+	deadline        time.Time
+	nonceGapRetries uint64
+	nonceGapExpiry  time.Time
+}
+
+// txQueueTracker keeps an index of the transactions held by the sequencer.
+// The queues themselves are either channels or only safe to access from the
+// block creation thread, so they can't be inspected directly.
+type txQueueTracker struct {
+	mutex sync.Mutex
+	txs   map[common.Hash]*trackedTx
+}
+
+func newTxQueueTracker() *txQueueTracker {
+	return &txQueueTracker{
+		txs: make(map[common.Hash]*trackedTx),
+	}
+}
+
+func (t *txQueueTracker) add(item *txQueueItem, sender common.Address) {
+	if t == nil {
+		return
+	}
+	deadline, _ := item.ctx.Deadline()
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.txs[item.tx.Hash()] = &trackedTx{
+		hash:            item.tx.Hash(),
+		sender:          sender,
+		nonce:           item.tx.Nonce(),
+		state:           queuedTxStateQueued,
+		timeboosted:     item.isTimeboosted,
+		firstAppearance: item.firstAppearance,
+		deadline:        deadline,
+	}
+}
+
+func (t *txQueueTracker) setState(txHash common.Hash, state string) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if tracked, ok := t.txs[txHash]; ok {
+		tracked.state = state
+	}
+}
+
+func (t *txQueueTracker) nonceGap(txHash common.Hash, expiry time.Time) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if tracked, ok := t.txs[txHash]; ok {
+		tracked.state = queuedTxStateNonceGap
+		tracked.nonceGapRetries++
+		tracked.nonceGapExpiry = expiry
+	}
+}
+
+func (t *txQueueTracker) remove(txHash common.Hash) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.txs, txHash)
+}
+
+func (t *txQueueTracker) snapshot() []trackedTx {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	txs := make([]trackedTx, 0, len(t.txs))
+	for _, tracked := range t.txs {
+		txs = append(txs, *tracked)
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		return txs[i].firstAppearance.Before(txs[j].firstAppearance)
+	})
+	return txs
+}
+
+type ExpressLaneRoundStatus struct {
+	Round                   uint64   `json:"round"`
+	NextSequenceNumber      uint64   `json:"nextSequenceNumber"`
+	BufferedSequenceNumbers []uint64 `json:"bufferedSequenceNumbers"`
+}
+
+// bufferedSubmissions reports, for every round still cached, the sequence
+// numbers that arrived ahead of their predecessors and are waiting for them.
+func (es *expressLaneService) bufferedSubmissions() []ExpressLaneRoundStatus {
+	es.roundInfoMutex.Lock()
+	defer es.roundInfoMutex.Unlock()
+	var rounds []ExpressLaneRoundStatus
+	for _, round := range es.roundInfo.Keys() {
+		roundInfo, ok := es.roundInfo.Peek(round)
+		if !ok {
+			continue
+		}
+		status := ExpressLaneRoundStatus{
+			Round:                   round,
+			NextSequenceNumber:      roundInfo.sequence,
+			BufferedSequenceNumbers: []uint64{},
+		}
+		for seqNum := range roundInfo.msgBySequenceNumber {
+			if seqNum >= roundInfo.sequence {
+				status.BufferedSequenceNumbers = append(status.BufferedSequenceNumbers, seqNum)
+			}
+		}
+		sort.Slice(status.BufferedSequenceNumbers, func(i, j int) bool {
+			return status.BufferedSequenceNumbers[i] < status.BufferedSequenceNumbers[j]
+		})
+		rounds = append(rounds, status)
+	}
+	sort.Slice(rounds, func(i, j int) bool { return rounds[i].Round < rounds[j].Round })
+	return rounds
+}
+
+type SequencerQueueStatus struct {
+	TxQueueLen      int                      `json:"txQueueLen"`
+	TxQueueCapacity int                      `json:"txQueueCapacity"`
+	RetryQueueLen   int                      `json:"retryQueueLen"`
+	NonceGapTxs     int                      `json:"nonceGapTxs"`
+	BundleQueueLen  int                      `json:"bundleQueueLen"`
+	TrackedTxs      int                      `json:"trackedTxs"`
+	OldestTxAge     string                   `json:"oldestTxAge,omitempty"`
+	ExpressLane     []ExpressLaneRoundStatus `json:"expressLane,omitempty"`
+}
+
+type QueuedTxInfo struct {
+	Hash            common.Hash    `json:"hash"`
+	Sender          common.Address `json:"sender"`
+	Nonce           hexutil.Uint64 `json:"nonce"`
+	State           string         `json:"state"`
+	Timeboosted     bool           `json:"timeboosted"`
+	QueuedAt        time.Time      `json:"queuedAt"`
+	Age             string         `json:"age"`
+	Deadline        *time.Time     `json:"deadline,omitempty"`
+	NonceGapRetries uint64         `json:"nonceGapRetries"`
+	NonceGapExpiry  *time.Time     `json:"nonceGapExpiry,omitempty"`
+}
+
+type SequencerQueueContent struct {
+	Txs         []QueuedTxInfo           `json:"txs"`
+	ExpressLane []ExpressLaneRoundStatus `json:"expressLane,omitempty"`
+}
+
+// ArbSequencerQueueAPI exposes the contents of the sequencer queues for
+// debugging why transactions aren't getting sequenced.
+type ArbSequencerQueueAPI struct {
+	sequencer *Sequencer
+}
+
+func NewArbSequencerQueueAPI(sequencer *Sequencer) *ArbSequencerQueueAPI {
+	return &ArbSequencerQueueAPI{sequencer}
+}
+
+var errQueueIntrospectionDisabled = errors.New("sequencer queue introspection is not enabled")
+
+func (a *ArbSequencerQueueAPI) SequencerQueueStatus(ctx context.Context) (*SequencerQueueStatus, error) {
+	s := a.sequencer
+	if s == nil || s.queueTracker == nil {
+		return nil, errQueueIntrospectionDisabled
+	}
+	txs := s.queueTracker.snapshot()
+	status := &SequencerQueueStatus{
+		TxQueueLen:      len(s.txQueue),
+		TxQueueCapacity: cap(s.txQueue),
+		RetryQueueLen:   s.txRetryQueue.Len(),
+		BundleQueueLen:  len(s.bundleQueue),
+		TrackedTxs:      len(txs),
+	}
+	for _, tx := range txs {
+		if tx.state == queuedTxStateNonceGap {
+			status.NonceGapTxs++
+		}
+	}
+	if len(txs) > 0 {
+		status.OldestTxAge = time.Since(txs[0].firstAppearance).String()
+	}
+	if s.expressLaneService != nil {
+		status.ExpressLane = s.expressLaneService.bufferedSubmissions()
+	}
+	return status, nil
+}
+
+func (a *ArbSequencerQueueAPI) SequencerQueueContent(ctx context.Context) (*SequencerQueueContent, error) {
+	s := a.sequencer
+	if s == nil || s.queueTracker == nil {
+		return nil, errQueueIntrospectionDisabled
+	}
+	now := time.Now()
+	content := &SequencerQueueContent{
+		Txs: []QueuedTxInfo{},
+	}
+	for _, tx := range s.queueTracker.snapshot() {
+		info := QueuedTxInfo{
+			Hash:            tx.hash,
+			Sender:          tx.sender,
+			Nonce:           hexutil.Uint64(tx.nonce),
+			State:           tx.state,
+			Timeboosted:     tx.timeboosted,
+			QueuedAt:        tx.firstAppearance,
+			Age:             now.Sub(tx.firstAppearance).String(),
+			NonceGapRetries: tx.nonceGapRetries,
+		}
+		if !tx.deadline.IsZero() {
+			deadline := tx.deadline
+			info.Deadline = &deadline
+		}
+		if tx.state == queuedTxStateNonceGap {
+			expiry := tx.nonceGapExpiry
+			info.NonceGapExpiry = &expiry
+		}
+		content.Txs = append(content.Txs, info)
+	}
+	if s.expressLaneService != nil {
+		content.ExpressLane = s.expressLaneService.bufferedSubmissions()
+	}
+	return content, nil
+}
+
+// senderForTracking recovers the sender for the queue tracker, leaving it
+// empty if it can't be recovered as the tx will be rejected later anyway.
+func (s *Sequencer) senderForTracking(tx *types.Transaction) common.Address {
+	sender, _ := types.Sender(types.LatestSigner(s.execEngine.bc.Config()), tx)
+	return sender
+}
@@ -0,0 +1,77 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package gethexec
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/offchainlabs/nitro/timeboost"
+	"github.com/offchainlabs/nitro/util/containers"
+)
+
+func TestTxQueueTrackerLifecycle(t *testing.T) {
+	tracker := newTxQueueTracker()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	item := makeOrderingTestItem(t, testPriv, 3, 1, time.Now())
+	item.ctx = ctx
+	item.tracker = tracker
+	item.resultChan = make(chan error, 1)
+	item.returnedResult = &atomic.Bool{}
+	sender := common.HexToAddress("0x1234")
+	tracker.add(&item, sender)
+
+	txs := tracker.snapshot()
+	require.Len(t, txs, 1)
+	require.Equal(t, sender, txs[0].sender)
+	require.Equal(t, queuedTxStateQueued, txs[0].state)
+	require.False(t, txs[0].deadline.IsZero())
+
+	var retryQueue synchronizedTxQueue
+	retryQueue.Push(item)
+	require.Equal(t, queuedTxStateRetry, tracker.snapshot()[0].state)
+
+	expiry := time.Now().Add(time.Second)
+	tracker.nonceGap(item.tx.Hash(), expiry)
+	tracker.nonceGap(item.tx.Hash(), expiry)
+	txs = tracker.snapshot()
+	require.Equal(t, queuedTxStateNonceGap, txs[0].state)
+	require.Equal(t, uint64(2), txs[0].nonceGapRetries)
+
+	item.returnResult(errors.New("done"))
+	require.Empty(t, tracker.snapshot())
+}
+
+func TestExpressLaneBufferedSubmissions(t *testing.T) {
+	es := &expressLaneService{
+		roundInfo: containers.NewLruCache[uint64, *expressLaneRoundInfo](8),
+	}
+	es.roundInfo.Add(7, &expressLaneRoundInfo{
+		sequence: 2,
+		msgBySequenceNumber: map[uint64]*timeboost.ExpressLaneSubmission{
+			0: {}, 1: {}, 5: {}, 3: {},
+		},
+	})
+	es.roundInfo.Add(6, &expressLaneRoundInfo{
+		sequence:            1,
+		msgBySequenceNumber: map[uint64]*timeboost.ExpressLaneSubmission{0: {}},
+	})
+
+	rounds := es.bufferedSubmissions()
+	require.Len(t, rounds, 2)
+	require.Equal(t, uint64(6), rounds[0].Round)
+	require.Empty(t, rounds[0].BufferedSequenceNumbers)
+	require.Equal(t, uint64(7), rounds[1].Round)
+	require.Equal(t, uint64(2), rounds[1].NextSequenceNumber)
+	require.Equal(t, []uint64{3, 5}, rounds[1].BufferedSequenceNumbers)
+}
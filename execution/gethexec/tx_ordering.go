@@ -0,0 +1,250 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package gethexec
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/metrics"
+
+	"github.com/offchainlabs/nitro/util/containers"
+)
+
+const (
+	FifoOrderingPolicyName        = "fifo"
+	PriorityFeeOrderingPolicyName = "priority-fee"
+	FairOrderingPolicyName        = "fair-round-robin"
+)
+
+// TxOrderingPolicy decides the order in which the transactions read from the
+// sequencer queue are offered to the block builder.
+// Implementations must keep the relative order of transactions from the same
+// sender, so that nonces are never sequenced out of order by the policy itself.
+type TxOrderingPolicy interface {
+	Name() string
+	// Order returns a permutation of items. The signer is used to recover
+	// senders, and baseFee is the base fee of the block being built upon.
+	Order(items []txQueueItem, signer types.Signer, baseFee *big.Int) []txQueueItem
+}
+
+var txOrderingPolicies = map[string]func() TxOrderingPolicy{
+	FifoOrderingPolicyName:        func() TxOrderingPolicy { return fifoOrderingPolicy{} },
+	PriorityFeeOrderingPolicyName: func() TxOrderingPolicy { return priorityFeeOrderingPolicy{} },
+	FairOrderingPolicyName:        func() TxOrderingPolicy { return fairOrderingPolicy{} },
+}
+
+func txOrderingPolicyNames() string {
+	names := make([]string, 0, len(txOrderingPolicies))
+	for name := range txOrderingPolicies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func NewTxOrderingPolicy(name string) (TxOrderingPolicy, error) {
+	constructor, ok := txOrderingPolicies[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown transaction ordering policy \"%s\", allowed values: %s", name, txOrderingPolicyNames())
+	}
+	return constructor(), nil
+}
+
+type fifoOrderingPolicy struct{}
+
+func (fifoOrderingPolicy) Name() string { return FifoOrderingPolicyName }
+
+func (fifoOrderingPolicy) Order(items []txQueueItem, _ types.Signer, _ *big.Int) []txQueueItem {
+	return items
+}
+
+// senderQueues groups items by sender, preserving arrival order both within a
+// sender and of the senders themselves (by their first queued item).
+type senderQueues struct {
+	senders []common.Address
+	queues  map[common.Address][]txQueueItem
+}
+
+func groupBySender(items []txQueueItem, signer types.Signer) senderQueues {
+	grouped := senderQueues{
+		queues: make(map[common.Address][]txQueueItem),
+	}
+	for _, item := range items {
+		// An unrecoverable sender is rejected later by precheckNonces,
+		// grouping it under the zero address keeps it in the output.
+		sender, _ := types.Sender(signer, item.tx)
+		if _, ok := grouped.queues[sender]; !ok {
+			grouped.senders = append(grouped.senders, sender)
+		}
+		grouped.queues[sender] = append(grouped.queues[sender], item)
+	}
+	return grouped
+}
+
+// priorityFeeOrderingPolicy repeatedly picks, among the oldest pending tx of
+// every sender, the one paying the highest effective tip. Ties are broken by
+// arrival time, so with equal tips it degrades to FIFO.
+type priorityFeeOrderingPolicy struct{}
+
+func (priorityFeeOrderingPolicy) Name() string { return PriorityFeeOrderingPolicyName }
+
+func (priorityFeeOrderingPolicy) Order(items []txQueueItem, signer types.Signer, baseFee *big.Int) []txQueueItem {
+	grouped := groupBySender(items, signer)
+	tips := make(map[common.Hash]*big.Int, len(items))
+	for _, item := range items {
+		tips[item.tx.Hash()] = item.tx.EffectiveGasTipValue(baseFee)
+	}
+	output := make([]txQueueItem, 0, len(items))
+	for len(output) < len(items) {
+		var best *common.Address
+		for i := range grouped.senders {
+			sender := &grouped.senders[i]
+			queue := grouped.queues[*sender]
+			if len(queue) == 0 {
+				continue
+			}
+			if best == nil {
+				best = sender
+				continue
+			}
+			bestHead := grouped.queues[*best][0]
+			cmp := tips[queue[0].tx.Hash()].Cmp(tips[bestHead.tx.Hash()])
+			if cmp > 0 || (cmp == 0 && queue[0].firstAppearance.Before(bestHead.firstAppearance)) {
+				best = sender
+			}
+		}
+		queue := grouped.queues[*best]
+		output = append(output, queue[0])
+		grouped.queues[*best] = queue[1:]
+	}
+	return output
+}
+
+// fairOrderingPolicy takes one tx from every sender in turn, so that a single
+// sender flooding the queue can't delay everybody else's transactions.
+type fairOrderingPolicy struct{}
+
+func (fairOrderingPolicy) Name() string { return FairOrderingPolicyName }
+
+func (fairOrderingPolicy) Order(items []txQueueItem, signer types.Signer, _ *big.Int) []txQueueItem {
+	grouped := groupBySender(items, signer)
+	output := make([]txQueueItem, 0, len(items))
+	for len(output) < len(items) {
+		for _, sender := range grouped.senders {
+			queue := grouped.queues[sender]
+			if len(queue) == 0 {
+				continue
+			}
+			output = append(output, queue[0])
+			grouped.queues[sender] = queue[1:]
+		}
+	}
+	return output
+}
+
+// maxTrackedSenders bounds the number of senders with their own wait time
+// histogram, the least recently queued sender's histogram being dropped first.
+const maxTrackedSenders = 128
+
+type txOrderingMetrics struct {
+	prefix         string
+	senderWaitTime metrics.Histogram
+	reorderCounter metrics.Counter
+
+	sendersMutex sync.Mutex
+	senders      *containers.LruCache[common.Address, metrics.Histogram]
+}
+
+func (m *txOrderingMetrics) senderWaitTimeName(sender common.Address) string {
+	return m.prefix + "/senderwaittime/" + sender.Hex()
+}
+
+// updateSenderWaitTime records the wait time both across all senders and for the sender itself.
+func (m *txOrderingMetrics) updateSenderWaitTime(sender common.Address, waitTime time.Duration) {
+	m.senderWaitTime.Update(waitTime.Nanoseconds())
+	m.sendersMutex.Lock()
+	defer m.sendersMutex.Unlock()
+	histogram, ok := m.senders.Get(sender)
+	if !ok {
+		histogram = metrics.GetOrRegisterHistogram(m.senderWaitTimeName(sender), nil, metrics.NewBoundedHistogramSample())
+		m.senders.Add(sender, histogram)
+	}
+	histogram.Update(waitTime.Nanoseconds())
+}
+
+var (
+	txOrderingMetricsMutex    sync.Mutex
+	txOrderingMetricsByPolicy = make(map[string]*txOrderingMetrics)
+)
+
+func getTxOrderingMetrics(policyName string) *txOrderingMetrics {
+	txOrderingMetricsMutex.Lock()
+	defer txOrderingMetricsMutex.Unlock()
+	m, ok := txOrderingMetricsByPolicy[policyName]
+	if !ok {
+		prefix := "arb/sequencer/ordering/" + policyName
+		m = &txOrderingMetrics{
+			prefix:         prefix,
+			senderWaitTime: metrics.GetOrRegisterHistogram(prefix+"/senderwaittime", nil, metrics.NewBoundedHistogramSample()),
+			reorderCounter: metrics.GetOrRegisterCounter(prefix+"/reordered", nil),
+		}
+		m.senders = containers.NewLruCacheWithOnEvict(maxTrackedSenders, func(sender common.Address, _ metrics.Histogram) {
+			metrics.Unregister(m.senderWaitTimeName(sender))
+		})
+		txOrderingMetricsByPolicy[policyName] = m
+	}
+	return m
+}
+
+// orderQueueItems applies the policy to every item that isn't timeboosted.
+// Timeboosted items keep their positions, as their order is dictated by the
+// express lane sequence numbers and must not be changed by the policy.
+func orderQueueItems(policy TxOrderingPolicy, items []txQueueItem, signer types.Signer, baseFee *big.Int) []txQueueItem {
+	var orderable []txQueueItem
+	var slots []int
+	for i, item := range items {
+		if !item.isTimeboosted {
+			orderable = append(orderable, item)
+			slots = append(slots, i)
+		}
+	}
+	if len(orderable) == 0 {
+		return items
+	}
+	ordered := policy.Order(orderable, signer, baseFee)
+	if len(ordered) != len(orderable) {
+		// A policy dropping or duplicating transactions is a bug, fall back to FIFO.
+		return items
+	}
+
+	m := getTxOrderingMetrics(policy.Name())
+	output := make([]txQueueItem, len(items))
+	copy(output, items)
+	for i, item := range ordered {
+		if item.tx.Hash() != orderable[i].tx.Hash() {
+			m.reorderCounter.Inc(1)
+		}
+		output[slots[i]] = item
+	}
+
+	now := time.Now()
+	oldestPerSender := make(map[common.Address]time.Time)
+	for _, item := range orderable {
+		sender, _ := types.Sender(signer, item.tx)
+		if oldest, ok := oldestPerSender[sender]; !ok || item.firstAppearance.Before(oldest) {
+			oldestPerSender[sender] = item.firstAppearance
+		}
+	}
+	for sender, oldest := range oldestPerSender {
+		m.updateSenderWaitTime(sender, now.Sub(oldest))
+	}
+	return output
+}
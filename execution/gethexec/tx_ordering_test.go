@@ -0,0 +1,106 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package gethexec
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var orderingTestSigner = types.LatestSignerForChainID(params.TestChainConfig.ChainID)
+
+func makeOrderingTestItem(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, tipGwei int64, arrival time.Time) txQueueItem {
+	t.Helper()
+	tx, err := types.SignNewTx(key, orderingTestSigner, &types.DynamicFeeTx{
+		ChainID:   params.TestChainConfig.ChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(tipGwei * params.GWei),
+		GasFeeCap: big.NewInt(100 * params.GWei),
+		Gas:       21000,
+	})
+	require.NoError(t, err)
+	return txQueueItem{tx: tx, firstAppearance: arrival}
+}
+
+func requireOrder(t *testing.T, items []txQueueItem, expected ...txQueueItem) {
+	t.Helper()
+	require.Len(t, items, len(expected))
+	for i := range expected {
+		require.Equal(t, expected[i].tx.Hash(), items[i].tx.Hash(), "unexpected tx at position %d", i)
+	}
+}
+
+func TestNewTxOrderingPolicy(t *testing.T) {
+	for _, name := range []string{FifoOrderingPolicyName, PriorityFeeOrderingPolicyName, FairOrderingPolicyName} {
+		policy, err := NewTxOrderingPolicy(name)
+		require.NoError(t, err)
+		require.Equal(t, name, policy.Name())
+	}
+	_, err := NewTxOrderingPolicy("lifo")
+	require.Error(t, err)
+}
+
+func TestPriorityFeeOrderingPolicy(t *testing.T) {
+	now := time.Now()
+	a0 := makeOrderingTestItem(t, testPriv, 0, 1, now)
+	a1 := makeOrderingTestItem(t, testPriv, 1, 50, now.Add(time.Millisecond))
+	b0 := makeOrderingTestItem(t, testPriv2, 0, 10, now.Add(2*time.Millisecond))
+	b1 := makeOrderingTestItem(t, testPriv2, 1, 10, now.Add(3*time.Millisecond))
+
+	ordered := priorityFeeOrderingPolicy{}.Order([]txQueueItem{a0, a1, b0, b1}, orderingTestSigner, big.NewInt(params.GWei))
+	// a1 pays the most but must wait for a0, which pays less than b0 and b1.
+	requireOrder(t, ordered, b0, b1, a0, a1)
+}
+
+func TestFairOrderingPolicy(t *testing.T) {
+	now := time.Now()
+	a0 := makeOrderingTestItem(t, testPriv, 0, 1, now)
+	a1 := makeOrderingTestItem(t, testPriv, 1, 1, now)
+	a2 := makeOrderingTestItem(t, testPriv, 2, 1, now)
+	b0 := makeOrderingTestItem(t, testPriv2, 0, 1, now)
+
+	ordered := fairOrderingPolicy{}.Order([]txQueueItem{a0, a1, a2, b0}, orderingTestSigner, nil)
+	requireOrder(t, ordered, a0, b0, a1, a2)
+}
+
+func TestOrderQueueItemsKeepsTimeboostedPositions(t *testing.T) {
+	now := time.Now()
+	a0 := makeOrderingTestItem(t, testPriv, 0, 1, now)
+	controllerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	boosted := makeOrderingTestItem(t, controllerKey, 0, 0, now)
+	boosted.isTimeboosted = true
+	b0 := makeOrderingTestItem(t, testPriv2, 0, 20, now)
+
+	ordered := orderQueueItems(priorityFeeOrderingPolicy{}, []txQueueItem{a0, boosted, b0}, orderingTestSigner, big.NewInt(params.GWei))
+	requireOrder(t, ordered, b0, boosted, a0)
+
+	ordered = orderQueueItems(fifoOrderingPolicy{}, []txQueueItem{a0, boosted, b0}, orderingTestSigner, big.NewInt(params.GWei))
+	requireOrder(t, ordered, a0, boosted, b0)
+}
+
+func TestTxOrderingSenderWaitTimeMetrics(t *testing.T) {
+	m := getTxOrderingMetrics("sender-wait-time-test")
+	senders := make([]common.Address, maxTrackedSenders+1)
+	for i := range senders {
+		senders[i] = common.BigToAddress(big.NewInt(int64(i + 1)))
+		m.updateSenderWaitTime(senders[i], time.Second)
+	}
+	// The least recently queued sender's histogram is dropped to bound the metric's cardinality.
+	require.Nil(t, metrics.Get(m.senderWaitTimeName(senders[0])))
+	for _, sender := range senders[1:] {
+		require.NotNil(t, metrics.Get(m.senderWaitTimeName(sender)))
+	}
+	require.Equal(t, maxTrackedSenders, m.senders.Len())
+}
@@ -229,6 +229,28 @@ func (c *TxPreChecker) PublishTransaction(ctx context.Context, tx *types.Transac
 	return c.TransactionPublisher.PublishTransaction(ctx, tx, options)
 }
 
+// PublishBundle only runs the checks that don't depend on the effects of the
+// earlier transactions in the bundle, as those aren't reflected in the current state.
+func (c *TxPreChecker) PublishBundle(ctx context.Context, bundle *Bundle) error {
+	block := c.bc.CurrentBlock()
+	statedb, err := c.bc.StateAt(block.Root)
+	if err != nil {
+		return err
+	}
+	arbos, err := arbosState.OpenSystemArbosState(statedb, nil, true)
+	if err != nil {
+		return err
+	}
+	config := *c.config()
+	config.Strictness = min(config.Strictness, TxPreCheckerStrictnessAlwaysCompatible)
+	for i, tx := range bundle.Txs {
+		if err := PreCheckTx(c.bc, c.bc.Config(), block, statedb, arbos, tx, nil, &config); err != nil {
+			return fmt.Errorf("bundle transaction %d (%v): %w", i, tx.Hash(), err)
+		}
+	}
+	return c.TransactionPublisher.PublishBundle(ctx, bundle)
+}
+
 func (c *TxPreChecker) PublishExpressLaneTransaction(ctx context.Context, msg *timeboost.ExpressLaneSubmission) error {
 	if msg == nil || msg.Transaction == nil {
 		return timeboost.ErrMalformedData
@@ -278,3 +300,7 @@ func (c *TxPreChecker) PublishAuctionResolutionTransaction(ctx context.Context,
 func (c *TxPreChecker) SetExpressLaneTracker(tracker *ExpressLaneTracker) {
 	c.expressLaneTracker = tracker
 }
+
+func (c *TxPreChecker) ExpressLaneTracker() *ExpressLaneTracker {
+	return c.expressLaneTracker
+}
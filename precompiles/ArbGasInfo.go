@@ -284,3 +284,29 @@ func (con ArbGasInfo) GetL1PricingUnitsSinceUpdate(c ctx, evm mech) (uint64, err
 func (con ArbGasInfo) GetLastL1PricingSurplus(c ctx, evm mech) (*big.Int, error) {
 	return c.State.L1PricingState().LastSurplus()
 }
+
+// GetResourceConstraints gets the resource constraints pricing the L2 base fee, with their target
+// per period and current backlog, ordered by resource and period
+func (con ArbGasInfo) GetResourceConstraints(c ctx, evm mech) ([]uint8, []uint32, []uint64, []uint64, error) {
+	return getResourceConstraints(c)
+}
+
+func getResourceConstraints(c ctx) ([]uint8, []uint32, []uint64, []uint64, error) {
+	rc, err := c.State.L2PricingState().ResourceConstraints()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	list := rc.List()
+	resources := make([]uint8, 0, len(list))
+	periods := make([]uint32, 0, len(list))
+	targets := make([]uint64, 0, len(list))
+	backlogs := make([]uint64, 0, len(list))
+	for _, constraint := range list {
+		resources = append(resources, uint8(constraint.Resource))
+		periods = append(periods, uint32(constraint.PeriodSecs))
+		// the target is set per period, but stored per second
+		targets = append(targets, arbmath.SaturatingUMul(constraint.TargetPerSecond, uint64(constraint.PeriodSecs)))
+		backlogs = append(backlogs, constraint.Backlog)
+	}
+	return resources, periods, targets, backlogs, nil
+}
@@ -10,9 +10,11 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/arbitrum/multigas"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
 
+	"github.com/offchainlabs/nitro/arbos/constraints"
 	"github.com/offchainlabs/nitro/arbos/l1pricing"
 	"github.com/offchainlabs/nitro/arbos/programs"
 	"github.com/offchainlabs/nitro/util/arbmath"
@@ -23,9 +25,11 @@ import (
 // which ensures only a chain owner can access these methods. For methods that
 // are safe for non-owners to call, see ArbOwnerOld
 type ArbOwner struct {
-	Address          addr // 0x70
-	OwnerActs        func(ctx, mech, bytes4, addr, []byte) error
-	OwnerActsGasCost func(bytes4, addr, []byte) (uint64, error)
+	Address           addr // 0x70
+	OwnerActs         func(ctx, mech, bytes4, addr, []byte) error
+	OwnerActsGasCost  func(bytes4, addr, []byte) (uint64, error)
+	FeatureSet        func(ctx, mech, string, bool) error
+	FeatureSetGasCost func(string, bool) (uint64, error)
 }
 
 const NativeTokenEnableDelay = 7 * 24 * 60 * 60
@@ -173,6 +177,29 @@ func (con ArbOwner) SetL2GasBacklogTolerance(c ctx, evm mech, sec uint64) error
 	return c.State.L2PricingState().SetBacklogTolerance(sec)
 }
 
+// SetResourceConstraint sets the gas target of a resource over a period, rounded down to a whole
+// amount of gas per second. While any constraint is set, the L2 base fee is priced by the backlogs
+// of the constraints instead of the gas backlog.
+func (con ArbOwner) SetResourceConstraint(c ctx, _ mech, resource uint8, periodSecs uint32, targetPerPeriod uint64) error {
+	kind := multigas.ResourceKind(resource)
+	if kind <= multigas.ResourceKindUnknown || kind >= multigas.NumResourceKind {
+		return ErrOutOfBounds
+	}
+	if periodSecs == 0 || targetPerPeriod < uint64(periodSecs) {
+		return errors.New("resource constraint must target at least one unit of gas per second")
+	}
+	return c.State.L2PricingState().SetResourceConstraint(kind, constraints.PeriodSecs(periodSecs), targetPerPeriod)
+}
+
+// ClearResourceConstraint removes the gas target of a resource over a period
+func (con ArbOwner) ClearResourceConstraint(c ctx, _ mech, resource uint8, periodSecs uint32) error {
+	kind := multigas.ResourceKind(resource)
+	if kind <= multigas.ResourceKindUnknown || kind >= multigas.NumResourceKind {
+		return ErrOutOfBounds
+	}
+	return c.State.L2PricingState().ClearResourceConstraint(kind, constraints.PeriodSecs(periodSecs))
+}
+
 // GetNetworkFeeAccount gets the network fee collector
 func (con ArbOwner) GetNetworkFeeAccount(c ctx, evm mech) (addr, error) {
 	return c.State.NetworkFeeAccount()
@@ -453,3 +480,18 @@ func (con ArbOwner) SetChainConfig(c ctx, evm mech, serializedChainConfig []byte
 func (con ArbOwner) SetCalldataPriceIncrease(c ctx, _ mech, enable bool) error {
 	return c.State.Features().SetCalldataPriceIncrease(enable)
 }
+
+// SetFeature turns the named ArbOS feature on or off (see ArbOwnerPublic.GetFeatures)
+func (con ArbOwner) SetFeature(c ctx, evm mech, name string, enabled bool) error {
+	wasEnabled, err := c.State.Features().IsEnabled(name)
+	if err != nil {
+		return err
+	}
+	if err := c.State.Features().Set(name, enabled, c.State.ArbOSVersion()); err != nil {
+		return err
+	}
+	if wasEnabled == enabled {
+		return nil
+	}
+	return con.FeatureSet(c, evm, name, enabled)
+}
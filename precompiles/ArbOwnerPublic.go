@@ -6,6 +6,8 @@ package precompiles
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/offchainlabs/nitro/arbos/features"
 )
 
 // ArbOwnerPublic precompile provides non-owners with info about the current chain owners.
@@ -93,3 +95,34 @@ func (con ArbOwnerPublic) IsCalldataPriceIncreaseEnabled(c ctx, _ mech) (bool, e
 func (con ArbOwnerPublic) GetParentGasFloorPerToken(c ctx, evm mech) (uint64, error) {
 	return c.State.L1PricingState().ParentGasFloorPerToken()
 }
+
+// GetResourceConstraints gets the resource constraints set by the chain owner, with their target
+// per period and current backlog, ordered by resource and period
+func (con ArbOwnerPublic) GetResourceConstraints(c ctx, evm mech) ([]uint8, []uint32, []uint64, []uint64, error) {
+	return getResourceConstraints(c)
+}
+
+// IsFeatureEnabled checks if the named ArbOS feature is enabled
+func (con ArbOwnerPublic) IsFeatureEnabled(c ctx, _ mech, name string) (bool, error) {
+	return c.State.Features().IsEnabled(name)
+}
+
+// GetFeatures gets the name, description, minimum ArbOS version and status of every ArbOS feature
+func (con ArbOwnerPublic) GetFeatures(c ctx, _ mech) ([]string, []string, []uint64, []bool, error) {
+	all := features.All()
+	names := make([]string, 0, len(all))
+	descriptions := make([]string, 0, len(all))
+	minArbosVersions := make([]uint64, 0, len(all))
+	enabled := make([]bool, 0, len(all))
+	for _, feature := range all {
+		isEnabled, err := c.State.Features().IsEnabled(feature.Name)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		names = append(names, feature.Name)
+		descriptions = append(descriptions, feature.Description)
+		minArbosVersions = append(minArbosVersions, feature.MinArbosVersion)
+		enabled = append(enabled, isEnabled)
+	}
+	return names, descriptions, minArbosVersions, enabled, nil
+}
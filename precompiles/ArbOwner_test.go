@@ -7,14 +7,18 @@ import (
 	"bytes"
 	"encoding/json"
 	"math/big"
+	"slices"
 	"testing"
 
 	"github.com/holiman/uint256"
 
+	"github.com/ethereum/go-ethereum/arbitrum/multigas"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/tracing"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
 
@@ -23,6 +27,7 @@ import (
 	"github.com/offchainlabs/nitro/arbos/l1pricing"
 	"github.com/offchainlabs/nitro/arbos/util"
 	"github.com/offchainlabs/nitro/cmd/chaininfo"
+	"github.com/offchainlabs/nitro/solgen/go/precompilesgen"
 	"github.com/offchainlabs/nitro/util/testhelpers"
 )
 
@@ -283,3 +288,119 @@ func TestArbInfraFeeAccount(t *testing.T) {
 		t.Fatal()
 	}
 }
+
+func TestArbOwnerResourceConstraints(t *testing.T) {
+	evm := newMockEVMForTesting()
+	caller := common.BytesToAddress(crypto.Keccak256([]byte{})[:20])
+	callCtx := testContext(caller, evm)
+	prec := &ArbOwner{}
+	precPublic := &ArbOwnerPublic{}
+	gasInfo := &ArbGasInfo{}
+
+	computation := uint8(multigas.ResourceKindComputation)
+	storageGrowth := uint8(multigas.ResourceKindStorageGrowth)
+	Require(t, prec.SetResourceConstraint(callCtx, evm, storageGrowth, 3600, 1_000*3600))
+	Require(t, prec.SetResourceConstraint(callCtx, evm, computation, 60, 7_000_000*60))
+	Require(t, prec.SetResourceConstraint(callCtx, evm, computation, 12, 10_000_000*12))
+	if err := prec.SetResourceConstraint(callCtx, evm, uint8(multigas.NumResourceKind), 12, 1_000); err == nil {
+		Fail(t, "expected unknown resource to be rejected")
+	}
+	if err := prec.SetResourceConstraint(callCtx, evm, computation, 0, 1_000); err == nil {
+		Fail(t, "expected zero period to be rejected")
+	}
+
+	resources, periods, targets, backlogs, err := precPublic.GetResourceConstraints(callCtx, evm)
+	Require(t, err)
+	expectedResources := []uint8{computation, computation, storageGrowth}
+	expectedPeriods := []uint32{12, 60, 3600}
+	expectedTargets := []uint64{10_000_000 * 12, 7_000_000 * 60, 1_000 * 3600}
+	if !slices.Equal(resources, expectedResources) || !slices.Equal(periods, expectedPeriods) || !slices.Equal(targets, expectedTargets) {
+		Fail(t, "unexpected constraints", resources, periods, targets)
+	}
+	if !slices.Equal(backlogs, []uint64{0, 0, 0}) {
+		Fail(t, "unexpected backlogs", backlogs)
+	}
+
+	Require(t, prec.ClearResourceConstraint(callCtx, evm, computation, 60))
+	Require(t, callCtx.State.L2PricingState().AddToResourceBacklogs(multigas.ComputationGas(1_000), 0))
+	resources, periods, _, backlogs, err = gasInfo.GetResourceConstraints(callCtx, evm)
+	Require(t, err)
+	if !slices.Equal(resources, []uint8{computation, storageGrowth}) || !slices.Equal(periods, []uint32{12, 3600}) {
+		Fail(t, "unexpected constraints after clearing one", resources, periods)
+	}
+	if !slices.Equal(backlogs, []uint64{1_000, 0}) {
+		Fail(t, "unexpected backlogs", backlogs)
+	}
+}
+
+func TestArbOwnerFeatures(t *testing.T) {
+	arbOwnerAbi, err := precompilesgen.ArbOwnerMetaData.GetAbi()
+	Require(t, err)
+	arbOwner, err := precompilesgen.NewArbOwner(common.Address{}, nil)
+	Require(t, err)
+	precPublic := &ArbOwnerPublic{}
+	name := "calldata-price-increase"
+
+	// the zero address is an owner by default
+	setFeature := func(evm *vm.EVM, enabled bool) error {
+		input, err := arbOwnerAbi.Pack("setFeature", name, enabled)
+		Require(t, err)
+		_, _, err = Precompiles()[types.ArbOwnerAddress].Call(input, types.ArbOwnerAddress, types.ArbOwnerAddress, common.Address{}, common.Big0, false, ^uint64(0), evm)
+		return err
+	}
+	featureSetEvents := func(evm *vm.EVM) []*precompilesgen.ArbOwnerFeatureSet {
+		var events []*precompilesgen.ArbOwnerFeatureSet
+		//nolint:errcheck
+		for _, log := range evm.StateDB.(*state.StateDB).Logs() {
+			if log.Address != types.ArbOwnerAddress || log.Topics[0] != arbOwnerAbi.Events["FeatureSet"].ID {
+				continue
+			}
+			event, err := arbOwner.ParseFeatureSet(*log)
+			Require(t, err)
+			events = append(events, event)
+		}
+		return events
+	}
+
+	// setFeature isn't available before ArbOS 60
+	if err := setFeature(newMockEVMForTesting(), true); err == nil {
+		Fail(t, "expected setFeature to revert before ArbOS 60")
+	}
+
+	chainConfig := chaininfo.ArbitrumDevTestChainConfig()
+	chainConfig.ArbitrumChainParams.InitialArbOSVersion = params.ArbosVersion_60
+	evm := newMockEVMForTestingWithConfigs(chainConfig, chainConfig)
+	callCtx := testContext(common.Address{}, evm)
+	if err := setFeature(evm, true); err != nil {
+		Fail(t, err)
+	}
+	names, _, minVersions, enabled, err := precPublic.GetFeatures(callCtx, evm)
+	Require(t, err)
+	index := slices.Index(names, name)
+	if index < 0 {
+		Fail(t, "feature isn't listed", names)
+	}
+	if minVersions[index] != params.ArbosVersion_40 || !enabled[index] {
+		Fail(t, "unexpected feature", minVersions[index], enabled[index])
+	}
+
+	// the event is only emitted when the feature changes
+	if err := setFeature(evm, true); err != nil {
+		Fail(t, err)
+	}
+	if err := setFeature(evm, false); err != nil {
+		Fail(t, err)
+	}
+	events := featureSetEvents(evm)
+	if len(events) != 2 {
+		Fail(t, "unexpected number of FeatureSet events", len(events))
+	}
+	if events[0].Name != name || !events[0].Enabled || events[1].Name != name || events[1].Enabled {
+		Fail(t, "unexpected FeatureSet events", events[0], events[1])
+	}
+	_, _, _, enabled, err = precPublic.GetFeatures(callCtx, evm)
+	Require(t, err)
+	if enabled[index] {
+		Fail(t, "feature wasn't turned off")
+	}
+}
@@ -193,6 +193,7 @@ func TestPrecompilesPerArbosVersion(t *testing.T) {
 		params.ArbosVersion_40: 3,
 		params.ArbosVersion_41: 10,
 		params.ArbosVersion_50: 5,
+		params.ArbosVersion_60: 7,
 	}
 
 	precompiles := Precompiles()
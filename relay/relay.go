@@ -10,10 +10,13 @@ import (
 
 	"github.com/spf13/pflag"
 
+	"github.com/ethereum/go-ethereum/log"
+
 	"github.com/offchainlabs/nitro/arbutil"
 	"github.com/offchainlabs/nitro/broadcastclient"
 	"github.com/offchainlabs/nitro/broadcastclients"
 	"github.com/offchainlabs/nitro/broadcaster"
+	"github.com/offchainlabs/nitro/broadcaster/archive"
 	"github.com/offchainlabs/nitro/broadcaster/message"
 	"github.com/offchainlabs/nitro/cmd/genericconf"
 	"github.com/offchainlabs/nitro/cmd/util/confighelpers"
@@ -28,6 +31,10 @@ type Relay struct {
 	broadcaster                 *broadcaster.Broadcaster
 	confirmedSequenceNumberChan chan arbutil.MessageIndex
 	messageChan                 chan message.BroadcastFeedMessage
+	archive                     *archive.Directory
+	archiveFileMessages         uint64
+	archiveChan                 chan *message.BroadcastFeedMessage
+	archiveBuffer               []*message.BroadcastFeedMessage
 }
 
 type MessageQueue struct {
@@ -67,12 +74,52 @@ func NewRelay(config *Config, feedErrChan chan error) (*Relay, error) {
 	dataSignerErr := func([]byte) ([]byte, error) {
 		return nil, errors.New("relay attempted to sign feed message")
 	}
-	return &Relay{
+	relay := &Relay{
 		broadcaster:                 broadcaster.NewBroadcaster(func() *wsbroadcastserver.BroadcasterConfig { return &config.Node.Feed.Output }, config.Chain.ID, feedErrChan, dataSignerErr),
 		broadcastClients:            clients,
 		confirmedSequenceNumberChan: confirmedSequenceNumberListener,
 		messageChan:                 q.queue,
-	}, nil
+	}
+	if config.Archive.Directory != "" {
+		relay.archive, err = archive.OpenDirectory(config.Archive.Directory)
+		if err != nil {
+			return nil, err
+		}
+		relay.archiveFileMessages = config.Archive.FileMessages
+		relay.archiveChan = make(chan *message.BroadcastFeedMessage, config.Queue)
+		relay.broadcaster.SetHistory(relay.archive)
+	}
+	return relay, nil
+}
+
+// archiveMessage buffers a relayed message until there are enough consecutive
+// messages to write an archive file.
+func (r *Relay) archiveMessage(msg *message.BroadcastFeedMessage) {
+	if len(r.archiveBuffer) > 0 {
+		first := r.archiveBuffer[0].SequenceNumber
+		next := first + arbutil.MessageIndex(len(r.archiveBuffer))
+		if msg.SequenceNumber < next {
+			// Reorg, the following messages are replaced
+			r.archiveBuffer = r.archiveBuffer[:max(msg.SequenceNumber, first)-first]
+		} else if msg.SequenceNumber > next {
+			log.Warn("gap in relayed messages, the missing messages won't be archived", "expected", next, "got", msg.SequenceNumber)
+			r.flushArchive()
+		}
+	}
+	r.archiveBuffer = append(r.archiveBuffer, msg)
+	if uint64(len(r.archiveBuffer)) >= r.archiveFileMessages {
+		r.flushArchive()
+	}
+}
+
+func (r *Relay) flushArchive() {
+	if len(r.archiveBuffer) == 0 {
+		return
+	}
+	if err := r.archive.Append(r.archiveBuffer); err != nil {
+		log.Error("error archiving relayed messages", "first", r.archiveBuffer[0].SequenceNumber, "count", len(r.archiveBuffer), "err", err)
+	}
+	r.archiveBuffer = nil
 }
 
 func (r *Relay) Start(ctx context.Context) error {
@@ -96,12 +143,42 @@ func (r *Relay) Start(ctx context.Context) error {
 			case msg := <-r.messageChan:
 				sharedmetrics.UpdateSequenceNumberGauge(msg.SequenceNumber)
 				r.broadcaster.BroadcastSingleFeedMessage(&msg)
+				if r.archive != nil {
+					// Archive files are compressed and synced to disk, which mustn't hold up the broadcast
+					select {
+					case r.archiveChan <- &msg:
+					default:
+						log.Warn("archive queue full, relayed message won't be archived", "sequenceNumber", msg.SequenceNumber)
+					}
+				}
 			case cs := <-r.confirmedSequenceNumberChan:
 				r.broadcaster.Confirm(cs)
 			}
 		}
 	})
 
+	if r.archive != nil {
+		r.LaunchThread(func(ctx context.Context) {
+			for {
+				select {
+				case <-ctx.Done():
+					// Archive what was already queued before stopping
+					for {
+						select {
+						case msg := <-r.archiveChan:
+							r.archiveMessage(msg)
+						default:
+							r.flushArchive()
+							return
+						}
+					}
+				case msg := <-r.archiveChan:
+					r.archiveMessage(msg)
+				}
+			}
+		})
+	}
+
 	return nil
 }
 
@@ -126,6 +203,7 @@ type Config struct {
 	PprofCfg      genericconf.PProf               `koanf:"pprof-cfg"`
 	Node          NodeConfig                      `koanf:"node"`
 	Queue         int                             `koanf:"queue"`
+	Archive       archive.Config                  `koanf:"archive"`
 }
 
 var ConfigDefault = Config{
@@ -139,6 +217,7 @@ var ConfigDefault = Config{
 	PprofCfg:      genericconf.PProfDefault,
 	Node:          NodeConfigDefault,
 	Queue:         1024,
+	Archive:       archive.DefaultConfig,
 }
 
 func ConfigAddOptions(f *pflag.FlagSet) {
@@ -152,6 +231,7 @@ func ConfigAddOptions(f *pflag.FlagSet) {
 	genericconf.PProfAddOptions("pprof-cfg", f)
 	NodeConfigAddOptions("node", f)
 	f.Int("queue", ConfigDefault.Queue, "queue for incoming messages from sequencer")
+	archive.ConfigAddOptions("archive", f)
 }
 
 type NodeConfig struct {
@@ -192,6 +272,9 @@ func ParseRelay(_ context.Context, args []string) (*Config, error) {
 	if err := confighelpers.EndCommonParse(k, &relayConfig); err != nil {
 		return nil, err
 	}
+	if err := relayConfig.Archive.Validate(); err != nil {
+		return nil, err
+	}
 
 	if relayConfig.Conf.Dump {
 		err = confighelpers.DumpConfig(k, map[string]interface{}{})
@@ -0,0 +1,464 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+// Package retryableredeemer watches for retryables whose auto-redeem failed
+// and redeems them through ArbRetryableTx.
+package retryableredeemer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/offchainlabs/nitro/cmd/genericconf"
+	"github.com/offchainlabs/nitro/solgen/go/precompilesgen"
+	"github.com/offchainlabs/nitro/util/stopwaiter"
+)
+
+var (
+	failedAutoRedeemsCounter = metrics.NewRegisteredCounter("arb/retryableredeemer/autoredeems/failed", nil)
+	redeemedCounter          = metrics.NewRegisteredCounter("arb/retryableredeemer/redeemed", nil)
+	redeemFailuresCounter    = metrics.NewRegisteredCounter("arb/retryableredeemer/redeems/failed", nil)
+	gasSpentGauge            = metrics.NewRegisteredGauge("arb/retryableredeemer/gas/spent", nil)
+)
+
+type Config struct {
+	Wallet               genericconf.WalletConfig `koanf:"wallet"`
+	ArbitrumNodeEndpoint string                   `koanf:"arbitrum-node-endpoint"`
+	FromBlock            uint64                   `koanf:"from-block"`
+	PollInterval         time.Duration            `koanf:"poll-interval"`
+	MaxBlocksPerPoll     uint64                   `koanf:"max-blocks-per-poll"`
+	AllowList            []string                 `koanf:"allow-list"`
+	DenyList             []string                 `koanf:"deny-list"`
+	MaxRedeemGas         uint64                   `koanf:"max-redeem-gas"`
+	MaxGasBudget         uint64                   `koanf:"max-gas-budget"`
+	MaxRedeemAttempts    uint64                   `koanf:"max-redeem-attempts"`
+	ReceiptTimeout       time.Duration            `koanf:"receipt-timeout"`
+
+	allowed map[common.Address]bool
+	denied  map[common.Address]bool
+}
+
+var DefaultConfig = Config{
+	ArbitrumNodeEndpoint: "http://localhost:8547",
+	PollInterval:         time.Second,
+	MaxBlocksPerPoll:     1000,
+	MaxRedeemGas:         10_000_000,
+	MaxRedeemAttempts:    3,
+	ReceiptTimeout:       time.Minute,
+}
+
+func ConfigAddOptions(f *pflag.FlagSet) {
+	genericconf.WalletConfigAddOptions("wallet", f, "wallet")
+	f.String("arbitrum-node-endpoint", DefaultConfig.ArbitrumNodeEndpoint, "arbitrum node RPC endpoint")
+	f.Uint64("from-block", DefaultConfig.FromBlock, "block to start looking for failed auto-redeems from, 0 starts from the latest block")
+	f.Duration("poll-interval", DefaultConfig.PollInterval, "how often to look for new failed auto-redeems")
+	f.Uint64("max-blocks-per-poll", DefaultConfig.MaxBlocksPerPoll, "maximum number of blocks whose logs are fetched at once")
+	f.StringSlice("allow-list", DefaultConfig.AllowList, "if set, only redeem retryables sent from or to these addresses")
+	f.StringSlice("deny-list", DefaultConfig.DenyList, "never redeem retryables sent from or to these addresses")
+	f.Uint64("max-redeem-gas", DefaultConfig.MaxRedeemGas, "retryables needing more gas than this to be redeemed are skipped")
+	f.Uint64("max-gas-budget", DefaultConfig.MaxGasBudget, "total gas the redeemer may spend, retryables which would exceed it are given up on, 0 is unlimited")
+	f.Uint64("max-redeem-attempts", DefaultConfig.MaxRedeemAttempts, "how many times a retryable is redeemed before giving up on it")
+	f.Duration("receipt-timeout", DefaultConfig.ReceiptTimeout, "how long to wait for a redeem transaction to be included before sending another one")
+}
+
+func parseAddressList(name string, list []string) (map[common.Address]bool, error) {
+	addresses := make(map[common.Address]bool, len(list))
+	for _, address := range list {
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid address %q in %s", address, name)
+		}
+		addresses[common.HexToAddress(address)] = true
+	}
+	return addresses, nil
+}
+
+func (c *Config) Validate() error {
+	if c.PollInterval <= 0 {
+		return errors.New("poll-interval must be positive")
+	}
+	if c.MaxBlocksPerPoll == 0 {
+		return errors.New("max-blocks-per-poll must be positive")
+	}
+	if c.MaxRedeemAttempts == 0 {
+		return errors.New("max-redeem-attempts must be positive")
+	}
+	var err error
+	if c.allowed, err = parseAddressList("allow-list", c.AllowList); err != nil {
+		return err
+	}
+	if c.denied, err = parseAddressList("deny-list", c.DenyList); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ShouldRedeem checks a retryable against the allow and deny lists. The
+// sender is the one seen on L2, so it's aliased if the retryable was created
+// by an L1 contract.
+func (c *Config) ShouldRedeem(from common.Address, to *common.Address) bool {
+	if c.denied[from] || (to != nil && c.denied[*to]) {
+		return false
+	}
+	if len(c.allowed) == 0 {
+		return true
+	}
+	return c.allowed[from] || (to != nil && c.allowed[*to])
+}
+
+type ticket struct {
+	id       common.Hash
+	attempts uint64
+	// the redeem waiting to be included, if any
+	redeemTx *types.Transaction
+	sentAt   time.Time
+}
+
+// nonceManager hands out consecutive nonces, so that the redeems of several
+// retryables can be pending at once without querying the node for each of
+// them. It resyncs with the node's pending nonce after any failure.
+type nonceManager struct {
+	client  *ethclient.Client
+	account common.Address
+	nonce   *uint64
+}
+
+func (m *nonceManager) next(ctx context.Context) (uint64, error) {
+	if m.nonce == nil {
+		nonce, err := m.client.PendingNonceAt(ctx, m.account)
+		if err != nil {
+			return 0, err
+		}
+		m.nonce = &nonce
+	}
+	return *m.nonce, nil
+}
+
+func (m *nonceManager) used() {
+	*m.nonce++
+}
+
+func (m *nonceManager) reset() {
+	m.nonce = nil
+}
+
+// RetryableRedeemer tracks the retryables created through the delayed inbox,
+// and redeems those whose auto-redeem failed or wasn't scheduled, as happens
+// when the submission didn't provide enough gas for it. Tracked retryables are
+// only kept in memory, after a restart FromBlock has to be set to find them again.
+type RetryableRedeemer struct {
+	stopwaiter.StopWaiter
+	config            *Config
+	client            *ethclient.Client
+	txOpts            *bind.TransactOpts
+	arbRetryableTx    *precompilesgen.ArbRetryableTx
+	ticketCreatedID   common.Hash
+	redeemScheduledID common.Hash
+	noTicketSelector  []byte
+	nonces            nonceManager
+
+	nextBlock uint64
+	tickets   []*ticket
+	gasSpent  atomic.Uint64
+	// the gas limit of the redeems waiting to be included, counted against the budget
+	gasPending uint64
+}
+
+func NewRetryableRedeemer(client *ethclient.Client, txOpts *bind.TransactOpts, config *Config) (*RetryableRedeemer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	arbRetryableTx, err := precompilesgen.NewArbRetryableTx(types.ArbRetryableTxAddress, client)
+	if err != nil {
+		return nil, err
+	}
+	retryableAbi, err := precompilesgen.ArbRetryableTxMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &RetryableRedeemer{
+		config:            config,
+		client:            client,
+		txOpts:            txOpts,
+		arbRetryableTx:    arbRetryableTx,
+		ticketCreatedID:   retryableAbi.Events["TicketCreated"].ID,
+		redeemScheduledID: retryableAbi.Events["RedeemScheduled"].ID,
+		noTicketSelector:  retryableAbi.Errors["NoTicketWithID"].ID[:4],
+		nonces: nonceManager{
+			client:  client,
+			account: txOpts.From,
+		},
+		nextBlock: config.FromBlock,
+	}, nil
+}
+
+func (r *RetryableRedeemer) Start(ctxIn context.Context) error {
+	if r.nextBlock == 0 {
+		head, err := r.client.BlockNumber(ctxIn)
+		if err != nil {
+			return err
+		}
+		r.nextBlock = head + 1
+	}
+	r.StopWaiter.Start(ctxIn, r)
+	r.CallIteratively(func(ctx context.Context) time.Duration {
+		if err := r.update(ctx); err != nil {
+			log.Warn("Error updating retryable redeemer", "err", err)
+		}
+		return r.config.PollInterval
+	})
+	return nil
+}
+
+// GasSpent returns the gas used by the redeemer's transactions so far.
+func (r *RetryableRedeemer) GasSpent() uint64 {
+	return r.gasSpent.Load()
+}
+
+func (r *RetryableRedeemer) update(ctx context.Context) error {
+	if err := r.findFailedAutoRedeems(ctx); err != nil {
+		return err
+	}
+	remaining := r.tickets[:0]
+	for _, ticket := range r.tickets {
+		var done bool
+		var err error
+		if ticket.redeemTx != nil {
+			done, err = r.checkRedeem(ctx, ticket)
+		} else {
+			done, err = r.redeem(ctx, ticket)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			log.Warn("Error redeeming retryable", "ticketId", ticket.id, "err", err)
+		}
+		if !done {
+			remaining = append(remaining, ticket)
+		}
+	}
+	r.tickets = remaining
+	return nil
+}
+
+func (r *RetryableRedeemer) findFailedAutoRedeems(ctx context.Context) error {
+	head, err := r.client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if r.nextBlock > head {
+		return nil
+	}
+	toBlock := min(head, r.nextBlock+r.config.MaxBlocksPerPoll-1)
+	logs, err := r.client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(r.nextBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: []common.Address{types.ArbRetryableTxAddress},
+		Topics:    [][]common.Hash{{r.ticketCreatedID, r.redeemScheduledID}},
+	})
+	if err != nil {
+		return err
+	}
+	// the tickets are only tracked once the whole range was processed, so that
+	// a failure doesn't make the next poll track them a second time
+	var found []*ticket
+	// the auto-redeem is scheduled by the submission, whose hash is the ticket id
+	autoRedeems := make(map[common.Hash]common.Hash)
+	for _, txLog := range logs {
+		if txLog.Topics[0] != r.redeemScheduledID {
+			continue
+		}
+		event, err := r.arbRetryableTx.ParseRedeemScheduled(txLog)
+		if err != nil {
+			return err
+		}
+		if ticketId := common.Hash(event.TicketId); txLog.TxHash == ticketId {
+			autoRedeems[ticketId] = event.RetryTxHash
+		}
+	}
+	for _, txLog := range logs {
+		if txLog.Topics[0] != r.ticketCreatedID {
+			continue
+		}
+		event, err := r.arbRetryableTx.ParseTicketCreated(txLog)
+		if err != nil {
+			return err
+		}
+		ticketId := common.Hash(event.TicketId)
+		if retryTxHash, ok := autoRedeems[ticketId]; ok {
+			receipt, err := r.client.TransactionReceipt(ctx, retryTxHash)
+			if err != nil {
+				return fmt.Errorf("failed to get receipt of auto-redeem %v: %w", retryTxHash, err)
+			}
+			if receipt.Status == types.ReceiptStatusSuccessful {
+				continue
+			}
+		}
+		failedAutoRedeemsCounter.Inc(1)
+		submission, _, err := r.client.TransactionByHash(ctx, ticketId)
+		if err != nil {
+			return fmt.Errorf("failed to get submission of retryable %v: %w", ticketId, err)
+		}
+		inner, ok := submission.GetInner().(*types.ArbitrumSubmitRetryableTx)
+		if !ok {
+			return fmt.Errorf("transaction %v is not a retryable submission", ticketId)
+		}
+		if !r.config.ShouldRedeem(inner.From, inner.RetryTo) {
+			log.Info("Ignoring failed auto-redeem of filtered retryable", "ticketId", ticketId, "from", inner.From, "to", inner.RetryTo)
+			continue
+		}
+		_, scheduled := autoRedeems[ticketId]
+		log.Info("Found failed auto-redeem", "ticketId", ticketId, "block", txLog.BlockNumber, "scheduled", scheduled)
+		found = append(found, &ticket{id: ticketId})
+	}
+	r.tickets = append(r.tickets, found...)
+	r.nextBlock = toBlock + 1
+	return nil
+}
+
+// redeem sends a redeem of the retryable, returning true once it no longer
+// needs to be tracked. The receipt is checked by the following updates, so
+// that the other retryables don't wait for the redeem to be included.
+func (r *RetryableRedeemer) redeem(ctx context.Context, ticket *ticket) (bool, error) {
+	if r.config.MaxGasBudget != 0 && r.gasSpent.Load()+r.gasPending >= r.config.MaxGasBudget {
+		log.Warn("Gas budget exhausted, giving up on retryable", "ticketId", ticket.id, "spent", r.gasSpent.Load(), "pending", r.gasPending, "budget", r.config.MaxGasBudget)
+		return true, nil
+	}
+	// the retryable might have been redeemed by someone else, or expired
+	if _, err := r.arbRetryableTx.GetTimeout(&bind.CallOpts{Context: ctx}, ticket.id); err != nil {
+		if r.isNoTicketError(err) {
+			log.Info("Retryable no longer redeemable", "ticketId", ticket.id)
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get timeout of retryable: %w", err)
+	}
+	nonce, err := r.nonces.next(ctx)
+	if err != nil {
+		return false, err
+	}
+	opts := *r.txOpts
+	opts.Context = ctx
+	opts.Nonce = new(big.Int).SetUint64(nonce)
+	opts.NoSend = true
+	tx, err := r.arbRetryableTx.Redeem(&opts, ticket.id)
+	if err != nil {
+		// the gas estimation runs the retry, so this is how it fails before we spend any gas
+		return r.failed(ticket), err
+	}
+	if tx.Gas() > r.config.MaxRedeemGas {
+		log.Warn("Skipping retryable needing too much gas to redeem", "ticketId", ticket.id, "gas", tx.Gas(), "max", r.config.MaxRedeemGas)
+		return true, nil
+	}
+	if r.config.MaxGasBudget != 0 && r.gasSpent.Load()+r.gasPending+tx.Gas() > r.config.MaxGasBudget {
+		log.Warn("Gas budget exhausted, giving up on retryable", "ticketId", ticket.id, "gas", tx.Gas(), "spent", r.gasSpent.Load(), "pending", r.gasPending, "budget", r.config.MaxGasBudget)
+		return true, nil
+	}
+	if err := r.client.SendTransaction(ctx, tx); err != nil {
+		r.nonces.reset()
+		return false, err
+	}
+	r.nonces.used()
+	ticket.redeemTx = tx
+	ticket.sentAt = time.Now()
+	r.gasPending += tx.Gas()
+	return false, nil
+}
+
+// checkRedeem checks if the pending redeem of the retryable was included,
+// returning true once the retryable no longer needs to be tracked.
+func (r *RetryableRedeemer) checkRedeem(ctx context.Context, ticket *ticket) (bool, error) {
+	tx := ticket.redeemTx
+	receipt, err := r.client.TransactionReceipt(ctx, tx.Hash())
+	if errors.Is(err, ethereum.NotFound) {
+		if time.Since(ticket.sentAt) < r.config.ReceiptTimeout {
+			return false, nil
+		}
+		// the nonce may have been skipped, so the redeem is sent again with a fresh one
+		r.nonces.reset()
+		r.gasPending -= tx.Gas()
+		ticket.redeemTx = nil
+		return false, fmt.Errorf("redeem %v wasn't included after %v", tx.Hash(), r.config.ReceiptTimeout)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get receipt of redeem %v: %w", tx.Hash(), err)
+	}
+	succeeded, err := r.retrySucceeded(ctx, receipt, ticket.id)
+	if err != nil {
+		return false, err
+	}
+	r.gasPending -= tx.Gas()
+	ticket.redeemTx = nil
+	gasSpent := r.gasSpent.Add(receipt.GasUsed)
+	gasSpentGauge.Update(int64(gasSpent)) // #nosec G115
+	if succeeded {
+		redeemedCounter.Inc(1)
+		log.Info("Redeemed retryable", "ticketId", ticket.id, "redeemTx", tx.Hash(), "gasUsed", receipt.GasUsed)
+		return true, nil
+	}
+	log.Warn("Redeem of retryable failed", "ticketId", ticket.id, "redeemTx", tx.Hash())
+	return r.failed(ticket), nil
+}
+
+// failed counts a failed attempt, returning true once the redeemer gives up.
+func (r *RetryableRedeemer) failed(ticket *ticket) bool {
+	redeemFailuresCounter.Inc(1)
+	ticket.attempts++
+	if ticket.attempts >= r.config.MaxRedeemAttempts {
+		log.Error("Giving up on retryable", "ticketId", ticket.id, "attempts", ticket.attempts)
+		return true
+	}
+	return false
+}
+
+// isNoTicketError checks if a call reverted because the retryable doesn't exist
+func (r *RetryableRedeemer) isNoTicketError(err error) bool {
+	var errWithData rpc.DataError
+	if !errors.As(err, &errWithData) {
+		return false
+	}
+	dataString, ok := errWithData.ErrorData().(string)
+	if !ok {
+		return false
+	}
+	return bytes.HasPrefix(common.FromHex(dataString), r.noTicketSelector)
+}
+
+func (r *RetryableRedeemer) retrySucceeded(ctx context.Context, receipt *types.Receipt, ticketId common.Hash) (bool, error) {
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return false, nil
+	}
+	for _, txLog := range receipt.Logs {
+		if txLog.Address != types.ArbRetryableTxAddress || len(txLog.Topics) == 0 || txLog.Topics[0] != r.redeemScheduledID {
+			continue
+		}
+		event, err := r.arbRetryableTx.ParseRedeemScheduled(*txLog)
+		if err != nil {
+			return false, err
+		}
+		if event.TicketId != ticketId {
+			continue
+		}
+		retryReceipt, err := r.client.TransactionReceipt(ctx, event.RetryTxHash)
+		if err != nil {
+			return false, err
+		}
+		return retryReceipt.Status == types.ReceiptStatusSuccessful, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package retryableredeemer
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestShouldRedeem(t *testing.T) {
+	sender := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	destination := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	other := common.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	config := DefaultConfig
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if !config.ShouldRedeem(sender, &destination) || !config.ShouldRedeem(sender, nil) {
+		t.Fatal("retryables should all be redeemed without allow or deny lists")
+	}
+
+	config.AllowList = []string{destination.Hex()}
+	config.DenyList = []string{other.Hex()}
+	if err := config.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if !config.ShouldRedeem(sender, &destination) {
+		t.Fatal("retryable to an allowed address should be redeemed")
+	}
+	if config.ShouldRedeem(sender, nil) || config.ShouldRedeem(sender, &sender) {
+		t.Fatal("retryable not involving an allowed address shouldn't be redeemed")
+	}
+	if config.ShouldRedeem(other, &destination) {
+		t.Fatal("retryable from a denied address shouldn't be redeemed")
+	}
+
+	config.AllowList = []string{"not an address"}
+	if err := config.Validate(); err == nil {
+		t.Fatal("expected invalid allow list to be rejected")
+	}
+}
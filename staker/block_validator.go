@@ -31,6 +31,7 @@ import (
 	"github.com/offchainlabs/nitro/util/rpcclient"
 	"github.com/offchainlabs/nitro/util/stopwaiter"
 	"github.com/offchainlabs/nitro/validator"
+	"github.com/offchainlabs/nitro/validator/client"
 	"github.com/offchainlabs/nitro/validator/client/redis"
 	"github.com/offchainlabs/nitro/validator/inputs"
 	"github.com/offchainlabs/nitro/validator/retry_wrapper"
@@ -114,6 +115,7 @@ type BlockValidatorConfig struct {
 	RedisValidationClientConfig       redis.ValidationClientConfig  `koanf:"redis-validation-client-config"`
 	ValidationServer                  rpcclient.ClientConfig        `koanf:"validation-server" reload:"hot"`
 	ValidationServerConfigs           []rpcclient.ClientConfig      `koanf:"validation-server-configs"`
+	ValidationLoadBalancer            client.LoadBalancerConfig     `koanf:"validation-load-balancer"`
 	ValidationPoll                    time.Duration                 `koanf:"validation-poll" reload:"hot"`
 	PrerecordedBlocks                 uint64                        `koanf:"prerecorded-blocks" reload:"hot"`
 	RecordingIterLimit                uint64                        `koanf:"recording-iter-limit"`
@@ -173,6 +175,9 @@ func (c *BlockValidatorConfig) Validate() error {
 			}
 		}
 	}
+	if err := c.ValidationLoadBalancer.Validate(len(c.ValidationServerConfigs)); err != nil {
+		return err
+	}
 	if c.Dangerous.Revalidation.EndBlock > 0 && c.Dangerous.Revalidation.EndBlock < c.Dangerous.Revalidation.StartBlock {
 		return fmt.Errorf("revalidation end block %d is before start block %d", c.Dangerous.Revalidation.EndBlock, c.Dangerous.Revalidation.StartBlock)
 	}
@@ -197,6 +202,7 @@ func BlockValidatorConfigAddOptions(prefix string, f *pflag.FlagSet) {
 	rpcclient.RPCClientAddOptions(prefix+".validation-server", f, &DefaultBlockValidatorConfig.ValidationServer)
 	redis.ValidationClientConfigAddOptions(prefix+".redis-validation-client-config", f)
 	f.String(prefix+".validation-server-configs-list", DefaultBlockValidatorConfig.ValidationServerConfigsList, "array of execution rpc configs given as a json string. time duration should be supplied in number indicating nanoseconds")
+	client.LoadBalancerConfigAddOptions(prefix+".validation-load-balancer", f)
 	f.Duration(prefix+".validation-poll", DefaultBlockValidatorConfig.ValidationPoll, "poll time to check validations")
 	f.Uint64(prefix+".forward-blocks", DefaultBlockValidatorConfig.ForwardBlocks, "prepare entries for up to that many blocks ahead of validation (stores batch-copy per block)")
 	f.Uint64(prefix+".prerecorded-blocks", DefaultBlockValidatorConfig.PrerecordedBlocks, "record that many blocks ahead of validation (larger footprint)")
@@ -227,6 +233,7 @@ var DefaultBlockValidatorConfig = BlockValidatorConfig{
 	Enable:                            false,
 	ValidationServerConfigsList:       "default",
 	ValidationServer:                  rpcclient.DefaultClientConfig,
+	ValidationLoadBalancer:            client.DefaultLoadBalancerConfig,
 	RedisValidationClientConfig:       redis.DefaultValidationClientConfig,
 	ValidationPoll:                    time.Second,
 	ForwardBlocks:                     128,
@@ -247,6 +254,7 @@ var TestBlockValidatorConfig = BlockValidatorConfig{
 	Enable:                            false,
 	ValidationServer:                  rpcclient.TestClientConfig,
 	ValidationServerConfigs:           []rpcclient.ClientConfig{rpcclient.TestClientConfig},
+	ValidationLoadBalancer:            client.DefaultLoadBalancerConfig,
 	RedisValidationClientConfig:       redis.TestValidationClientConfig,
 	ValidationPoll:                    100 * time.Millisecond,
 	ForwardBlocks:                     128,
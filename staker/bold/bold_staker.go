@@ -117,12 +117,16 @@ type StateProviderConfig struct {
 	CheckBatchFinality bool   `koanf:"check-batch-finality"`
 	// Path to a filesystem directory that will cache machine hashes for BOLD.
 	MachineLeavesCachePath string `koanf:"machine-leaves-cache-path"`
+	// Max size in bytes of the machine hashes cache, evicting the least
+	// recently used hashes beyond it. Zero means no limit.
+	MachineLeavesCacheMaxSize uint64 `koanf:"machine-leaves-cache-max-size"`
 }
 
 var DefaultStateProviderConfig = StateProviderConfig{
-	ValidatorName:          "default-validator",
-	CheckBatchFinality:     true,
-	MachineLeavesCachePath: "machine-hashes-cache",
+	ValidatorName:             "default-validator",
+	CheckBatchFinality:        true,
+	MachineLeavesCachePath:    "machine-hashes-cache",
+	MachineLeavesCacheMaxSize: 0,
 }
 
 var DefaultBoldConfig = BoldConfig{
@@ -180,6 +184,7 @@ func StateProviderConfigAddOptions(prefix string, f *pflag.FlagSet) {
 	f.String(prefix+".validator-name", DefaultStateProviderConfig.ValidatorName, "name identifier for cosmetic purposes")
 	f.Bool(prefix+".check-batch-finality", DefaultStateProviderConfig.CheckBatchFinality, "check batch finality")
 	f.String(prefix+".machine-leaves-cache-path", DefaultStateProviderConfig.MachineLeavesCachePath, "path to machine cache")
+	f.Uint64(prefix+".machine-leaves-cache-max-size", DefaultStateProviderConfig.MachineLeavesCacheMaxSize, "max size in bytes of the machine cache, evicting the least recently used hashes beyond it (0 = unlimited)")
 }
 
 func DelegatedStakingConfigAddOptions(prefix string, f *pflag.FlagSet) {
@@ -56,7 +56,7 @@ func NewBOLDStateProvider(
 	inboxStreamer staker.TransactionStreamerInterface,
 	inboxReader staker.InboxReaderInterface,
 ) (*BOLDStateProvider, error) {
-	historyCache, err := challengecache.New(machineHashesCachePath)
+	historyCache, err := challengecache.New(machineHashesCachePath, challengecache.WithMaxSize(stateProviderConfig.MachineLeavesCacheMaxSize))
 	if err != nil {
 		return nil, err
 	}
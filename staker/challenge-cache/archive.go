@@ -0,0 +1,271 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package challengecache
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var ErrInvalidArchive = errors.New("invalid challenge cache archive")
+
+// VerifyResult summarizes a verification pass over the cache.
+type VerifyResult struct {
+	// Number of hashes files matching their checksum.
+	Verified int
+	// Number of hashes files written before checksums were, which could only
+	// be checked to hold whole hashes.
+	Unchecked int
+	// Paths of the hashes files which are truncated or don't match their
+	// checksum.
+	Corrupted []string
+}
+
+// Verify recomputes the checksums of all the hashes files in the cache,
+// removing the corrupted ones if repair is set so that they get recomputed
+// the next time they are needed.
+func (c *Cache) Verify(ctx context.Context, repair bool) (*VerifyResult, error) {
+	result := &VerifyResult{}
+	if err := c.walkHashFiles(ctx, func(path string, info os.FileInfo) error {
+		checksum, err := fileChecksum(path)
+		if err != nil {
+			return err
+		}
+		expected, err := os.ReadFile(filepath.Join(filepath.Dir(path), checksumFileName))
+		switch {
+		case info.Size()%common.HashLength != 0:
+		case errors.Is(err, os.ErrNotExist):
+			result.Unchecked++
+			return nil
+		case err != nil:
+			return err
+		case bytes.Equal(expected, checksum):
+			result.Verified++
+			return nil
+		}
+		log.Warn("Corrupted challenge cache entry", "file", path)
+		result.Corrupted = append(result.Corrupted, path)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if repair {
+		for _, path := range result.Corrupted {
+			if err := removeHashesFile(path); err != nil {
+				return nil, err
+			}
+			c.removed(path)
+		}
+	}
+	return result, nil
+}
+
+// Export writes the hashes files in the cache with their checksums as a
+// gzipped tar archive, for another validator to Import, e.g. to warm up the
+// cache of a standby validator before a challenge.
+func (c *Cache) Export(ctx context.Context, w io.Writer) error {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	numExported := 0
+	if err := c.walkHashFiles(ctx, func(path string, info os.FileInfo) error {
+		relPath, err := filepath.Rel(c.baseDir, path)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				log.Error("Could not close file after reading", "err", err, "file", path)
+			}
+		}()
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:    filepath.ToSlash(relPath),
+			Mode:    0644,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		}); err != nil {
+			return err
+		}
+		hasher := sha256.New()
+		if _, err := io.Copy(tarWriter, io.TeeReader(f, hasher)); err != nil {
+			return err
+		}
+		// Export the stored checksum when there's one, so that the importer
+		// rejects files which got corrupted here.
+		checksum, err := os.ReadFile(filepath.Join(filepath.Dir(path), checksumFileName))
+		if errors.Is(err, os.ErrNotExist) {
+			checksum = hasher.Sum(nil)
+		} else if err != nil {
+			return err
+		}
+		if err := tarWriter.WriteHeader(&tar.Header{
+			Name:    filepath.ToSlash(filepath.Join(filepath.Dir(relPath), checksumFileName)),
+			Mode:    0644,
+			Size:    int64(len(checksum)),
+			ModTime: info.ModTime(),
+		}); err != nil {
+			return err
+		}
+		if _, err := tarWriter.Write(checksum); err != nil {
+			return err
+		}
+		numExported++
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+	log.Info("Exported challenge cache", "numFiles", numExported)
+	return nil
+}
+
+// Import adds the hashes files from an archive written by Export to the
+// cache, keeping the local files which already exist. Every file is checked
+// against its checksum before being added, and the whole import fails on the
+// first mismatch, keeping the files imported until then.
+func (c *Cache) Import(ctx context.Context, r io.Reader) (int, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	tarReader := tar.NewReader(gzipReader)
+	numImported := 0
+	for {
+		if ctx.Err() != nil {
+			return numImported, ctx.Err()
+		}
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return numImported, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+		}
+		fName, err := c.archivePath(header, hashesFileName)
+		if err != nil {
+			return numImported, err
+		}
+		tempName, checksum, err := c.writeTempFile(tarReader)
+		imported := false
+		if err == nil {
+			imported, err = c.importHashesFile(tarReader, fName, tempName, checksum, header.Size)
+		}
+		if removeErr := os.Remove(tempName); removeErr != nil && !os.IsNotExist(removeErr) {
+			log.Warn("Could not remove temporary challenge cache file", "err", removeErr, "file", tempName)
+		}
+		if err != nil {
+			return numImported, err
+		}
+		if imported {
+			numImported++
+		}
+	}
+	log.Info("Imported challenge cache", "numFiles", numImported)
+	return numImported, nil
+}
+
+// Moves a hashes file read from an archive into the cache, after checking it
+// against the checksum which follows it in the archive.
+func (c *Cache) importHashesFile(tarReader *tar.Reader, fName, tempName string, checksum []byte, size int64) (bool, error) {
+	header, err := tarReader.Next()
+	if err != nil {
+		return false, fmt.Errorf("%w: missing checksum for %s: %w", ErrInvalidArchive, fName, err)
+	}
+	checksumName, err := c.archivePath(header, checksumFileName)
+	if err != nil {
+		return false, err
+	}
+	if filepath.Dir(checksumName) != filepath.Dir(fName) {
+		return false, fmt.Errorf("%w: missing checksum for %s", ErrInvalidArchive, fName)
+	}
+	expected, err := io.ReadAll(io.LimitReader(tarReader, sha256.Size+1))
+	if err != nil {
+		return false, err
+	}
+	if size%common.HashLength != 0 || !bytes.Equal(expected, checksum) {
+		return false, fmt.Errorf("%w: %s does not match its checksum", ErrInvalidArchive, fName)
+	}
+	if _, err := os.Stat(fName); err == nil {
+		return false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(fName), os.ModePerm); err != nil {
+		return false, fmt.Errorf("could not make file directory %s: %w", fName, err)
+	}
+	if err := os.Rename(tempName, fName); err != nil {
+		return false, err
+	}
+	if err := c.writeChecksum(fName, checksum); err != nil {
+		return false, err
+	}
+	c.added(fName, uint64(size)) // #nosec G115
+	return true, nil
+}
+
+// Returns the path in the cache of a file from an archive, making sure it
+// can't escape the cache directory.
+func (c *Cache) archivePath(header *tar.Header, expectedName string) (string, error) {
+	name := filepath.FromSlash(header.Name)
+	if header.Typeflag != tar.TypeReg ||
+		filepath.IsAbs(name) ||
+		!filepath.IsLocal(name) ||
+		filepath.Base(name) != expectedName ||
+		!strings.HasPrefix(name, wavmModuleRootPrefix) {
+		return "", fmt.Errorf("%w: unexpected file %s", ErrInvalidArchive, header.Name)
+	}
+	return filepath.Join(c.baseDir, name), nil
+}
+
+func (c *Cache) writeTempFile(r io.Reader) (string, []byte, error) {
+	f, err := os.CreateTemp(c.tempWritesDir, fmt.Sprintf("%s-*", hashesFileName))
+	if err != nil {
+		return "", nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Error("Could not close file after writing", "err", err, "file", f.Name())
+		}
+	}()
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(f, hasher), r); err != nil {
+		return f.Name(), nil, err
+	}
+	return f.Name(), hasher.Sum(nil), nil
+}
+
+func fileChecksum(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Error("Could not close file after reading", "err", err, "file", path)
+		}
+	}()
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return nil, err
+	}
+	return hasher.Sum(nil), nil
+}
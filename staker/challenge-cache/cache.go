@@ -32,13 +32,18 @@ package challengecache
 import (
 	"bufio"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -49,6 +54,8 @@ var (
 	ErrFileAlreadyExists  = errors.New("file already exists")
 	ErrNoHashes           = errors.New("no hashes being written")
 	hashesFileName        = "hashes.bin"
+	checksumFileName      = "hashes.bin.sha256"
+	tempDirPrefix         = "temp"
 	wavmModuleRootPrefix  = "wavm-module-root"
 	rollupBlockHashPrefix = "rollup-block-hash"
 	messageNumberPrefix   = "message-num"
@@ -75,10 +82,32 @@ type Key struct {
 type Cache struct {
 	baseDir       string
 	tempWritesDir string
+	maxSize       uint64
+	// Hashes files in the cache by path, for evicting the least recently used
+	// ones when the cache exceeds its max size.
+	entries   map[string]*cacheEntry
+	size      uint64
+	entriesMu sync.Mutex
+}
+
+type cacheEntry struct {
+	size     uint64
+	lastUsed time.Time
+}
+
+type Opt func(*Cache)
+
+// WithMaxSize limits the total size in bytes of the hashes in the cache,
+// evicting the least recently used ones when it is exceeded. Zero means no
+// limit.
+func WithMaxSize(maxSize uint64) Opt {
+	return func(c *Cache) {
+		c.maxSize = maxSize
+	}
 }
 
 // New cache from a base directory path.
-func New(baseDir string) (*Cache, error) {
+func New(baseDir string, opts ...Opt) (*Cache, error) {
 	if err := os.MkdirAll(baseDir, os.ModePerm); err != nil {
 		return nil, err
 	}
@@ -86,14 +115,29 @@ func New(baseDir string) (*Cache, error) {
 	// Once writing succeeds, we rename in an atomic operation to the correct file name
 	// in the cache directory hierarchy in the `Put` function. All of these temporary writes
 	// will occur in a subdir of the base directory called temp.
-	tempWritesDir, err := os.MkdirTemp(baseDir, "temp")
+	tempWritesDir, err := os.MkdirTemp(baseDir, tempDirPrefix)
 	if err != nil {
 		return nil, err
 	}
-	return &Cache{
+	c := &Cache{
 		baseDir:       baseDir,
 		tempWritesDir: tempWritesDir,
-	}, nil
+		entries:       make(map[string]*cacheEntry),
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	// Files last modification times persist the least recently used order
+	// across restarts.
+	if err := c.walkHashFiles(context.Background(), func(path string, info os.FileInfo) error {
+		c.entries[path] = &cacheEntry{size: uint64(info.Size()), lastUsed: info.ModTime()} // #nosec G115
+		c.size += uint64(info.Size())                                                      // #nosec G115
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	c.evict("")
+	return c, nil
 }
 
 // Get a list of hashes from the cache from index 0 up to a certain index. Hashes are saved as files in the directory
@@ -121,6 +165,7 @@ func (c *Cache) Get(
 			log.Error("Could not close file after reading", "err", err, "file", fName)
 		}
 	}()
+	c.markUsed(fName)
 	return readHashes(f, numToRead)
 }
 
@@ -149,7 +194,8 @@ func (c *Cache) Put(lookup *Key, hashes []common.Hash) error {
 			log.Error("Could not close file after writing", "err", err, "file", fName)
 		}
 	}()
-	if err := writeHashes(f, hashes); err != nil {
+	checksum := sha256.New()
+	if err := writeHashes(io.MultiWriter(f, checksum), hashes); err != nil {
 		return err
 	}
 	if err := os.MkdirAll(filepath.Dir(fName), os.ModePerm); err != nil {
@@ -159,7 +205,165 @@ func (c *Cache) Put(lookup *Key, hashes []common.Hash) error {
 	// into our cache directory. This is an atomic operation.
 	// For more information on this atomic write pattern, see:
 	// https://stackoverflow.com/questions/2333872/how-to-make-file-creation-an-atomic-operation
-	return os.Rename(f.Name() /*old */, fName /* new */)
+	if err := os.Rename(f.Name() /*old */, fName /* new */); err != nil {
+		return err
+	}
+	if err := c.writeChecksum(fName, checksum.Sum(nil)); err != nil {
+		return err
+	}
+	c.added(fName, uint64(len(hashes)*common.HashLength)) // #nosec G115
+	return nil
+}
+
+// Writes the checksum of a hashes file next to it, atomically.
+func (c *Cache) writeChecksum(fName string, checksum []byte) error {
+	f, err := os.CreateTemp(c.tempWritesDir, fmt.Sprintf("%s-*", checksumFileName))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Error("Could not close file after writing", "err", err, "file", f.Name())
+		}
+	}()
+	if _, err := f.Write(checksum); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(filepath.Dir(fName), checksumFileName))
+}
+
+// Records a hashes file as just used.
+func (c *Cache) markUsed(fName string) {
+	now := time.Now()
+	c.entriesMu.Lock()
+	if entry, ok := c.entries[fName]; ok {
+		entry.lastUsed = now
+	}
+	c.entriesMu.Unlock()
+	if c.maxSize > 0 {
+		if err := os.Chtimes(fName, now, now); err != nil {
+			log.Warn("Could not update challenge cache file modification time", "err", err, "file", fName)
+		}
+	}
+}
+
+// Records a hashes file as added to the cache, and evicts others if the cache
+// exceeds its max size as a result.
+func (c *Cache) added(fName string, size uint64) {
+	c.entriesMu.Lock()
+	if entry, ok := c.entries[fName]; ok {
+		c.size -= entry.size
+	}
+	c.entries[fName] = &cacheEntry{size: size, lastUsed: time.Now()}
+	c.size += size
+	c.entriesMu.Unlock()
+	c.evict(fName)
+}
+
+// Records a hashes file as removed from the cache.
+func (c *Cache) removed(fName string) {
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	if entry, ok := c.entries[fName]; ok {
+		c.size -= entry.size
+		delete(c.entries, fName)
+	}
+}
+
+// Records the hashes files in a directory as removed from the cache.
+func (c *Cache) removedDir(dir string) {
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	for path, entry := range c.entries {
+		if strings.HasPrefix(path, dir+string(filepath.Separator)) {
+			c.size -= entry.size
+			delete(c.entries, path)
+		}
+	}
+}
+
+// Evicts the least recently used hashes files until the cache is within its
+// max size, except for the one just added.
+func (c *Cache) evict(keep string) {
+	if c.maxSize == 0 {
+		return
+	}
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	if c.size <= c.maxSize {
+		return
+	}
+	paths := make([]string, 0, len(c.entries))
+	for path := range c.entries {
+		if path != keep {
+			paths = append(paths, path)
+		}
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		return c.entries[paths[i]].lastUsed.Before(c.entries[paths[j]].lastUsed)
+	})
+	numEvicted := 0
+	for _, path := range paths {
+		if c.size <= c.maxSize {
+			break
+		}
+		if err := removeHashesFile(path); err != nil {
+			log.Error("Could not evict challenge cache entry", "err", err, "file", path)
+			continue
+		}
+		c.size -= c.entries[path].size
+		delete(c.entries, path)
+		numEvicted++
+	}
+	log.Info("Evicted least recently used challenge cache entries", "numEvicted", numEvicted, "size", c.size, "maxSize", c.maxSize)
+}
+
+// Size returns the total size in bytes of the hashes in the cache.
+func (c *Cache) Size() uint64 {
+	c.entriesMu.Lock()
+	defer c.entriesMu.Unlock()
+	return c.size
+}
+
+// Removes a hashes file with its checksum, and its directory if it is left
+// empty.
+func removeHashesFile(path string) error {
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Remove(filepath.Join(filepath.Dir(path), checksumFileName)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	// Fails if the directory still has subchallenge directories, which is fine.
+	_ = os.Remove(filepath.Dir(path))
+	return nil
+}
+
+// Calls fn for each hashes file in the cache, skipping the temporary write
+// directories.
+func (c *Cache) walkHashFiles(ctx context.Context, fn func(path string, info os.FileInfo) error) error {
+	return filepath.WalkDir(c.baseDir, func(path string, d os.DirEntry, err error) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if filepath.Dir(path) == filepath.Clean(c.baseDir) && strings.HasPrefix(d.Name(), tempDirPrefix) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != hashesFileName {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		return fn(path, info)
+	})
 }
 
 // Prune all entries in the cache with a message number <= a specified value.
@@ -202,6 +406,7 @@ func (c *Cache) Prune(ctx context.Context, messageNumber uint64) error {
 		if err := os.RemoveAll(path); err != nil {
 			return fmt.Errorf("could not prune directory with path %s: %w", path, err)
 		}
+		c.removedDir(path)
 		numPruned += 1
 	}
 	log.Info("Pruned challenge cache", "numDirsPruned", numPruned, "messageNumber", messageNumPattern)
@@ -440,3 +440,144 @@ func BenchmarkCache_Read_32Mb(b *testing.B) {
 		}
 	}
 }
+
+func TestMaxSize(t *testing.T) {
+	basePath := t.TempDir()
+	hashes := []common.Hash{
+		common.BytesToHash([]byte("foo")),
+		common.BytesToHash([]byte("bar")),
+	}
+	entrySize := uint64(len(hashes) * common.HashLength)
+	cache, err := New(basePath, WithMaxSize(2*entrySize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := make([]*Key, 3)
+	for i := range keys {
+		keys[i] = &Key{
+			WavmModuleRoot: common.BytesToHash([]byte("foo")),
+			MessageHeight:  uint64(i),
+			StepHeights:    []uint64{0},
+		}
+	}
+	for _, key := range keys[:2] {
+		if err = cache.Put(key, hashes); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Reading the first entry makes the second one the least recently used.
+	if _, err = cache.Get(keys[0], 2); err != nil {
+		t.Fatal(err)
+	}
+	if err = cache.Put(keys[2], hashes); err != nil {
+		t.Fatal(err)
+	}
+	if cache.Size() != 2*entrySize {
+		t.Fatalf("Expected cache size %d, got %d", 2*entrySize, cache.Size())
+	}
+	if _, err = cache.Get(keys[1], 2); !errors.Is(err, ErrNotFoundInCache) {
+		t.Fatalf("Expected least recently used entry to be evicted, got %v", err)
+	}
+	for _, key := range []*Key{keys[0], keys[2]} {
+		if _, err = cache.Get(key, 2); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The size of the existing entries is accounted for on startup.
+	cache, err = New(basePath, WithMaxSize(entrySize))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cache.Size() != entrySize {
+		t.Fatalf("Expected cache size %d after restart, got %d", entrySize, cache.Size())
+	}
+}
+
+func TestVerifyExportImport(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cache, err := New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []common.Hash{
+		common.BytesToHash([]byte("foo")),
+		common.BytesToHash([]byte("bar")),
+		common.BytesToHash([]byte("baz")),
+	}
+	keys := []*Key{
+		{WavmModuleRoot: common.BytesToHash([]byte("foo")), MessageHeight: 1},
+		{WavmModuleRoot: common.BytesToHash([]byte("foo")), MessageHeight: 1, StepHeights: []uint64{2}},
+		{WavmModuleRoot: common.BytesToHash([]byte("foo")), MessageHeight: 2},
+	}
+	for _, key := range keys {
+		if err = cache.Put(key, want); err != nil {
+			t.Fatal(err)
+		}
+	}
+	result, err := cache.Verify(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Verified != len(keys) || result.Unchecked != 0 || len(result.Corrupted) != 0 {
+		t.Fatalf("Unexpected verify result %+v", result)
+	}
+
+	var archive bytes.Buffer
+	if err = cache.Export(ctx, &archive); err != nil {
+		t.Fatal(err)
+	}
+	standby, err := New(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	numImported, err := standby.Import(ctx, bytes.NewReader(archive.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if numImported != len(keys) {
+		t.Fatalf("Expected %d files imported, got %d", len(keys), numImported)
+	}
+	for _, key := range keys {
+		got, err := standby.Get(key, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != len(want) || got[2] != want[2] {
+			t.Fatalf("Unexpected imported hashes %v", got)
+		}
+	}
+	if numImported, err = standby.Import(ctx, bytes.NewReader(archive.Bytes())); err != nil || numImported != 0 {
+		t.Fatalf("Expected existing files to be kept, got %d imported (err %v)", numImported, err)
+	}
+
+	// Corrupt a file.
+	fName, err := determineFilePath(cache.baseDir, keys[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(fName, make([]byte, 3*common.HashLength), 0600); err != nil {
+		t.Fatal(err)
+	}
+	archive.Reset()
+	if err = cache.Export(ctx, &archive); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = standby.Import(ctx, &archive); !errors.Is(err, ErrInvalidArchive) {
+		t.Fatalf("Expected corrupted file to be rejected, got %v", err)
+	}
+	result, err = cache.Verify(ctx, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Verified != len(keys)-1 || len(result.Corrupted) != 1 || result.Corrupted[0] != fName {
+		t.Fatalf("Unexpected verify result %+v", result)
+	}
+	if _, err = cache.Get(keys[0], 3); !errors.Is(err, ErrNotFoundInCache) {
+		t.Fatalf("Expected corrupted file to be removed, got %v", err)
+	}
+	if _, err = cache.Get(keys[1], 3); err != nil {
+		t.Fatal(err)
+	}
+}
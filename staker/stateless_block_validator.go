@@ -254,13 +254,23 @@ func NewStatelessBlockValidator(
 		}
 	}
 	configs := config().ValidationServerConfigs
+	var executionClients []*client.ExecutionClient
 	for i := range configs {
 		i := i
 		confFetcher := func() *rpcclient.ClientConfig { return &config().ValidationServerConfigs[i] }
 
-		executionSpawner := client.NewExecutionClient(confFetcher, stack)
-		executionSpawners = append(executionSpawners, executionSpawner)
-		boldExecutionSpawners = append(boldExecutionSpawners, client.NewBOLDExecutionClient(executionSpawner))
+		executionClients = append(executionClients, client.NewExecutionClient(confFetcher, stack))
+	}
+	if config().ValidationLoadBalancer.Enable && len(executionClients) > 1 {
+		loadBalancerConfig := func() *client.LoadBalancerConfig { return &config().ValidationLoadBalancer }
+		loadBalancer := client.NewLoadBalancer(loadBalancerConfig, executionClients)
+		executionSpawners = append(executionSpawners, loadBalancer)
+		boldExecutionSpawners = append(boldExecutionSpawners, client.NewBOLDExecutionClient(loadBalancer))
+	} else {
+		for _, executionSpawner := range executionClients {
+			executionSpawners = append(executionSpawners, executionSpawner)
+			boldExecutionSpawners = append(boldExecutionSpawners, client.NewBOLDExecutionClient(executionSpawner))
+		}
 	}
 
 	if len(executionSpawners) == 0 {
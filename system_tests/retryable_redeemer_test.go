@@ -0,0 +1,100 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package arbtest
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/offchainlabs/nitro/arbos/l2pricing"
+	"github.com/offchainlabs/nitro/retryable_redeemer"
+	"github.com/offchainlabs/nitro/solgen/go/localgen"
+	"github.com/offchainlabs/nitro/util/arbmath"
+)
+
+func TestRetryableRedeemer(t *testing.T) {
+	// send enough L2 gas for intrinsic but not compute
+	testRetryableRedeemer(t, big.NewInt(int64(params.TxGas+params.TxDataNonZeroGasEIP2028*4)))
+}
+
+func TestRetryableRedeemerWithoutAutoRedeem(t *testing.T) {
+	// without gas the submission doesn't schedule an auto-redeem
+	testRetryableRedeemer(t, common.Big0)
+}
+
+func testRetryableRedeemer(t *testing.T, gasLimit *big.Int) {
+	builder, delayedInbox, lookupL2Tx, ctx, teardown := retryableSetup(t)
+	defer teardown()
+
+	ownerTxOpts := builder.L2Info.GetDefaultTransactOpts("Owner", ctx)
+	simpleAddr, simple := builder.L2.DeploySimple(t, ownerTxOpts)
+	simpleABI, err := localgen.SimpleMetaData.GetAbi()
+	Require(t, err)
+
+	builder.L2Info.GenerateAccount("Redeemer")
+	builder.L2.TransferBalance(t, "Faucet", "Redeemer", big.NewInt(1e18), builder.L2Info)
+	redeemerTxOpts := builder.L2Info.GetDefaultTransactOpts("Redeemer", ctx)
+
+	config := retryableredeemer.DefaultConfig
+	config.PollInterval = 10 * time.Millisecond
+	config.AllowList = []string{simpleAddr.Hex()}
+	redeemer, err := retryableredeemer.NewRetryableRedeemer(builder.L2.Client, &redeemerTxOpts, &config)
+	Require(t, err)
+	Require(t, redeemer.Start(ctx))
+	defer redeemer.StopAndWait()
+
+	usertxopts := builder.L1Info.GetDefaultTransactOpts("Faucet", ctx)
+	usertxopts.Value = arbmath.BigMul(big.NewInt(1e12), big.NewInt(1e12))
+	beneficiaryAddress := builder.L2Info.GetAddress("Beneficiary")
+	l1tx, err := delayedInbox.CreateRetryableTicket(
+		&usertxopts,
+		simpleAddr,
+		common.Big0,
+		big.NewInt(1e16),
+		beneficiaryAddress,
+		beneficiaryAddress,
+		gasLimit,
+		big.NewInt(l2pricing.InitialBaseFeeWei*2),
+		simpleABI.Methods["incrementRedeem"].ID,
+	)
+	Require(t, err)
+	l1Receipt, err := builder.L1.EnsureTxSucceeded(l1tx)
+	Require(t, err)
+
+	waitForL1DelayBlocks(t, builder)
+
+	receipt, err := builder.L2.EnsureTxSucceeded(lookupL2Tx(l1Receipt))
+	Require(t, err)
+	if gasLimit.Sign() == 0 {
+		if len(receipt.Logs) != 1 {
+			Fatal(t, "expected no auto-redeem to be scheduled", receipt.Logs)
+		}
+	} else {
+		autoRedeemId := receipt.Logs[1].Topics[2]
+		receipt, err = WaitForTx(ctx, builder.L2.Client, autoRedeemId, time.Second*5)
+		Require(t, err)
+		if receipt.Status != types.ReceiptStatusFailed {
+			Fatal(t, "expected the auto-redeem to fail")
+		}
+	}
+
+	for i := 0; ; i++ {
+		counter, err := simple.Counter(&bind.CallOpts{Context: ctx})
+		Require(t, err)
+		// the gas is accounted for once the redeemer got the receipt
+		if counter == 1 && redeemer.GasSpent() > 0 {
+			break
+		}
+		if i >= 100 {
+			Fatal(t, "retryable wasn't redeemed by the redeemer")
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
@@ -403,6 +403,32 @@ func TestSubmitRetryableFailThenRetry(t *testing.T) {
 		Fatal(t, receipt.GasUsed)
 	}
 
+	var info gethexec.RetryableInfo
+	Require(t, builder.L2.Client.Client().CallContext(ctx, &info, "arb_getRetryable", ticketId))
+	if info.Status != gethexec.RetryableStatusPending || info.Beneficiary != beneficiaryAddress || info.To == nil || *info.To != simpleAddr {
+		Fatal(t, "unexpected pending retryable", info)
+	}
+	if len(info.Redeems) != 1 || info.Redeems[0].RetryTxHash != firstRetryTxId || info.Redeems[0].Receipt.Status != types.ReceiptStatusFailed {
+		Fatal(t, "unexpected redeem attempts", info.Redeems)
+	}
+	var pending gethexec.RetryablesPage
+	Require(t, builder.L2.Client.Client().CallContext(ctx, &pending, "arb_listRetryables", gethexec.RetryablesFilter{Beneficiary: &beneficiaryAddress}))
+	if len(pending.Retryables) != 1 || pending.Retryables[0].TicketId != ticketId || pending.NextCursor != nil {
+		Fatal(t, "unexpected pending retryables", pending)
+	}
+	limit := hexutil.Uint64(1)
+	pending = gethexec.RetryablesPage{}
+	Require(t, builder.L2.Client.Client().CallContext(ctx, &pending, "arb_listRetryables", gethexec.RetryablesFilter{Beneficiary: &beneficiaryAddress, Limit: &limit}))
+	if len(pending.Retryables) != 1 || pending.NextCursor == nil {
+		Fatal(t, "unexpected first page of pending retryables", pending)
+	}
+	cursor := *pending.NextCursor
+	pending = gethexec.RetryablesPage{}
+	Require(t, builder.L2.Client.Client().CallContext(ctx, &pending, "arb_listRetryables", gethexec.RetryablesFilter{Beneficiary: &beneficiaryAddress, Cursor: &cursor}))
+	if len(pending.Retryables) != 0 || pending.NextCursor != nil {
+		Fatal(t, "unexpected second page of pending retryables", pending)
+	}
+
 	l2FaucetTxOpts := builder.L2Info.GetDefaultTransactOpts("Faucet", ctx)
 	l2FaucetTxOpts.GasLimit = 0 // gas estimation
 	l2FaucetTxOpts.Value = big.NewInt(2)
@@ -458,6 +484,17 @@ func TestSubmitRetryableFailThenRetry(t *testing.T) {
 	if parsed.Redeemer != ownerTxOpts.From {
 		Fatal(t, "Unexpected redeemer", parsed.Redeemer, "expected", ownerTxOpts.From)
 	}
+
+	info = gethexec.RetryableInfo{}
+	Require(t, builder.L2.Client.Client().CallContext(ctx, &info, "arb_getRetryable", ticketId))
+	if info.Status != gethexec.RetryableStatusRedeemed || len(info.Redeems) != 2 || info.Redeems[1].RetryTxHash != retryTxId {
+		Fatal(t, "unexpected redeemed retryable", info)
+	}
+	pending = gethexec.RetryablesPage{}
+	Require(t, builder.L2.Client.Client().CallContext(ctx, &pending, "arb_listRetryables", gethexec.RetryablesFilter{Beneficiary: &beneficiaryAddress}))
+	if len(pending.Retryables) != 0 {
+		Fatal(t, "redeemed retryable still listed", pending)
+	}
 	testFlatCallTracer(t, ctx, builder.L2.Client.Client())
 }
 
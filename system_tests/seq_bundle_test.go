@@ -0,0 +1,102 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package arbtest
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/offchainlabs/nitro/execution/gethexec"
+)
+
+func sendBundleArgs(t *testing.T, txs ...*types.Transaction) gethexec.SendBundleArgs {
+	t.Helper()
+	var args gethexec.SendBundleArgs
+	for _, tx := range txs {
+		encoded, err := tx.MarshalBinary()
+		Require(t, err)
+		args.Txs = append(args.Txs, hexutil.Bytes(encoded))
+	}
+	return args
+}
+
+func TestSequencerBundleIncludedContiguously(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	builder := NewNodeBuilder(ctx).DefaultConfig(t, false)
+	cleanup := builder.Build(t)
+	defer cleanup()
+
+	builder.L2Info.GenerateAccount("User")
+	builder.L2Info.GenerateAccount("User2")
+	builder.L2.TransferBalance(t, "Owner", "User", big.NewInt(params.Ether), builder.L2Info)
+
+	first := builder.L2Info.PrepareTx("User", "User2", builder.L2Info.TransferGas, big.NewInt(params.Ether/10), nil)
+	second := builder.L2Info.PrepareTx("Owner", "User2", builder.L2Info.TransferGas, big.NewInt(params.Ether/10), nil)
+	third := builder.L2Info.PrepareTx("User", "User2", builder.L2Info.TransferGas, big.NewInt(params.Ether/10), nil)
+
+	var bundleHash common.Hash
+	l2rpc := builder.L2.Stack.Attach()
+	Require(t, l2rpc.CallContext(ctx, &bundleHash, "arb_sendBundle", sendBundleArgs(t, first, second, third)))
+	if bundleHash == (common.Hash{}) {
+		Fatal(t, "arb_sendBundle returned an empty bundle hash")
+	}
+
+	var blockNumber uint64
+	for i, tx := range []*types.Transaction{first, second, third} {
+		receipt, err := builder.L2.EnsureTxSucceeded(tx)
+		Require(t, err)
+		if i == 0 {
+			blockNumber = receipt.BlockNumber.Uint64()
+		} else if receipt.BlockNumber.Uint64() != blockNumber {
+			Fatal(t, "bundle tx", i, "included in block", receipt.BlockNumber, "instead of", blockNumber)
+		}
+		// The start block internal tx is always at index 0
+		// #nosec G115
+		if receipt.TransactionIndex != uint(i+1) {
+			Fatal(t, "bundle tx", i, "has unexpected index", receipt.TransactionIndex)
+		}
+	}
+}
+
+func TestSequencerBundleDroppedOnFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	builder := NewNodeBuilder(ctx).DefaultConfig(t, false)
+	cleanup := builder.Build(t)
+	defer cleanup()
+
+	builder.L2Info.GenerateAccount("User")
+	builder.L2Info.GenerateAccount("User2")
+	builder.L2.TransferBalance(t, "Owner", "User", big.NewInt(params.Ether), builder.L2Info)
+
+	valid := builder.L2Info.PrepareTx("Owner", "User2", builder.L2Info.TransferGas, big.NewInt(params.Ether/10), nil)
+	// User can't afford this transfer, so the whole bundle must be dropped
+	invalid := builder.L2Info.PrepareTx("User", "User2", builder.L2Info.TransferGas, big.NewInt(params.Ether*10), nil)
+
+	ownerNonce, err := builder.L2.Client.NonceAt(ctx, builder.L2Info.GetAddress("Owner"), nil)
+	Require(t, err)
+
+	l2rpc := builder.L2.Stack.Attach()
+	var bundleHash common.Hash
+	err = l2rpc.CallContext(ctx, &bundleHash, "arb_sendBundle", sendBundleArgs(t, valid, invalid))
+	if err == nil {
+		Fatal(t, "bundle with a failing transaction was accepted")
+	}
+
+	// Make sure a new block is created, then check the valid tx wasn't included
+	builder.L2Info.GetInfoWithPrivKey("Owner").Nonce.Store(ownerNonce)
+	builder.L2.TransferBalance(t, "Owner", "User2", common.Big1, builder.L2Info)
+	if _, err := builder.L2.Client.TransactionReceipt(ctx, valid.Hash()); err == nil {
+		Fatal(t, "transaction from a dropped bundle was included")
+	}
+}
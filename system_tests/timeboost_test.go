@@ -7,6 +7,7 @@ import (
 	"crypto/rand"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"net"
@@ -1167,6 +1168,89 @@ func TestTimeboostSequencerFeed_ExpressLaneAuction_ExpressLaneTxsHaveAdvantage(t
 	verifyControllerAdvantage(t, ctx, seqClient, expressLaneClient, seqInfo, "Bob", "Alice")
 }
 
+func TestTimeboostExpressLaneControllerTransfer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tmpDir := t.TempDir()
+
+	auctionContractAddr, aliceBidderClient, bobBidderClient, roundDuration, builderSeq, cleanupSeq, _, _, _ := setupExpressLaneAuction(t, tmpDir, ctx, 0, 0)
+	seq, seqClient, seqInfo := builderSeq.L2.ConsensusNode, builderSeq.L2.Client, builderSeq.L2Info
+	defer cleanupSeq()
+
+	auctionContract, err := express_lane_auctiongen.NewExpressLaneAuction(auctionContractAddr, seqClient)
+	Require(t, err)
+	roundTimingInfo, err := gethexec.GetRoundTimingInfo(auctionContract)
+	Require(t, err)
+
+	// The sequencer's tracker polls the resolved rounds, also follow the
+	// auction with trackers in both modes.
+	newTracker := func(useLogs bool) *gethexec.ExpressLaneTracker {
+		tracker := gethexec.NewExpressLaneTracker(
+			*roundTimingInfo,
+			builderSeq.execConfig.Sequencer.MaxBlockSpeed,
+			builderSeq.L2.ExecNode.Backend.APIBackend(),
+			auctionContract,
+			auctionContractAddr,
+			builderSeq.chainConfig,
+			builderSeq.execConfig.Sequencer.Timeboost.EarlySubmissionGrace,
+		)
+		if useLogs {
+			tracker.UseLogs()
+		}
+		tracker.Start(ctx)
+		t.Cleanup(tracker.StopAndWait)
+		return tracker
+	}
+	trackers := map[string]*gethexec.ExpressLaneTracker{
+		"contract polling": newTracker(false),
+		"log iterator":     newTracker(true),
+	}
+	awaitController := func(round uint64, expected common.Address) {
+		t.Helper()
+		for mode, tracker := range trackers {
+			var controller common.Address
+			for start := time.Now(); time.Since(start) < roundDuration; time.Sleep(50 * time.Millisecond) {
+				if controller, err = tracker.RoundController(round); err == nil && controller == expected {
+					break
+				}
+			}
+			if controller != expected {
+				t.Fatalf("%s tracker has controller %v for round %d instead of %v (err %v)", mode, controller, round, expected, err)
+			}
+		}
+	}
+
+	placeBidsAndDecideWinner(t, ctx, seqClient, seqInfo, auctionContract, "Bob", "Alice", bobBidderClient, aliceBidderClient, roundDuration)
+	round := roundTimingInfo.RoundNumber() + 1
+	awaitController(round, seqInfo.GetAddress("Bob"))
+
+	// Bob transfers the control of the round he won to Alice.
+	bobManager, err := timeboost.NewControllerManager(ctx, func() *timeboost.ControllerManagerConfig {
+		config := timeboost.DefaultControllerManagerConfig
+		config.AuctionContractAddress = auctionContractAddr.Hex()
+		config.ArbitrumNodeEndpoint = seq.Stack.HTTPEndpoint()
+		config.ResolutionPollInterval = 100 * time.Millisecond
+		config.VerifyTimeout = roundDuration
+		config.Wallet = genericconf.WalletConfig{
+			PrivateKey: fmt.Sprintf("00%x", seqInfo.Accounts["Bob"].PrivateKey.D.Bytes()),
+		}
+		return &config
+	})
+	Require(t, err)
+	_, err = bobManager.TransferControl(ctx, round, seqInfo.GetAddress("Alice"))
+	Require(t, err)
+
+	awaitController(round, seqInfo.GetAddress("Alice"))
+	Require(t, bobManager.VerifyController(ctx, round, seqInfo.GetAddress("Alice")))
+
+	// Bob lost the control, so he can't transfer it again.
+	_, err = bobManager.TransferControl(ctx, round, seqInfo.GetAddress("Bob"))
+	if !errors.Is(err, timeboost.ErrNotExpressLaneController) {
+		t.Fatalf("expected Bob's second transfer to fail with %v, got %v", timeboost.ErrNotExpressLaneController, err)
+	}
+}
+
 func TestTimeboostSequencerFeed_ExpressLaneAuction_InnerPayloadNoncesAreRespected_TimeboostedFieldIsCorrect(t *testing.T) {
 	logHandler := testhelpers.InitTestLog(t, log.LevelInfo)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -76,6 +76,7 @@ type AuctioneerServerConfig struct {
 	DbDirectory               string                   `koanf:"db-directory"`
 	AuctionResolutionWaitTime time.Duration            `koanf:"auction-resolution-wait-time"`
 	S3Storage                 S3StorageServiceConfig   `koanf:"s3-storage"`
+	BidHistoryAPI             BidHistoryAPIConfig      `koanf:"bid-history-api"`
 }
 
 var DefaultAuctioneerConsumerConfig = pubsub.ConsumerConfig{
@@ -92,6 +93,7 @@ var DefaultAuctioneerServerConfig = AuctioneerServerConfig{
 	StreamTimeout:             10 * time.Minute,
 	AuctionResolutionWaitTime: 2 * time.Second,
 	S3Storage:                 DefaultS3StorageServiceConfig,
+	BidHistoryAPI:             DefaultBidHistoryAPIConfig,
 }
 
 var TestAuctioneerServerConfig = AuctioneerServerConfig{
@@ -100,6 +102,7 @@ var TestAuctioneerServerConfig = AuctioneerServerConfig{
 	ConsumerConfig:            DefaultAuctioneerConsumerConfig,
 	StreamTimeout:             time.Minute,
 	AuctionResolutionWaitTime: 2 * time.Second,
+	BidHistoryAPI:             DefaultBidHistoryAPIConfig,
 }
 
 func AuctioneerServerConfigAddOptions(prefix string, f *pflag.FlagSet) {
@@ -116,6 +119,7 @@ func AuctioneerServerConfigAddOptions(prefix string, f *pflag.FlagSet) {
 	f.String(prefix+".db-directory", DefaultAuctioneerServerConfig.DbDirectory, "path to database directory for persisting validated bids in a sqlite file")
 	f.Duration(prefix+".auction-resolution-wait-time", DefaultAuctioneerServerConfig.AuctionResolutionWaitTime, "wait time after auction closing before resolving the auction")
 	S3StorageServiceConfigAddOptions(prefix+".s3-storage", f)
+	BidHistoryAPIConfigAddOptions(prefix+".bid-history-api", f)
 }
 
 // AuctioneerServer is a struct that represents an autonomous auctioneer.
@@ -136,6 +140,7 @@ type AuctioneerServer struct {
 	auctionResolutionWaitTime      time.Duration
 	database                       *SqliteDatabase
 	s3StorageService               *S3StorageService
+	bidHistory                     *BidHistory
 	unackedBidsMutex               sync.Mutex
 	unackedBids                    map[string]*pubsub.Message[*JsonValidatedBid]
 
@@ -228,6 +233,11 @@ func NewAuctioneerServer(ctx context.Context, configFetcher AuctioneerServerConf
 		return nil, err
 	}
 
+	var bidHistory *BidHistory
+	if cfg.BidHistoryAPI.Enable {
+		bidHistory = NewBidHistory(func() *BidHistoryAPIConfig { return &configFetcher().BidHistoryAPI }, database, s3StorageService, domainSeparator)
+	}
+
 	// Generate unique ID for this auctioneer instance
 	myId := fmt.Sprintf("auctioneer-%s-%d",
 		uuid.New().String()[:8], // Short UUID
@@ -241,6 +251,7 @@ func NewAuctioneerServer(ctx context.Context, configFetcher AuctioneerServerConf
 		chainId:                        chainId,
 		database:                       database,
 		s3StorageService:               s3StorageService,
+		bidHistory:                     bidHistory,
 		consumer:                       c,
 		auctionContract:                auctionContract,
 		auctionContractAddr:            auctionContractAddr,
@@ -0,0 +1,339 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package timeboost
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/node"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/offchainlabs/nitro/util/containers"
+)
+
+type BidHistoryAPIConfig struct {
+	Enable           bool   `koanf:"enable"`
+	MaxRoundRange    uint64 `koanf:"max-round-range"`
+	MaxResults       int    `koanf:"max-results"`
+	ArchiveCacheSize int    `koanf:"archive-cache-size"`
+}
+
+var DefaultBidHistoryAPIConfig = BidHistoryAPIConfig{
+	Enable:           false,
+	MaxRoundRange:    10_000,
+	MaxResults:       10_000,
+	ArchiveCacheSize: 64,
+}
+
+func BidHistoryAPIConfigAddOptions(prefix string, f *pflag.FlagSet) {
+	f.Bool(prefix+".enable", DefaultBidHistoryAPIConfig.Enable, "enable the read-only API to query the history of validated bids, served over the http/ws endpoints")
+	f.Uint64(prefix+".max-round-range", DefaultBidHistoryAPIConfig.MaxRoundRange, "maximum number of rounds a single bid history query can span")
+	f.Int(prefix+".max-results", DefaultBidHistoryAPIConfig.MaxResults, "maximum number of bids returned by a single bid history query")
+	f.Int(prefix+".archive-cache-size", DefaultBidHistoryAPIConfig.ArchiveCacheSize, "number of batches downloaded from s3 to keep in memory")
+}
+
+func (c *BidHistoryAPIConfig) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+	if c.MaxRoundRange == 0 {
+		return errors.New("bid-history-api.max-round-range must be positive")
+	}
+	if c.MaxResults <= 0 {
+		return errors.New("bid-history-api.max-results must be positive")
+	}
+	if c.ArchiveCacheSize <= 0 {
+		return errors.New("bid-history-api.archive-cache-size must be positive")
+	}
+	return nil
+}
+
+type BidHistoryFilter struct {
+	FromRound             hexutil.Uint64  `json:"fromRound"`
+	ToRound               hexutil.Uint64  `json:"toRound"`
+	Bidder                *common.Address `json:"bidder,omitempty"`
+	ExpressLaneController *common.Address `json:"expressLaneController,omitempty"`
+	MinAmount             *hexutil.Big    `json:"minAmount,omitempty"`
+	MaxAmount             *hexutil.Big    `json:"maxAmount,omitempty"`
+}
+
+type HistoricalBid struct {
+	ChainId                *hexutil.Big   `json:"chainId"`
+	Bidder                 common.Address `json:"bidder"`
+	ExpressLaneController  common.Address `json:"expressLaneController"`
+	AuctionContractAddress common.Address `json:"auctionContractAddress"`
+	Round                  hexutil.Uint64 `json:"round"`
+	Amount                 *hexutil.Big   `json:"amount"`
+	Signature              hexutil.Bytes  `json:"signature"`
+	// Archived is set for bids read back from the s3 archive rather than the database.
+	Archived bool `json:"archived"`
+}
+
+type BidHistoryResult struct {
+	Bids []*HistoricalBid `json:"bids"`
+	// Truncated is set if more bids matched than max-results.
+	Truncated bool `json:"truncated"`
+}
+
+type RoundResult struct {
+	Round   hexutil.Uint64 `json:"round"`
+	NumBids int            `json:"numBids"`
+	// Winner is nil if no bids were received for the round.
+	Winner      *HistoricalBid `json:"winner"`
+	SecondPlace *HistoricalBid `json:"secondPlace,omitempty"`
+	// ClearingPrice is the second highest bid, it's nil for single bid
+	// auctions where the winner pays the reserve price instead.
+	ClearingPrice     *hexutil.Big `json:"clearingPrice,omitempty"`
+	IsMultiBidAuction bool         `json:"isMultiBidAuction"`
+}
+
+func historicalBidFromDb(bid *SqliteDatabaseBid, archived bool) (*HistoricalBid, error) {
+	chainId, ok := new(big.Int).SetString(bid.ChainId, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid chain id %q in stored bid", bid.ChainId)
+	}
+	amount, ok := new(big.Int).SetString(bid.Amount, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount %q in stored bid", bid.Amount)
+	}
+	signature, err := hex.DecodeString(bid.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature in stored bid: %w", err)
+	}
+	return &HistoricalBid{
+		ChainId:                (*hexutil.Big)(chainId),
+		Bidder:                 common.HexToAddress(bid.Bidder),
+		ExpressLaneController:  common.HexToAddress(bid.ExpressLaneController),
+		AuctionContractAddress: common.HexToAddress(bid.AuctionContractAddress),
+		Round:                  hexutil.Uint64(bid.Round),
+		Amount:                 (*hexutil.Big)(amount),
+		Signature:              signature,
+		Archived:               archived,
+	}, nil
+}
+
+func (f *BidHistoryFilter) matches(bid *HistoricalBid) bool {
+	if f.Bidder != nil && bid.Bidder != *f.Bidder {
+		return false
+	}
+	if f.ExpressLaneController != nil && bid.ExpressLaneController != *f.ExpressLaneController {
+		return false
+	}
+	if f.MinAmount != nil && bid.Amount.ToInt().Cmp(f.MinAmount.ToInt()) < 0 {
+		return false
+	}
+	if f.MaxAmount != nil && bid.Amount.ToInt().Cmp(f.MaxAmount.ToInt()) > 0 {
+		return false
+	}
+	return true
+}
+
+// BidHistory answers queries about past bids from the auctioneer's database,
+// and from the batches uploaded to s3 for the rounds no longer in the database.
+type BidHistory struct {
+	config          func() *BidHistoryAPIConfig
+	database        *SqliteDatabase
+	archive         *S3StorageService // nil if s3 storage isn't enabled
+	domainSeparator [32]byte
+
+	archiveCacheMutex sync.Mutex
+	archiveCache      *containers.LruCache[string, []*SqliteDatabaseBid]
+}
+
+func NewBidHistory(config func() *BidHistoryAPIConfig, database *SqliteDatabase, archive *S3StorageService, domainSeparator [32]byte) *BidHistory {
+	return &BidHistory{
+		config:          config,
+		database:        database,
+		archive:         archive,
+		domainSeparator: domainSeparator,
+		archiveCache:    containers.NewLruCache[string, []*SqliteDatabaseBid](config().ArchiveCacheSize),
+	}
+}
+
+func (h *BidHistory) readArchivedBatch(ctx context.Context, key string) ([]*SqliteDatabaseBid, error) {
+	h.archiveCacheMutex.Lock()
+	bids, ok := h.archiveCache.Get(key)
+	h.archiveCacheMutex.Unlock()
+	if ok {
+		return bids, nil
+	}
+	bids, err := h.archive.readBatch(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	h.archiveCacheMutex.Lock()
+	h.archiveCache.Add(key, bids)
+	h.archiveCacheMutex.Unlock()
+	return bids, nil
+}
+
+// bids returns all the bids of the rounds between fromRound and toRound
+// inclusive, ordered by round and then by arrival. Bids are read from s3
+// first, as the database only deletes bids once they've been uploaded.
+func (h *BidHistory) bids(ctx context.Context, fromRound, toRound uint64, bidder *common.Address, limit int) ([]*HistoricalBid, error) {
+	var result []*HistoricalBid
+	seen := make(map[string]struct{})
+	add := func(bid *SqliteDatabaseBid, archived bool) error {
+		if bid.Round < fromRound || bid.Round > toRound {
+			return nil
+		}
+		// A batch can be uploaded again if deleting it from the database failed
+		key := fmt.Sprintf("%d-%s", bid.Round, bid.Signature)
+		if _, ok := seen[key]; ok {
+			return nil
+		}
+		seen[key] = struct{}{}
+		historical, err := historicalBidFromDb(bid, archived)
+		if err != nil {
+			return err
+		}
+		if bidder != nil && historical.Bidder != *bidder {
+			return nil
+		}
+		result = append(result, historical)
+		return nil
+	}
+	if h.archive != nil {
+		batches, err := h.archive.listBatches(ctx, fromRound, toRound)
+		if err != nil {
+			return nil, fmt.Errorf("error listing archived bids: %w", err)
+		}
+		for _, batch := range batches {
+			bids, err := h.readArchivedBatch(ctx, batch.key)
+			if err != nil {
+				return nil, fmt.Errorf("error reading archived bids: %w", err)
+			}
+			for _, bid := range bids {
+				if err := add(bid, true); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	dbBids, err := h.database.QueryBids(fromRound, toRound, bidder, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, bid := range dbBids {
+		if err := add(bid, false); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (h *BidHistory) GetBids(ctx context.Context, filter *BidHistoryFilter) (*BidHistoryResult, error) {
+	config := h.config()
+	if filter == nil {
+		return nil, errors.New("missing filter")
+	}
+	fromRound, toRound := uint64(filter.FromRound), uint64(filter.ToRound)
+	if toRound < fromRound {
+		return nil, fmt.Errorf("toRound %d is before fromRound %d", toRound, fromRound)
+	}
+	if toRound-fromRound >= config.MaxRoundRange {
+		return nil, fmt.Errorf("round range %d-%d exceeds the maximum of %d rounds", fromRound, toRound, config.MaxRoundRange)
+	}
+	var limit int
+	if filter.ExpressLaneController == nil && filter.MinAmount == nil && filter.MaxAmount == nil {
+		// Only the bidder and rounds are filtered in the database, so the
+		// results can only be limited there when there are no other filters.
+		limit = config.MaxResults + 1
+	}
+	bids, err := h.bids(ctx, fromRound, toRound, filter.Bidder, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := &BidHistoryResult{Bids: []*HistoricalBid{}}
+	for _, bid := range bids {
+		if !filter.matches(bid) {
+			continue
+		}
+		if len(result.Bids) == config.MaxResults {
+			result.Truncated = true
+			break
+		}
+		result.Bids = append(result.Bids, bid)
+	}
+	return result, nil
+}
+
+// GetRoundResult recomputes the outcome of the auction of a round from the
+// bids received for it, the same way the auctioneer resolved it.
+func (h *BidHistory) GetRoundResult(ctx context.Context, round uint64) (*RoundResult, error) {
+	bids, err := h.bids(ctx, round, round, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	// Later bids of an express lane controller replace its earlier ones.
+	cache := newBidCache(h.domainSeparator)
+	historicalByController := make(map[common.Address]*HistoricalBid)
+	for _, bid := range bids {
+		cache.add(&ValidatedBid{
+			ChainId:                bid.ChainId.ToInt(),
+			AuctionContractAddress: bid.AuctionContractAddress,
+			Signature:              bid.Signature,
+			Bidder:                 bid.Bidder,
+			ExpressLaneController:  bid.ExpressLaneController,
+			Round:                  uint64(bid.Round),
+			Amount:                 bid.Amount.ToInt(),
+		})
+		historicalByController[bid.ExpressLaneController] = bid
+	}
+	result := &RoundResult{
+		Round:   hexutil.Uint64(round),
+		NumBids: cache.size(),
+	}
+	top := cache.topTwoBids()
+	if top.firstPlace != nil {
+		result.Winner = historicalByController[top.firstPlace.ExpressLaneController]
+	}
+	if top.secondPlace != nil {
+		result.SecondPlace = historicalByController[top.secondPlace.ExpressLaneController]
+		result.ClearingPrice = result.SecondPlace.Amount
+		result.IsMultiBidAuction = true
+	}
+	return result, nil
+}
+
+// BidHistoryAPI is the read-only bid history API of the auctioneer.
+type BidHistoryAPI struct {
+	history *BidHistory
+}
+
+func NewBidHistoryAPI(history *BidHistory) *BidHistoryAPI {
+	return &BidHistoryAPI{history}
+}
+
+func (a *BidHistoryAPI) GetBids(ctx context.Context, filter *BidHistoryFilter) (*BidHistoryResult, error) {
+	return a.history.GetBids(ctx, filter)
+}
+
+func (a *BidHistoryAPI) GetRoundResult(ctx context.Context, round hexutil.Uint64) (*RoundResult, error) {
+	return a.history.GetRoundResult(ctx, uint64(round))
+}
+
+// RegisterBidHistoryAPI exposes the bid history API under the auctioneer
+// namespace, it returns false if the API isn't enabled.
+func (a *AuctioneerServer) RegisterBidHistoryAPI(stack *node.Node) bool {
+	if a.bidHistory == nil {
+		return false
+	}
+	stack.RegisterAPIs([]rpc.API{{
+		Namespace: AuctioneerNamespace,
+		Version:   "1.0",
+		Service:   NewBidHistoryAPI(a.bidHistory),
+		Public:    true,
+	}})
+	return true
+}
@@ -0,0 +1,132 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package timeboost
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestBidHistory(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	db, err := NewDatabase(t.TempDir())
+	require.NoError(t, err)
+	mockClient := newmockS3FullClient()
+	archive := &S3StorageService{
+		client: mockClient,
+		lister: mockClient,
+		config: &S3StorageServiceConfig{MaxBatchSize: 0},
+		sqlDB:  db,
+	}
+
+	alice := common.HexToAddress("0xa")
+	bob := common.HexToAddress("0xb")
+	insertBid := func(bidder common.Address, controller common.Address, round uint64, amount int64) {
+		t.Helper()
+		require.NoError(t, db.InsertBid(&ValidatedBid{
+			ChainId:                big.NewInt(1),
+			AuctionContractAddress: common.HexToAddress("0x1"),
+			Bidder:                 bidder,
+			ExpressLaneController:  controller,
+			Round:                  round,
+			Amount:                 big.NewInt(amount),
+			Signature:              []byte(fmt.Sprintf("%v-%d-%d", bidder, round, amount)),
+		}))
+	}
+	insertBid(alice, alice, 1, 100)
+	insertBid(bob, bob, 1, 150)
+	// Alice replaces her bid for round 1
+	insertBid(alice, alice, 1, 200)
+	insertBid(bob, bob, 2, 50)
+	insertBid(alice, alice, 3, 10)
+
+	// Archive rounds 1 and 2 to s3, only round 3 stays in the database
+	archive.uploadBatches(ctx)
+	remaining, err := db.QueryBids(0, 10, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+
+	config := DefaultBidHistoryAPIConfig
+	config.Enable = true
+	history := NewBidHistory(func() *BidHistoryAPIConfig { return &config }, db, archive, [32]byte{})
+
+	result, err := history.GetBids(ctx, &BidHistoryFilter{FromRound: 1, ToRound: 3})
+	require.NoError(t, err)
+	require.False(t, result.Truncated)
+	require.Len(t, result.Bids, 5)
+	for i, bid := range result.Bids {
+		require.Equal(t, i < 4, bid.Archived)
+	}
+	require.Equal(t, hexutil.Uint64(3), result.Bids[4].Round)
+
+	result, err = history.GetBids(ctx, &BidHistoryFilter{FromRound: 1, ToRound: 3, Bidder: &alice})
+	require.NoError(t, err)
+	require.Len(t, result.Bids, 3)
+
+	result, err = history.GetBids(ctx, &BidHistoryFilter{
+		FromRound: 1,
+		ToRound:   2,
+		MinAmount: (*hexutil.Big)(big.NewInt(100)),
+		MaxAmount: (*hexutil.Big)(big.NewInt(150)),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Bids, 2)
+	require.Equal(t, alice, result.Bids[0].Bidder)
+	require.Equal(t, bob, result.Bids[1].Bidder)
+
+	config.MaxResults = 2
+	result, err = history.GetBids(ctx, &BidHistoryFilter{FromRound: 1, ToRound: 3})
+	require.NoError(t, err)
+	require.True(t, result.Truncated)
+	require.Len(t, result.Bids, 2)
+
+	_, err = history.GetBids(ctx, &BidHistoryFilter{FromRound: 3, ToRound: 1})
+	require.Error(t, err)
+	config.MaxRoundRange = 2
+	_, err = history.GetBids(ctx, &BidHistoryFilter{FromRound: 1, ToRound: 3})
+	require.Error(t, err)
+
+	roundResult, err := history.GetRoundResult(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, roundResult.NumBids)
+	require.True(t, roundResult.IsMultiBidAuction)
+	require.Equal(t, alice, roundResult.Winner.Bidder)
+	require.Equal(t, big.NewInt(200), roundResult.Winner.Amount.ToInt())
+	require.Equal(t, bob, roundResult.SecondPlace.Bidder)
+	require.Equal(t, big.NewInt(150), roundResult.ClearingPrice.ToInt())
+
+	roundResult, err = history.GetRoundResult(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, 1, roundResult.NumBids)
+	require.False(t, roundResult.IsMultiBidAuction)
+	require.Nil(t, roundResult.ClearingPrice)
+	require.False(t, roundResult.Winner.Archived)
+
+	roundResult, err = history.GetRoundResult(ctx, 5)
+	require.NoError(t, err)
+	require.Zero(t, roundResult.NumBids)
+	require.Nil(t, roundResult.Winner)
+}
+
+func TestParseBatchName(t *testing.T) {
+	s := &S3StorageService{objectPrefix: "prefix/"}
+	batch, ok := parseBatchName(s.getBatchName(12, 345))
+	require.True(t, ok)
+	require.Equal(t, uint64(12), batch.firstRound)
+	require.Equal(t, uint64(345), batch.lastRound)
+
+	for _, invalid := range []string{"prefix/validated-timeboost-bids/2025/01/01/0000012.csv.gzip", "0000012-0000010.csv.gzip", "0000012-0000015.csv"} {
+		_, ok := parseBatchName(invalid)
+		require.False(t, ok, invalid)
+	}
+}
@@ -33,16 +33,19 @@ type BidderClientConfig struct {
 	AuctionContractAddress string                   `koanf:"auction-contract-address"`
 	DepositGwei            int                      `koanf:"deposit-gwei"`
 	BidGwei                int                      `koanf:"bid-gwei"`
+	Strategy               BidStrategyConfig        `koanf:"strategy"`
 }
 
 var DefaultBidderClientConfig = BidderClientConfig{
 	ArbitrumNodeEndpoint: "http://localhost:8547",
 	BidValidatorEndpoint: "http://localhost:9372",
+	Strategy:             DefaultBidStrategyConfig,
 }
 
 var TestBidderClientConfig = BidderClientConfig{
 	ArbitrumNodeEndpoint: "http://localhost:8547",
 	BidValidatorEndpoint: "http://localhost:9372",
+	Strategy:             DefaultBidStrategyConfig,
 }
 
 func BidderClientConfigAddOptions(f *pflag.FlagSet) {
@@ -52,6 +55,7 @@ func BidderClientConfigAddOptions(f *pflag.FlagSet) {
 	f.String("auction-contract-address", DefaultBidderClientConfig.AuctionContractAddress, "express lane auction contract address")
 	f.Int("deposit-gwei", DefaultBidderClientConfig.DepositGwei, "deposit amount in gwei to take from bidder's account and send to auction contract")
 	f.Int("bid-gwei", DefaultBidderClientConfig.BidGwei, "bid amount in gwei, bidder must have already deposited enough into the auction contract")
+	BidStrategyConfigAddOptions("strategy", f)
 }
 
 type BidderClient struct {
@@ -182,6 +186,54 @@ func (bd *BidderClient) Deposit(ctx context.Context, amount *big.Int) error {
 	return nil
 }
 
+// Address returns the account the BidderClient bids and deposits with.
+func (bd *BidderClient) Address() common.Address {
+	return bd.txOpts.From
+}
+
+// AuctionBalance returns the balance the bidder has deposited into the auction contract.
+func (bd *BidderClient) AuctionBalance(ctx context.Context) (*big.Int, error) {
+	return bd.auctionContract.BalanceOf(&bind.CallOpts{
+		Context: ctx,
+	}, bd.txOpts.From)
+}
+
+// WithdrawableBalance returns the amount that can be withdrawn by FinalizeWithdrawal.
+func (bd *BidderClient) WithdrawableBalance(ctx context.Context) (*big.Int, error) {
+	return bd.auctionContract.WithdrawableBalance(&bind.CallOpts{
+		Context: ctx,
+	}, bd.txOpts.From)
+}
+
+// InitiateWithdrawal starts withdrawing the whole deposit of the bidder, it
+// can be finalized once the withdrawal delay of the auction contract has passed.
+func (bd *BidderClient) InitiateWithdrawal(ctx context.Context) error {
+	tx, err := bd.auctionContract.InitiateWithdrawal(bd.txOpts)
+	if err != nil {
+		return err
+	}
+	return bd.waitForSuccess(ctx, tx, "initiate withdrawal")
+}
+
+func (bd *BidderClient) FinalizeWithdrawal(ctx context.Context) error {
+	tx, err := bd.auctionContract.FinalizeWithdrawal(bd.txOpts)
+	if err != nil {
+		return err
+	}
+	return bd.waitForSuccess(ctx, tx, "finalize withdrawal")
+}
+
+func (bd *BidderClient) waitForSuccess(ctx context.Context, tx *types.Transaction, action string) error {
+	receipt, err := bind.WaitMined(ctx, bd.client, tx)
+	if err != nil {
+		return err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return fmt.Errorf("%s failed", action)
+	}
+	return nil
+}
+
 func (bd *BidderClient) Bid(
 	ctx context.Context, amount *big.Int, expressLaneController common.Address,
 ) (*Bid, error) {
@@ -0,0 +1,158 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package timeboost
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// LedgerEntry records a bid placed by the bidding engine and, once the
+// auction for its round is resolved, whether it won and what it paid.
+type LedgerEntry struct {
+	Round         uint64         `json:"round"`
+	BidAmount     *big.Int       `json:"bidAmount"`
+	BidTime       time.Time      `json:"bidTime"`
+	Resolved      bool           `json:"resolved"`
+	Won           bool           `json:"won"`
+	Winner        common.Address `json:"winner,omitempty"`
+	ClearingPrice *big.Int       `json:"clearingPrice,omitempty"`
+}
+
+// PricePaid is the second price charged for a won round, zero otherwise.
+func (e *LedgerEntry) PricePaid() *big.Int {
+	if !e.Won || e.ClearingPrice == nil {
+		return new(big.Int)
+	}
+	return e.ClearingPrice
+}
+
+// BidLedger is a persisted history of the bids placed by a bidder.
+// If no path is given it is only kept in memory.
+type BidLedger struct {
+	mutex   sync.Mutex
+	path    string
+	entries map[uint64]*LedgerEntry
+}
+
+func OpenBidLedger(path string) (*BidLedger, error) {
+	ledger := &BidLedger{
+		path:    path,
+		entries: make(map[uint64]*LedgerEntry),
+	}
+	if path == "" {
+		return ledger, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []*LedgerEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse bid ledger %s: %w", path, err)
+	}
+	for _, entry := range entries {
+		ledger.entries[entry.Round] = entry
+	}
+	return ledger, nil
+}
+
+func (l *BidLedger) RecordBid(round uint64, amount *big.Int, at time.Time) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.entries[round] = &LedgerEntry{
+		Round:     round,
+		BidAmount: new(big.Int).Set(amount),
+		BidTime:   at,
+	}
+	return l.persist()
+}
+
+// RecordResolution marks the bid for the round as won or lost. Rounds we
+// didn't bid on are ignored.
+func (l *BidLedger) RecordResolution(round uint64, winner common.Address, clearingPrice *big.Int, self common.Address) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	entry, ok := l.entries[round]
+	if !ok || entry.Resolved {
+		return nil
+	}
+	entry.Resolved = true
+	entry.Won = winner == self
+	entry.Winner = winner
+	entry.ClearingPrice = new(big.Int).Set(clearingPrice)
+	return l.persist()
+}
+
+// CommittedSince returns the amount spent on won rounds plus the amount of
+// the still unresolved bids, for the bids placed since the given time.
+func (l *BidLedger) CommittedSince(since time.Time) *big.Int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	total := new(big.Int)
+	for _, entry := range l.entries {
+		if entry.BidTime.Before(since) {
+			continue
+		}
+		if entry.Resolved {
+			total.Add(total, entry.PricePaid())
+		} else {
+			total.Add(total, entry.BidAmount)
+		}
+	}
+	return total
+}
+
+// Entries returns a copy of the ledger sorted by round.
+func (l *BidLedger) Entries() []LedgerEntry {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.sortedEntries()
+}
+
+func (l *BidLedger) sortedEntries() []LedgerEntry {
+	entries := make([]LedgerEntry, 0, len(l.entries))
+	for _, entry := range l.entries {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Round < entries[j].Round })
+	return entries
+}
+
+// persist atomically rewrites the ledger file, must be called with the mutex held.
+func (l *BidLedger) persist() error {
+	if l.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(l.sortedEntries(), "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(l.path), filepath.Base(l.path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), l.path)
+}
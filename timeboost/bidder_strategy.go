@@ -0,0 +1,403 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package timeboost
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/util/arbmath"
+	"github.com/offchainlabs/nitro/util/stopwaiter"
+)
+
+const (
+	FixedBidStrategyName                  = "fixed"
+	LastPricePlusIncrementBidStrategyName = "last-price-plus-increment"
+)
+
+const gwei = 1_000_000_000
+
+type BidStrategyConfig struct {
+	Enable                   bool          `koanf:"enable"`
+	Name                     string        `koanf:"name"`
+	BidGwei                  int           `koanf:"bid-gwei"`
+	IncrementGwei            int           `koanf:"increment-gwei"`
+	MaxBidGwei               int           `koanf:"max-bid-gwei"`
+	HourlyBudgetGwei         int           `koanf:"hourly-budget-gwei"`
+	DailyBudgetGwei          int           `koanf:"daily-budget-gwei"`
+	ExpressLaneController    string        `koanf:"express-lane-controller"`
+	BidLeadTime              time.Duration `koanf:"bid-lead-time"`
+	LedgerFile               string        `koanf:"ledger-file"`
+	ResolutionLookbackBlocks uint64        `koanf:"resolution-lookback-blocks"`
+	MinBalanceGwei           int           `koanf:"min-balance-gwei"`
+	TopUpGwei                int           `koanf:"top-up-gwei"`
+	WithdrawAfter            time.Duration `koanf:"withdraw-after"`
+}
+
+var DefaultBidStrategyConfig = BidStrategyConfig{
+	Enable:                   false,
+	Name:                     FixedBidStrategyName,
+	BidLeadTime:              5 * time.Second,
+	ResolutionLookbackBlocks: 10_000,
+}
+
+func BidStrategyConfigAddOptions(prefix string, f *pflag.FlagSet) {
+	f.Bool(prefix+".enable", DefaultBidStrategyConfig.Enable, "keep running and bid on every round according to the bidding strategy")
+	f.String(prefix+".name", DefaultBidStrategyConfig.Name, "bidding strategy, one of \""+FixedBidStrategyName+"\" or \""+LastPricePlusIncrementBidStrategyName+"\"")
+	f.Int(prefix+".bid-gwei", DefaultBidStrategyConfig.BidGwei, "bid amount in gwei for the fixed strategy, and the starting bid for the last-price-plus-increment strategy")
+	f.Int(prefix+".increment-gwei", DefaultBidStrategyConfig.IncrementGwei, "amount in gwei to bid over the last clearing price for the last-price-plus-increment strategy")
+	f.Int(prefix+".max-bid-gwei", DefaultBidStrategyConfig.MaxBidGwei, "maximum bid in gwei, 0 means no maximum")
+	f.Int(prefix+".hourly-budget-gwei", DefaultBidStrategyConfig.HourlyBudgetGwei, "maximum amount in gwei committed to bids over the last hour, 0 means no budget")
+	f.Int(prefix+".daily-budget-gwei", DefaultBidStrategyConfig.DailyBudgetGwei, "maximum amount in gwei committed to bids over the last day, 0 means no budget")
+	f.String(prefix+".express-lane-controller", DefaultBidStrategyConfig.ExpressLaneController, "express lane controller to bid for, defaults to the bidder's address")
+	f.Duration(prefix+".bid-lead-time", DefaultBidStrategyConfig.BidLeadTime, "how long before the auction closes to submit the bid for the round")
+	f.String(prefix+".ledger-file", DefaultBidStrategyConfig.LedgerFile, "file to persist the ledger of bids, wins and losses to, empty keeps it in memory only")
+	f.Uint64(prefix+".resolution-lookback-blocks", DefaultBidStrategyConfig.ResolutionLookbackBlocks, "number of blocks to look back for auction resolutions on startup")
+	f.Int(prefix+".min-balance-gwei", DefaultBidStrategyConfig.MinBalanceGwei, "deposit more into the auction contract when the balance falls below this amount in gwei, 0 disables top-ups")
+	f.Int(prefix+".top-up-gwei", DefaultBidStrategyConfig.TopUpGwei, "amount in gwei to deposit when the balance falls below min-balance-gwei")
+	f.Duration(prefix+".withdraw-after", DefaultBidStrategyConfig.WithdrawAfter, "stop bidding and withdraw the deposit after running for this long, 0 means never")
+}
+
+func (c *BidStrategyConfig) Validate() error {
+	if c.Name != FixedBidStrategyName && c.Name != LastPricePlusIncrementBidStrategyName {
+		return fmt.Errorf("unknown bidding strategy %q", c.Name)
+	}
+	if c.BidGwei < 0 || c.IncrementGwei < 0 || c.MaxBidGwei < 0 || c.HourlyBudgetGwei < 0 || c.DailyBudgetGwei < 0 || c.MinBalanceGwei < 0 || c.TopUpGwei < 0 {
+		return fmt.Errorf("bidding strategy amounts can't be negative")
+	}
+	if c.Name == FixedBidStrategyName && c.BidGwei == 0 {
+		return fmt.Errorf("the %s bidding strategy requires a bid amount", FixedBidStrategyName)
+	}
+	if c.MinBalanceGwei > 0 && c.TopUpGwei == 0 {
+		return fmt.Errorf("min-balance-gwei requires top-up-gwei to be set")
+	}
+	if c.ExpressLaneController != "" && !common.IsHexAddress(c.ExpressLaneController) {
+		return fmt.Errorf("invalid express lane controller address %q", c.ExpressLaneController)
+	}
+	return nil
+}
+
+// MarketState is what a BidStrategy knows about the auction when deciding on a bid.
+type MarketState struct {
+	Now time.Time
+	// LastClearingPrice is the price paid for the latest resolved round, nil if none was seen.
+	LastClearingPrice *big.Int
+	LastResolvedRound uint64
+	Ledger            *BidLedger
+}
+
+// BidStrategy decides how much to bid for a round.
+type BidStrategy interface {
+	// BidAmount returns the amount to bid for the round, or nil to skip the round.
+	BidAmount(round uint64, market *MarketState) *big.Int
+}
+
+// FixedBidStrategy always bids the same amount.
+type FixedBidStrategy struct {
+	Amount *big.Int
+}
+
+func (s *FixedBidStrategy) BidAmount(uint64, *MarketState) *big.Int {
+	return new(big.Int).Set(s.Amount)
+}
+
+// LastPricePlusIncrementBidStrategy outbids the clearing price of the latest
+// resolved round by a fixed increment, up to a maximum. Until a round has been
+// resolved it bids the starting amount.
+type LastPricePlusIncrementBidStrategy struct {
+	Start     *big.Int
+	Increment *big.Int
+	// Max is the highest bid allowed, nil means no maximum.
+	Max *big.Int
+}
+
+func (s *LastPricePlusIncrementBidStrategy) BidAmount(_ uint64, market *MarketState) *big.Int {
+	amount := new(big.Int).Set(s.Start)
+	if market.LastClearingPrice != nil {
+		amount = arbmath.BigAdd(market.LastClearingPrice, s.Increment)
+	}
+	if s.Max != nil && amount.Cmp(s.Max) > 0 {
+		amount.Set(s.Max)
+	}
+	return amount
+}
+
+// BudgetCappedBidStrategy limits the bids of another strategy so that the
+// amount committed over the last hour and day stays within budget. The full
+// amount of unresolved bids counts against the budget, as it's the most that
+// can be charged for them.
+type BudgetCappedBidStrategy struct {
+	Inner BidStrategy
+	// Hourly and Daily budgets, nil means unlimited.
+	Hourly *big.Int
+	Daily  *big.Int
+}
+
+func (s *BudgetCappedBidStrategy) BidAmount(round uint64, market *MarketState) *big.Int {
+	amount := s.Inner.BidAmount(round, market)
+	if amount == nil {
+		return nil
+	}
+	budgets := []struct {
+		budget *big.Int
+		window time.Duration
+	}{
+		{s.Hourly, time.Hour},
+		{s.Daily, 24 * time.Hour},
+	}
+	for _, b := range budgets {
+		if b.budget == nil {
+			continue
+		}
+		remaining := arbmath.BigSub(b.budget, market.Ledger.CommittedSince(market.Now.Add(-b.window)))
+		if remaining.Sign() <= 0 {
+			return nil
+		}
+		if amount.Cmp(remaining) > 0 {
+			amount = remaining
+		}
+	}
+	return amount
+}
+
+func gweiOrNil(amount int) *big.Int {
+	if amount == 0 {
+		return nil
+	}
+	return gweiToWei(amount)
+}
+
+func gweiToWei(amount int) *big.Int {
+	return arbmath.BigMulByUint(big.NewInt(int64(amount)), gwei)
+}
+
+func NewBidStrategy(config *BidStrategyConfig) (BidStrategy, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	var strategy BidStrategy
+	switch config.Name {
+	case FixedBidStrategyName:
+		strategy = &FixedBidStrategy{Amount: gweiToWei(config.BidGwei)}
+	case LastPricePlusIncrementBidStrategyName:
+		strategy = &LastPricePlusIncrementBidStrategy{
+			Start:     gweiToWei(config.BidGwei),
+			Increment: gweiToWei(config.IncrementGwei),
+			Max:       gweiOrNil(config.MaxBidGwei),
+		}
+	}
+	if config.HourlyBudgetGwei > 0 || config.DailyBudgetGwei > 0 {
+		strategy = &BudgetCappedBidStrategy{
+			Inner:  strategy,
+			Hourly: gweiOrNil(config.HourlyBudgetGwei),
+			Daily:  gweiOrNil(config.DailyBudgetGwei),
+		}
+	}
+	return strategy, nil
+}
+
+// BiddingEngine bids on every round according to a BidStrategy, keeping the
+// deposit topped up and recording the outcome of its bids in a BidLedger.
+type BiddingEngine struct {
+	stopwaiter.StopWaiter
+	bidder     *BidderClient
+	config     BidStrategyConfig
+	strategy   BidStrategy
+	ledger     *BidLedger
+	controller common.Address
+
+	// Only accessed from the bidding thread.
+	startedAt           time.Time
+	lastBidRound        uint64
+	lastClearingPrice   *big.Int
+	lastResolvedRound   uint64
+	nextResolutionBlock uint64
+	resolutionsSynced   bool
+	withdrawalInitiated bool
+	withdrawalDone      bool
+}
+
+func NewBiddingEngine(bidder *BidderClient, config *BidStrategyConfig, strategy BidStrategy) (*BiddingEngine, error) {
+	ledger, err := OpenBidLedger(config.LedgerFile)
+	if err != nil {
+		return nil, err
+	}
+	var controller common.Address
+	if config.ExpressLaneController != "" {
+		controller = common.HexToAddress(config.ExpressLaneController)
+	}
+	return &BiddingEngine{
+		bidder:     bidder,
+		config:     *config,
+		strategy:   strategy,
+		ledger:     ledger,
+		controller: controller,
+	}, nil
+}
+
+func (e *BiddingEngine) Ledger() *BidLedger {
+	return e.ledger
+}
+
+func (e *BiddingEngine) Start(ctxIn context.Context) {
+	e.StopWaiter.Start(ctxIn, e)
+	e.startedAt = time.Now()
+	e.CallIteratively(e.bidIteration)
+}
+
+func (e *BiddingEngine) bidIteration(ctx context.Context) time.Duration {
+	now := time.Now()
+	info := &e.bidder.roundTimingInfo
+	bidTime := info.TimeOfNextRoundAt(now).Add(-info.AuctionClosing - e.config.BidLeadTime)
+	if now.Before(bidTime) {
+		return bidTime.Sub(now)
+	}
+	nextBidTime := bidTime.Add(info.Round)
+	round := info.RoundNumberAt(now) + 1
+	if round <= e.lastBidRound || info.IsWithinAuctionCloseWindow(now) {
+		return time.Until(nextBidTime)
+	}
+
+	if err := e.syncResolutions(ctx); err != nil {
+		log.Warn("Failed to sync auction resolutions", "err", err)
+	}
+
+	if e.config.WithdrawAfter > 0 && now.Sub(e.startedAt) >= e.config.WithdrawAfter {
+		e.withdraw(ctx)
+		if e.withdrawalDone {
+			e.StopOnly()
+			return 0
+		}
+		return info.Round
+	}
+
+	if err := e.maybeTopUp(ctx); err != nil {
+		log.Warn("Failed to top up auction deposit", "err", err)
+	}
+
+	e.lastBidRound = round
+	amount := e.strategy.BidAmount(round, &MarketState{
+		Now:               now,
+		LastClearingPrice: e.lastClearingPrice,
+		LastResolvedRound: e.lastResolvedRound,
+		Ledger:            e.ledger,
+	})
+	if amount == nil || amount.Sign() <= 0 {
+		log.Info("Bidding strategy skipped round", "round", round)
+		return time.Until(nextBidTime)
+	}
+	bid, err := e.bidder.Bid(ctx, amount, e.controller)
+	if err != nil {
+		log.Warn("Failed to submit bid", "round", round, "amount", amount, "err", err)
+		return time.Until(nextBidTime)
+	}
+	if err := e.ledger.RecordBid(bid.Round, bid.Amount, now); err != nil {
+		log.Error("Failed to record bid in ledger", "round", bid.Round, "err", err)
+	}
+	log.Info("Submitted bid", "round", bid.Round, "amount", bid.Amount)
+	return time.Until(nextBidTime)
+}
+
+// syncResolutions reads the AuctionResolved events since the last sync to
+// learn the latest clearing price and whether our bids won.
+func (e *BiddingEngine) syncResolutions(ctx context.Context) error {
+	latest, err := e.bidder.client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if !e.resolutionsSynced {
+		e.nextResolutionBlock = arbmath.SaturatingUSub(latest, e.config.ResolutionLookbackBlocks)
+	}
+	if e.nextResolutionBlock > latest {
+		return nil
+	}
+	it, err := e.bidder.auctionContract.FilterAuctionResolved(&bind.FilterOpts{
+		Context: ctx,
+		Start:   e.nextResolutionBlock,
+		End:     &latest,
+	}, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.Next() {
+		event := it.Event
+		if e.lastClearingPrice == nil || event.Round >= e.lastResolvedRound {
+			e.lastClearingPrice = new(big.Int).Set(event.Price)
+			e.lastResolvedRound = event.Round
+		}
+		if err := e.ledger.RecordResolution(event.Round, event.FirstPriceBidder, event.Price, e.bidder.Address()); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	e.resolutionsSynced = true
+	e.nextResolutionBlock = latest + 1
+	return nil
+}
+
+func (e *BiddingEngine) maybeTopUp(ctx context.Context) error {
+	if e.config.MinBalanceGwei == 0 {
+		return nil
+	}
+	balance, err := e.bidder.AuctionBalance(ctx)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(gweiToWei(e.config.MinBalanceGwei)) >= 0 {
+		return nil
+	}
+	topUp := gweiToWei(e.config.TopUpGwei)
+	log.Info("Auction deposit below minimum, topping up", "balance", balance, "amount", topUp)
+	return e.bidder.Deposit(ctx, topUp)
+}
+
+// withdraw initiates the withdrawal of the deposit and then keeps trying to
+// finalize it on every call until the withdrawal delay has passed.
+func (e *BiddingEngine) withdraw(ctx context.Context) {
+	if !e.withdrawalInitiated {
+		balance, err := e.bidder.AuctionBalance(ctx)
+		if err != nil {
+			log.Warn("Failed to get auction deposit balance", "err", err)
+			return
+		}
+		if balance.Sign() > 0 {
+			if err := e.bidder.InitiateWithdrawal(ctx); err != nil {
+				log.Warn("Failed to initiate withdrawal", "err", err)
+				return
+			}
+			log.Info("Initiated withdrawal of auction deposit", "amount", balance)
+		}
+		e.withdrawalInitiated = true
+	}
+	withdrawable, err := e.bidder.WithdrawableBalance(ctx)
+	if err != nil {
+		log.Warn("Failed to get withdrawable balance", "err", err)
+		return
+	}
+	if withdrawable.Sign() == 0 {
+		balance, err := e.bidder.AuctionBalance(ctx)
+		if err == nil && balance.Sign() == 0 {
+			// Nothing left to withdraw.
+			e.withdrawalDone = true
+		}
+		return
+	}
+	if err := e.bidder.FinalizeWithdrawal(ctx); err != nil {
+		log.Warn("Failed to finalize withdrawal", "err", err)
+		return
+	}
+	log.Info("Finalized withdrawal of auction deposit", "amount", withdrawable)
+	e.withdrawalDone = true
+}
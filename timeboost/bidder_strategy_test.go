@@ -0,0 +1,255 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package timeboost
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+func requireBigEqual(t *testing.T, expected int64, actual *big.Int) {
+	t.Helper()
+	require.NotNil(t, actual)
+	require.Zero(t, actual.Cmp(big.NewInt(expected)), "expected %d, got %v", expected, actual)
+}
+
+func TestLastPricePlusIncrementBidStrategy(t *testing.T) {
+	strategy := &LastPricePlusIncrementBidStrategy{
+		Start:     big.NewInt(10),
+		Increment: big.NewInt(5),
+		Max:       big.NewInt(100),
+	}
+	market := &MarketState{Now: time.Now()}
+	requireBigEqual(t, 10, strategy.BidAmount(1, market))
+
+	market.LastClearingPrice = big.NewInt(40)
+	requireBigEqual(t, 45, strategy.BidAmount(2, market))
+
+	market.LastClearingPrice = big.NewInt(99)
+	requireBigEqual(t, 100, strategy.BidAmount(3, market))
+	// The strategy must not modify the market state.
+	requireBigEqual(t, 99, market.LastClearingPrice)
+}
+
+func TestBudgetCappedBidStrategy(t *testing.T) {
+	ledger, err := OpenBidLedger("")
+	require.NoError(t, err)
+	now := time.Now()
+	strategy := &BudgetCappedBidStrategy{
+		Inner:  &FixedBidStrategy{Amount: big.NewInt(30)},
+		Hourly: big.NewInt(50),
+		Daily:  big.NewInt(70),
+	}
+	market := &MarketState{Now: now, Ledger: ledger}
+	requireBigEqual(t, 30, strategy.BidAmount(1, market))
+
+	// An unresolved bid counts in full against the budget.
+	require.NoError(t, ledger.RecordBid(1, big.NewInt(30), now.Add(-time.Minute)))
+	requireBigEqual(t, 20, strategy.BidAmount(2, market))
+
+	// Once won, only the price paid counts.
+	self := common.HexToAddress("0x1")
+	require.NoError(t, ledger.RecordResolution(1, self, big.NewInt(10), self))
+	requireBigEqual(t, 30, strategy.BidAmount(2, market))
+
+	// Lost bids don't count at all.
+	require.NoError(t, ledger.RecordBid(2, big.NewInt(30), now.Add(-time.Minute)))
+	require.NoError(t, ledger.RecordResolution(2, common.HexToAddress("0x2"), big.NewInt(20), self))
+	requireBigEqual(t, 30, strategy.BidAmount(3, market))
+
+	// Spend older than an hour only counts against the daily budget.
+	require.NoError(t, ledger.RecordBid(3, big.NewInt(20), now.Add(-2*time.Hour)))
+	requireBigEqual(t, 30, strategy.BidAmount(4, market))
+	require.NoError(t, ledger.RecordBid(4, big.NewInt(50), now.Add(-3*time.Hour)))
+	require.Nil(t, strategy.BidAmount(5, market))
+}
+
+func TestBidLedgerPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.json")
+	ledger, err := OpenBidLedger(path)
+	require.NoError(t, err)
+	self := common.HexToAddress("0x1")
+	bidTime := time.Now().Truncate(time.Second)
+	require.NoError(t, ledger.RecordBid(5, big.NewInt(7), bidTime))
+	require.NoError(t, ledger.RecordBid(3, big.NewInt(9), bidTime))
+	require.NoError(t, ledger.RecordResolution(3, self, big.NewInt(4), self))
+	// Resolutions of rounds we didn't bid on are ignored.
+	require.NoError(t, ledger.RecordResolution(4, self, big.NewInt(4), self))
+
+	reopened, err := OpenBidLedger(path)
+	require.NoError(t, err)
+	entries := reopened.Entries()
+	require.Len(t, entries, 2)
+	require.Equal(t, uint64(3), entries[0].Round)
+	require.True(t, entries[0].Won)
+	requireBigEqual(t, 4, entries[0].PricePaid())
+	require.Equal(t, uint64(5), entries[1].Round)
+	require.False(t, entries[1].Resolved)
+	require.True(t, bidTime.Equal(entries[1].BidTime))
+	requireBigEqual(t, 11, reopened.CommittedSince(bidTime.Add(-time.Second)))
+}
+
+func TestBiddingEngineTopsUpDeposit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	testSetup := setupAuctionTest(t, ctx)
+	account := testSetup.accounts[1]
+
+	tx, err := testSetup.erc20Contract.Mint(testSetup.accounts[0].txOpts, account.accountAddr, big.NewInt(10*gwei))
+	require.NoError(t, err)
+	_, err = bind.WaitMined(ctx, testSetup.backend.Client(), tx)
+	require.NoError(t, err)
+
+	// The engine only deposits, so the bid validator is never called.
+	bc := setupBidderClient(t, ctx, account, testSetup, testSetup.endpoint)
+	config := DefaultBidStrategyConfig
+	config.BidGwei = 1
+	config.MinBalanceGwei = 2
+	config.TopUpGwei = 3
+	strategy, err := NewBidStrategy(&config)
+	require.NoError(t, err)
+	engine, err := NewBiddingEngine(bc, &config, strategy)
+	require.NoError(t, err)
+
+	require.NoError(t, engine.maybeTopUp(ctx))
+	balance, err := bc.AuctionBalance(ctx)
+	require.NoError(t, err)
+	requireBigEqual(t, 3*gwei, balance)
+
+	// Above the minimum, nothing more is deposited.
+	require.NoError(t, engine.maybeTopUp(ctx))
+	balance, err = bc.AuctionBalance(ctx)
+	require.NoError(t, err)
+	requireBigEqual(t, 3*gwei, balance)
+}
+
+// testBidValidator stands in for the bid validator, passing the submitted bids
+// on to the test, which resolves the auctions itself.
+type testBidValidator struct {
+	bids chan *JsonBid
+}
+
+func (v *testBidValidator) SubmitBid(bid *JsonBid) error {
+	v.bids <- bid
+	return nil
+}
+
+func TestBiddingEngineRounds(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	testSetup := setupAuctionTestWithRoundTiming(t, ctx, 10, 5, 1)
+	auctioneerOpts := testSetup.accounts[0].txOpts
+	self := testSetup.accounts[1]
+	rival := testSetup.accounts[2]
+
+	validator := &testBidValidator{bids: make(chan *JsonBid, 10)}
+	rpcServer := rpc.NewServer()
+	require.NoError(t, rpcServer.RegisterName("auctioneer", validator))
+	httpServer := httptest.NewServer(rpcServer)
+	defer httpServer.Close()
+
+	bc := setupBidderClient(t, ctx, self, testSetup, httpServer.URL)
+	rivalBc := setupBidderClient(t, ctx, rival, testSetup, httpServer.URL)
+	require.NoError(t, bc.Deposit(ctx, big.NewInt(100)))
+	require.NoError(t, rivalBc.Deposit(ctx, big.NewInt(100)))
+
+	config := DefaultBidStrategyConfig
+	config.BidLeadTime = 3 * time.Second
+	config.WithdrawAfter = 15 * time.Second
+	strategy := &LastPricePlusIncrementBidStrategy{
+		Start:     big.NewInt(10),
+		Increment: big.NewInt(5),
+	}
+	engine, err := NewBiddingEngine(bc, &config, strategy)
+	require.NoError(t, err)
+
+	// Starting at the beginning of a round, the engine bids 2 seconds into it
+	// and the next one, and withdraws on the third.
+	info := &bc.roundTimingInfo
+	time.Sleep(time.Until(info.TimeOfNextRound().Add(100 * time.Millisecond)))
+	engine.Start(ctx)
+	defer engine.StopAndWait()
+
+	// resolveRound waits for the engine's bid, has the rival bid against it and
+	// resolves the auction, returning the round.
+	resolveRound := func(expectedAmount int64, rivalAmount int64) uint64 {
+		t.Helper()
+		var bid *JsonBid
+		for bid == nil {
+			select {
+			case submitted := <-validator.bids:
+				if submitted.ExpressLaneController == self.accountAddr {
+					bid = submitted
+				}
+			case <-time.After(2 * info.Round):
+				t.Fatal("engine didn't bid")
+			}
+		}
+		requireBigEqual(t, expectedAmount, bid.Amount.ToInt())
+		rivalBid, err := rivalBc.Bid(ctx, big.NewInt(rivalAmount), common.Address{})
+		require.NoError(t, err)
+		require.Equal(t, uint64(bid.Round), rivalBid.Round)
+
+		first := express_lane_auctiongen.Bid{
+			ExpressLaneController: bid.ExpressLaneController,
+			Amount:                bid.Amount.ToInt(),
+			Signature:             bid.Signature,
+		}
+		second := express_lane_auctiongen.Bid{
+			ExpressLaneController: rivalBid.ExpressLaneController,
+			Amount:                rivalBid.Amount,
+			Signature:             rivalBid.Signature,
+		}
+		if rivalAmount > expectedAmount {
+			first, second = second, first
+		}
+		time.Sleep(time.Until(info.TimeOfNextRound().Add(-info.AuctionClosing + time.Second)))
+		tx, err := testSetup.expressLaneAuction.ResolveMultiBidAuction(auctioneerOpts, first, second)
+		require.NoError(t, err)
+		receipt, err := bind.WaitMined(ctx, testSetup.backend.Client(), tx)
+		require.NoError(t, err)
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		return uint64(bid.Round)
+	}
+
+	// The engine starts at its starting bid and loses, the rival pays 10.
+	lostRound := resolveRound(10, 20)
+	// It then outbids the last clearing price and wins, paying the rival's 12.
+	wonRound := resolveRound(15, 12)
+	require.Equal(t, lostRound+1, wonRound)
+
+	// The engine withdraws its deposit once the withdrawal delay has passed,
+	// and stops.
+	require.Eventually(t, engine.Stopped, 5*info.Round, time.Second)
+	entries := engine.Ledger().Entries()
+	require.Len(t, entries, 2)
+	require.Equal(t, lostRound, entries[0].Round)
+	require.True(t, entries[0].Resolved)
+	require.False(t, entries[0].Won)
+	require.Equal(t, rival.accountAddr, entries[0].Winner)
+	requireBigEqual(t, 0, entries[0].PricePaid())
+	require.Equal(t, wonRound, entries[1].Round)
+	require.True(t, entries[1].Won)
+	requireBigEqual(t, 12, entries[1].PricePaid())
+
+	balance, err := bc.AuctionBalance(ctx)
+	require.NoError(t, err)
+	requireBigEqual(t, 0, balance)
+	tokens, err := testSetup.erc20Contract.BalanceOf(&bind.CallOpts{Context: ctx}, self.accountAddr)
+	require.NoError(t, err)
+	requireBigEqual(t, 88, tokens)
+}
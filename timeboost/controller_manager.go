@@ -0,0 +1,371 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package timeboost
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/offchainlabs/nitro/cmd/genericconf"
+	"github.com/offchainlabs/nitro/cmd/util"
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+type ControllerManagerConfigFetcher func() *ControllerManagerConfig
+
+type ControllerManagerConfig struct {
+	Wallet                 genericconf.WalletConfig `koanf:"wallet"`
+	ArbitrumNodeEndpoint   string                   `koanf:"arbitrum-node-endpoint"`
+	SequencerEndpoint      string                   `koanf:"sequencer-endpoint"`
+	AuctionContractAddress string                   `koanf:"auction-contract-address"`
+	TransferGasLimit       uint64                   `koanf:"transfer-gas-limit"`
+	ResolutionPollInterval time.Duration            `koanf:"resolution-poll-interval"`
+	VerifyTimeout          time.Duration            `koanf:"verify-timeout"`
+	Round                  uint64                   `koanf:"round"`
+	NewController          string                   `koanf:"new-controller"`
+	Transfers              []string                 `koanf:"transfers"`
+	StartNonce             int64                    `koanf:"start-nonce"`
+	PresignedFile          string                   `koanf:"presigned-file"`
+	Transferor             string                   `koanf:"transferor"`
+	FixedUntilRound        uint64                   `koanf:"fixed-until-round"`
+}
+
+var DefaultControllerManagerConfig = ControllerManagerConfig{
+	ArbitrumNodeEndpoint:   "http://localhost:8547",
+	TransferGasLimit:       1_000_000,
+	ResolutionPollInterval: time.Second,
+	VerifyTimeout:          30 * time.Second,
+	StartNonce:             -1,
+}
+
+func ControllerManagerConfigAddOptions(f *pflag.FlagSet) {
+	genericconf.WalletConfigAddOptions("wallet", f, "wallet of the express lane controller or its transferor")
+	f.String("arbitrum-node-endpoint", DefaultControllerManagerConfig.ArbitrumNodeEndpoint, "arbitrum node RPC http endpoint")
+	f.String("sequencer-endpoint", DefaultControllerManagerConfig.SequencerEndpoint, "sequencer RPC endpoint used to verify the controller seen by the sequencer, defaults to the arbitrum node endpoint")
+	f.String("auction-contract-address", DefaultControllerManagerConfig.AuctionContractAddress, "express lane auction contract address")
+	f.Uint64("transfer-gas-limit", DefaultControllerManagerConfig.TransferGasLimit, "gas limit used for pre-signed transfers, as gas can't be estimated before the round is resolved")
+	f.Duration("resolution-poll-interval", DefaultControllerManagerConfig.ResolutionPollInterval, "how often to check whether a round has been resolved before sending a pre-signed transfer")
+	f.Duration("verify-timeout", DefaultControllerManagerConfig.VerifyTimeout, "how long to wait for the sequencer to observe a new express lane controller")
+	f.Uint64("round", DefaultControllerManagerConfig.Round, "round to transfer or verify the express lane control of")
+	f.String("new-controller", DefaultControllerManagerConfig.NewController, "address to transfer the express lane control to, or the controller expected when verifying")
+	f.StringSlice("transfers", DefaultControllerManagerConfig.Transfers, "transfers to pre-sign, as a list of round:address")
+	f.Int64("start-nonce", DefaultControllerManagerConfig.StartNonce, "nonce of the first pre-signed transfer, negative uses the pending nonce of the account")
+	f.String("presigned-file", DefaultControllerManagerConfig.PresignedFile, "file the pre-signed transfers are written to and sent from")
+	f.String("transferor", DefaultControllerManagerConfig.Transferor, "address allowed to transfer the express lane control of our rounds")
+	f.Uint64("fixed-until-round", DefaultControllerManagerConfig.FixedUntilRound, "round until which the transferor can't be changed")
+}
+
+// ParseControllerTransfers parses transfers given as round:address.
+func ParseControllerTransfers(transfers []string) ([]ControllerTransfer, error) {
+	parsed := make([]ControllerTransfer, 0, len(transfers))
+	for _, transfer := range transfers {
+		parts := strings.Split(transfer, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid transfer %q, expected round:address", transfer)
+		}
+		round, err := strconv.ParseUint(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid round in transfer %q: %w", transfer, err)
+		}
+		if !common.IsHexAddress(parts[1]) {
+			return nil, fmt.Errorf("invalid address in transfer %q", transfer)
+		}
+		parsed = append(parsed, ControllerTransfer{
+			Round:         round,
+			NewController: common.HexToAddress(parts[1]),
+		})
+	}
+	return parsed, nil
+}
+
+// ControllerTransfer is a transfer of the express lane control of a round.
+type ControllerTransfer struct {
+	Round         uint64         `json:"round"`
+	NewController common.Address `json:"newController"`
+}
+
+// PresignedTransfer is a signed transfer transaction that can be sent once
+// the auction for its round has been resolved.
+type PresignedTransfer struct {
+	ControllerTransfer
+	Nonce uint64        `json:"nonce"`
+	Tx    hexutil.Bytes `json:"tx"`
+}
+
+// ControllerManager lets an express lane controller, or the transferor it
+// delegated to, hand the control of its rounds to other addresses.
+type ControllerManager struct {
+	config                 ControllerManagerConfig
+	auctionContractAddress common.Address
+	txOpts                 *bind.TransactOpts
+	client                 *ethclient.Client
+	sequencerClient        *rpc.Client
+	auctionContract        *express_lane_auctiongen.ExpressLaneAuction
+	roundTimingInfo        RoundTimingInfo
+}
+
+func NewControllerManager(ctx context.Context, configFetcher ControllerManagerConfigFetcher) (*ControllerManager, error) {
+	cfg := configFetcher()
+	if cfg.AuctionContractAddress == "" {
+		return nil, fmt.Errorf("auction contract address cannot be empty")
+	}
+	auctionContractAddr := common.HexToAddress(cfg.AuctionContractAddress)
+	client, err := rpc.DialContext(ctx, cfg.ArbitrumNodeEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	arbClient := ethclient.NewClient(client)
+	chainId, err := arbClient.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	auctionContract, err := express_lane_auctiongen.NewExpressLaneAuction(auctionContractAddr, arbClient)
+	if err != nil {
+		return nil, err
+	}
+	rawRoundTimingInfo, err := auctionContract.RoundTimingInfo(&bind.CallOpts{
+		Context: ctx,
+	})
+	if err != nil {
+		return nil, err
+	}
+	roundTimingInfo, err := NewRoundTimingInfo(rawRoundTimingInfo)
+	if err != nil {
+		return nil, err
+	}
+	txOpts, _, err := util.OpenWallet("controller-manager", &cfg.Wallet, chainId)
+	if err != nil {
+		return nil, errors.Wrap(err, "opening wallet")
+	}
+	sequencerClient := client
+	if cfg.SequencerEndpoint != "" && cfg.SequencerEndpoint != cfg.ArbitrumNodeEndpoint {
+		sequencerClient, err = rpc.DialContext(ctx, cfg.SequencerEndpoint)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &ControllerManager{
+		config:                 *cfg,
+		auctionContractAddress: auctionContractAddr,
+		txOpts:                 txOpts,
+		client:                 arbClient,
+		sequencerClient:        sequencerClient,
+		auctionContract:        auctionContract,
+		roundTimingInfo:        *roundTimingInfo,
+	}, nil
+}
+
+func (m *ControllerManager) Address() common.Address {
+	return m.txOpts.From
+}
+
+func (m *ControllerManager) RoundTimingInfo() *RoundTimingInfo {
+	return &m.roundTimingInfo
+}
+
+// ResolvedController returns the on-chain express lane controller of the round,
+// or ErrNoOnchainController if the round isn't one of the latest resolved rounds.
+func (m *ControllerManager) ResolvedController(ctx context.Context, round uint64) (common.Address, error) {
+	r0, r1, err := m.auctionContract.ResolvedRounds(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return common.Address{}, err
+	}
+	for _, resolved := range []express_lane_auctiongen.ELCRound{r0, r1} {
+		if resolved.Round == round && resolved.ExpressLaneController != (common.Address{}) {
+			return resolved.ExpressLaneController, nil
+		}
+	}
+	return common.Address{}, errors.Wrapf(ErrNoOnchainController, "round %d is not resolved", round)
+}
+
+// checkCanTransfer makes sure the round can still be transferred and that our
+// account is either its controller or the transferor of its controller.
+func (m *ControllerManager) checkCanTransfer(ctx context.Context, round uint64) error {
+	if currentRound := m.roundTimingInfo.RoundNumber(); round < currentRound {
+		return errors.Wrapf(ErrBadRoundNumber, "round %d has already ended, current round is %d", round, currentRound)
+	}
+	controller, err := m.ResolvedController(ctx, round)
+	if err != nil {
+		return err
+	}
+	transferor, err := m.auctionContract.TransferorOf(&bind.CallOpts{Context: ctx}, controller)
+	if err != nil {
+		return err
+	}
+	if transferor.Addr != (common.Address{}) {
+		if transferor.Addr != m.Address() {
+			return errors.Wrapf(ErrNotExpressLaneController, "controller %v of round %d delegated transfers to %v", controller, round, transferor.Addr)
+		}
+		return nil
+	}
+	if controller != m.Address() {
+		return errors.Wrapf(ErrNotExpressLaneController, "round %d is controlled by %v", round, controller)
+	}
+	return nil
+}
+
+// TransferControl transfers the express lane control of a resolved round,
+// which can be the current round or the next one, to another address.
+func (m *ControllerManager) TransferControl(ctx context.Context, round uint64, newController common.Address) (*types.Receipt, error) {
+	if err := m.checkCanTransfer(ctx, round); err != nil {
+		return nil, err
+	}
+	opts := *m.txOpts
+	opts.Context = ctx
+	tx, err := m.auctionContract.TransferExpressLaneController(&opts, round, newController)
+	if err != nil {
+		return nil, err
+	}
+	return m.waitForSuccess(ctx, tx, "transfer express lane controller")
+}
+
+// SetTransferor delegates the right to transfer the control of our rounds to
+// another address. The transferor can't be changed until fixedUntilRound.
+func (m *ControllerManager) SetTransferor(ctx context.Context, transferor common.Address, fixedUntilRound uint64) (*types.Receipt, error) {
+	opts := *m.txOpts
+	opts.Context = ctx
+	tx, err := m.auctionContract.SetTransferor(&opts, express_lane_auctiongen.Transferor{
+		Addr:            transferor,
+		FixedUntilRound: fixedUntilRound,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m.waitForSuccess(ctx, tx, "set transferor")
+}
+
+// PresignTransfers signs transfers for future rounds without sending them.
+// They use consecutive nonces starting at startNonce, or at the account's
+// pending nonce if it's nil, so they must be sent in order.
+func (m *ControllerManager) PresignTransfers(ctx context.Context, transfers []ControllerTransfer, startNonce *uint64) ([]PresignedTransfer, error) {
+	var nonce uint64
+	if startNonce != nil {
+		nonce = *startNonce
+	} else {
+		var err error
+		nonce, err = m.client.PendingNonceAt(ctx, m.Address())
+		if err != nil {
+			return nil, err
+		}
+	}
+	currentRound := m.roundTimingInfo.RoundNumber()
+	presigned := make([]PresignedTransfer, 0, len(transfers))
+	for _, transfer := range transfers {
+		if transfer.Round < currentRound {
+			return nil, errors.Wrapf(ErrBadRoundNumber, "round %d has already ended, current round is %d", transfer.Round, currentRound)
+		}
+		opts := *m.txOpts
+		opts.Context = ctx
+		opts.NoSend = true
+		opts.Nonce = new(big.Int).SetUint64(nonce)
+		opts.GasLimit = m.config.TransferGasLimit
+		tx, err := m.auctionContract.TransferExpressLaneController(&opts, transfer.Round, transfer.NewController)
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		presigned = append(presigned, PresignedTransfer{
+			ControllerTransfer: transfer,
+			Nonce:              nonce,
+			Tx:                 encoded,
+		})
+		nonce++
+	}
+	return presigned, nil
+}
+
+// SendPresignedTransfers sends pre-signed transfers in nonce order, waiting
+// for the auction of each round to be resolved before sending its transfer.
+func (m *ControllerManager) SendPresignedTransfers(ctx context.Context, transfers []PresignedTransfer) error {
+	for _, transfer := range transfers {
+		if err := m.waitForResolution(ctx, transfer.Round); err != nil {
+			return err
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(transfer.Tx); err != nil {
+			return err
+		}
+		if err := m.client.SendTransaction(ctx, tx); err != nil {
+			return errors.Wrapf(err, "sending transfer of round %d", transfer.Round)
+		}
+		if _, err := m.waitForSuccess(ctx, tx, "transfer express lane controller"); err != nil {
+			return errors.Wrapf(err, "transfer of round %d", transfer.Round)
+		}
+		log.Info("Transferred express lane control", "round", transfer.Round, "newController", transfer.NewController, "tx", tx.Hash())
+	}
+	return nil
+}
+
+func (m *ControllerManager) waitForResolution(ctx context.Context, round uint64) error {
+	for {
+		_, err := m.ResolvedController(ctx, round)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrNoOnchainController) {
+			return err
+		}
+		if currentRound := m.roundTimingInfo.RoundNumber(); round < currentRound {
+			// Still send the transfer, otherwise the nonces of the later ones would be stuck
+			log.Warn("Round ended without being resolved, the pre-signed transfer will fail", "round", round, "currentRound", currentRound)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(m.config.ResolutionPollInterval):
+		}
+	}
+}
+
+// VerifyController waits for the sequencer's express lane tracker to report
+// the expected controller for the round.
+func (m *ControllerManager) VerifyController(ctx context.Context, round uint64, expected common.Address) error {
+	ctx, cancel := context.WithTimeout(ctx, m.config.VerifyTimeout)
+	defer cancel()
+	for {
+		var controller common.Address
+		err := m.sequencerClient.CallContext(ctx, &controller, "timeboost_roundController", hexutil.Uint64(round))
+		if err == nil && controller == expected {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return errors.Wrapf(err, "sequencer did not report a controller for round %d", round)
+			}
+			return fmt.Errorf("sequencer reports controller %v for round %d instead of %v", controller, round, expected)
+		case <-time.After(m.config.ResolutionPollInterval):
+		}
+	}
+}
+
+func (m *ControllerManager) waitForSuccess(ctx context.Context, tx *types.Transaction, action string) (*types.Receipt, error) {
+	receipt, err := bind.WaitMined(ctx, m.client, tx)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, fmt.Errorf("%s failed", action)
+	}
+	return receipt, nil
+}
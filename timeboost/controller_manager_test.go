@@ -0,0 +1,94 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package timeboost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/offchainlabs/nitro/cmd/genericconf"
+	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
+)
+
+func TestParseControllerTransfers(t *testing.T) {
+	transfers, err := ParseControllerTransfers([]string{
+		"10:0x0000000000000000000000000000000000000001",
+		"12:0x0000000000000000000000000000000000000002",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []ControllerTransfer{
+		{Round: 10, NewController: common.HexToAddress("0x1")},
+		{Round: 12, NewController: common.HexToAddress("0x2")},
+	}, transfers)
+
+	for _, invalid := range []string{"10", "x:0x0000000000000000000000000000000000000001", "10:0x1234"} {
+		_, err := ParseControllerTransfers([]string{invalid})
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestControllerManagerPresignTransfers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	testSetup := setupAuctionTest(t, ctx)
+	account := testSetup.accounts[1]
+
+	manager, err := NewControllerManager(ctx, func() *ControllerManagerConfig {
+		config := DefaultControllerManagerConfig
+		config.AuctionContractAddress = testSetup.expressLaneAuctionAddr.Hex()
+		config.ArbitrumNodeEndpoint = testSetup.endpoint
+		config.Wallet = genericconf.WalletConfig{
+			PrivateKey: fmt.Sprintf("%x", account.privKey.D.Bytes()),
+		}
+		return &config
+	})
+	require.NoError(t, err)
+
+	// Nothing has been resolved yet, so nothing can be transferred.
+	round := manager.RoundTimingInfo().RoundNumber() + 1
+	_, err = manager.ResolvedController(ctx, round)
+	require.True(t, errors.Is(err, ErrNoOnchainController))
+	_, err = manager.TransferControl(ctx, round, testSetup.accounts[2].accountAddr)
+	require.True(t, errors.Is(err, ErrNoOnchainController))
+
+	transfers := []ControllerTransfer{
+		{Round: round, NewController: testSetup.accounts[2].accountAddr},
+		{Round: round + 1, NewController: testSetup.accounts[3].accountAddr},
+	}
+	startNonce := uint64(7)
+	presigned, err := manager.PresignTransfers(ctx, transfers, &startNonce)
+	require.NoError(t, err)
+	require.Len(t, presigned, 2)
+
+	auctionAbi, err := express_lane_auctiongen.ExpressLaneAuctionMetaData.GetAbi()
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(testSetup.chainId)
+	for i, transfer := range presigned {
+		require.Equal(t, transfers[i], transfer.ControllerTransfer)
+		tx := new(types.Transaction)
+		require.NoError(t, tx.UnmarshalBinary(transfer.Tx))
+		require.Equal(t, startNonce+uint64(i), tx.Nonce())
+		require.Equal(t, transfer.Nonce, tx.Nonce())
+		require.Equal(t, DefaultControllerManagerConfig.TransferGasLimit, tx.Gas())
+		require.Equal(t, testSetup.expressLaneAuctionAddr, *tx.To())
+		sender, err := types.Sender(signer, tx)
+		require.NoError(t, err)
+		require.Equal(t, account.accountAddr, sender)
+
+		method, err := auctionAbi.MethodById(tx.Data()[:4])
+		require.NoError(t, err)
+		require.Equal(t, "transferExpressLaneController", method.Name)
+		args, err := method.Inputs.Unpack(tx.Data()[4:])
+		require.NoError(t, err)
+		require.Equal(t, transfers[i].Round, args[0])
+		require.Equal(t, transfers[i].NewController, args[1])
+	}
+}
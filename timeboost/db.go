@@ -11,6 +11,8 @@ import (
 
 	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 const sqliteFileName = "validated_bids.db?_journal_mode=WAL"
@@ -180,3 +182,27 @@ func (d *SqliteDatabase) DeleteBids(round uint64) error {
 	_, err := d.sqlDB.Exec(query, round)
 	return err
 }
+
+// QueryBids returns the bids of the rounds between fromRound and toRound
+// inclusive, in the order they were received, optionally only those of a bidder.
+// A limit of 0 means no limit.
+func (d *SqliteDatabase) QueryBids(fromRound, toRound uint64, bidder *common.Address, limit int) ([]*SqliteDatabaseBid, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	query := "SELECT * FROM Bids WHERE Round >= ? AND Round <= ?"
+	args := []interface{}{fromRound, toRound}
+	if bidder != nil {
+		query += " AND Bidder = ?"
+		args = append(args, bidder.Hex())
+	}
+	query += " ORDER BY Round ASC, Id ASC"
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+	var bids []*SqliteDatabaseBid
+	if err := d.sqlDB.Select(&bids, query, args...); err != nil {
+		return nil, err
+	}
+	return bids, nil
+}
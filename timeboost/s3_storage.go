@@ -4,8 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/csv"
+	"errors"
 	"fmt"
+	"path"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -64,10 +68,16 @@ func S3StorageServiceConfigAddOptions(prefix string, f *pflag.FlagSet) {
 	f.Int(prefix+".max-db-rows", DefaultS3StorageServiceConfig.MaxDbRows, "when the sql db is very large, this enables reading of db in chunks instead of all at once which might cause OOM")
 }
 
+// batchLister lists the uploaded batches, it's satisfied by *s3.Client
+type batchLister interface {
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+}
+
 type S3StorageService struct {
 	stopwaiter.StopWaiter
 	config                *S3StorageServiceConfig
 	client                s3client.FullClient
+	lister                batchLister
 	sqlDB                 *SqliteDatabase
 	bucket                string
 	objectPrefix          string
@@ -82,6 +92,7 @@ func NewS3StorageService(config *S3StorageServiceConfig, sqlDB *SqliteDatabase)
 	return &S3StorageService{
 		config:       config,
 		client:       client,
+		lister:       client.Client(),
 		sqlDB:        sqlDB,
 		bucket:       config.Bucket,
 		objectPrefix: config.ObjectPrefix,
@@ -116,10 +127,110 @@ func (s *S3StorageService) Start(ctx context.Context) {
 // Used in padding round numbers to a fixed length for naming the batch being uploaded to s3. <firstRound>-<lastRound>
 const fixedRoundStrLen = 7
 
+const batchDirectory = "validated-timeboost-bids/"
+const batchSuffix = ".csv.gzip"
+
 func (s *S3StorageService) getBatchName(firstRound, lastRound uint64) string {
 	padder := "%0" + strconv.Itoa(fixedRoundStrLen) + "d"
 	now := time.Now()
-	return fmt.Sprintf("%svalidated-timeboost-bids/%d/%02d/%02d/"+padder+"-"+padder+".csv.gzip", s.objectPrefix, now.Year(), now.Month(), now.Day(), firstRound, lastRound)
+	return fmt.Sprintf("%s"+batchDirectory+"%d/%02d/%02d/"+padder+"-"+padder+batchSuffix, s.objectPrefix, now.Year(), now.Month(), now.Day(), firstRound, lastRound)
+}
+
+// archivedBatch is a batch of bids uploaded to s3, covering the rounds firstRound to lastRound inclusive.
+type archivedBatch struct {
+	key        string
+	firstRound uint64
+	lastRound  uint64
+}
+
+// parseBatchName extracts the round range from the name of an uploaded batch.
+func parseBatchName(key string) (archivedBatch, bool) {
+	name := path.Base(key)
+	if !strings.HasSuffix(name, batchSuffix) {
+		return archivedBatch{}, false
+	}
+	rounds := strings.Split(strings.TrimSuffix(name, batchSuffix), "-")
+	if len(rounds) != 2 {
+		return archivedBatch{}, false
+	}
+	firstRound, err := strconv.ParseUint(rounds[0], 10, 64)
+	if err != nil {
+		return archivedBatch{}, false
+	}
+	lastRound, err := strconv.ParseUint(rounds[1], 10, 64)
+	if err != nil || lastRound < firstRound {
+		return archivedBatch{}, false
+	}
+	return archivedBatch{key: key, firstRound: firstRound, lastRound: lastRound}, true
+}
+
+// listBatches returns the uploaded batches containing bids of rounds between fromRound and toRound inclusive.
+func (s *S3StorageService) listBatches(ctx context.Context, fromRound, toRound uint64) ([]archivedBatch, error) {
+	if s.lister == nil {
+		return nil, errors.New("listing of s3 batches is not supported by the s3 client")
+	}
+	var batches []archivedBatch
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(s.bucket),
+		Prefix: aws.String(s.objectPrefix + batchDirectory),
+	}
+	for {
+		output, err := s.lister.ListObjectsV2(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range output.Contents {
+			batch, ok := parseBatchName(aws.ToString(object.Key))
+			if !ok {
+				continue
+			}
+			if batch.lastRound >= fromRound && batch.firstRound <= toRound {
+				batches = append(batches, batch)
+			}
+		}
+		if !aws.ToBool(output.IsTruncated) || output.NextContinuationToken == nil {
+			break
+		}
+		input.ContinuationToken = output.NextContinuationToken
+	}
+	sort.Slice(batches, func(i, j int) bool { return batches[i].firstRound < batches[j].firstRound })
+	return batches, nil
+}
+
+// readBatch downloads an uploaded batch and parses the bids in it.
+func (s *S3StorageService) readBatch(ctx context.Context, key string) ([]*SqliteDatabaseBid, error) {
+	data, err := s.downloadBatch(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error parsing batch %s: %w", key, err)
+	}
+	bids := make([]*SqliteDatabaseBid, 0, len(records))
+	for i, record := range records {
+		if i == 0 {
+			// Header
+			continue
+		}
+		if len(record) != 7 {
+			return nil, fmt.Errorf("unexpected number of fields %d in record %d of batch %s", len(record), i, key)
+		}
+		round, err := strconv.ParseUint(record[4], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid round in record %d of batch %s: %w", i, key, err)
+		}
+		bids = append(bids, &SqliteDatabaseBid{
+			ChainId:                record[0],
+			Bidder:                 record[1],
+			ExpressLaneController:  record[2],
+			AuctionContractAddress: record[3],
+			Round:                  round,
+			Amount:                 record[5],
+			Signature:              record[6],
+		})
+	}
+	return bids, nil
 }
 func (s *S3StorageService) uploadBatch(ctx context.Context, batch []byte, firstRound, lastRound uint64) error {
 	compressedData, err := gzip.CompressGzip(batch)
@@ -138,7 +249,6 @@ func (s *S3StorageService) uploadBatch(ctx context.Context, batch []byte, firstR
 	return nil
 }
 
-// downloadBatch is only used for testing purposes
 func (s *S3StorageService) downloadBatch(ctx context.Context, key string) ([]byte, error) {
 	buf := manager.NewWriteAtBuffer([]byte{})
 	if _, err := s.client.Download(ctx, buf, &s3.GetObjectInput{
@@ -8,10 +8,13 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -54,6 +57,16 @@ func (m *mockS3FullClient) Download(ctx context.Context, w io.WriterAt, input *s
 	return 0, errors.New("key not found")
 }
 
+func (m *mockS3FullClient) ListObjectsV2(ctx context.Context, input *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	output := &s3.ListObjectsV2Output{}
+	for key := range m.data {
+		if strings.HasPrefix(key, aws.ToString(input.Prefix)) {
+			output.Contents = append(output.Contents, types.Object{Key: aws.String(key)})
+		}
+	}
+	return output, nil
+}
+
 func TestS3StorageServiceUploadAndDownload(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
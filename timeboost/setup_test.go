@@ -22,6 +22,7 @@ import (
 	"github.com/offchainlabs/nitro/solgen/go/express_lane_auctiongen"
 	"github.com/offchainlabs/nitro/solgen/go/localgen"
 	"github.com/offchainlabs/nitro/timeboost/bindings"
+	"github.com/offchainlabs/nitro/util/arbmath"
 )
 
 type auctionSetup struct {
@@ -40,6 +41,12 @@ type auctionSetup struct {
 }
 
 func setupAuctionTest(t testing.TB, ctx context.Context) *auctionSetup {
+	return setupAuctionTestWithRoundTiming(t, ctx, 60, 15, 15)
+}
+
+// setupAuctionTestWithRoundTiming deploys an auction whose rounds start on
+// the next multiple of the round duration.
+func setupAuctionTestWithRoundTiming(t testing.TB, ctx context.Context, bidRoundSeconds, auctionClosingSeconds, reserveSubmissionSeconds uint64) *auctionSetup {
 	accs, backend, endpoint := setupAccounts(t, 10)
 
 	go func() {
@@ -97,12 +104,10 @@ func setupAuctionTest(t testing.TB, ctx context.Context) *auctionSetup {
 
 	expressLaneAddr := common.HexToAddress("0x2424242424242424242424242424242424242424")
 
-	// Calculate the number of seconds until the next minute
-	// and the next timestamp that is a multiple of a minute.
+	// Calculate the next timestamp that is a multiple of the round duration.
 	now := time.Now()
-	roundDuration := time.Minute
-	waitTime := roundDuration - time.Duration(now.Second())*time.Second - time.Duration(now.Nanosecond())
-	initialTime := now.Add(waitTime)
+	roundDuration := arbmath.SaturatingCast[time.Duration](bidRoundSeconds) * time.Second
+	initialTime := now.Truncate(roundDuration).Add(roundDuration)
 	initialTimestamp := big.NewInt(initialTime.Unix())
 	t.Logf("Initial timestamp for express lane auctions: %v", initialTime)
 
@@ -110,9 +115,6 @@ func setupAuctionTest(t testing.TB, ctx context.Context) *auctionSetup {
 	auctioneer := opts.From
 	beneficiary := opts.From
 	biddingToken := erc20Addr
-	bidRoundSeconds := uint64(60)
-	auctionClosingSeconds := uint64(15)
-	reserveSubmissionSeconds := uint64(15)
 	minReservePrice := big.NewInt(1) // 1 wei.
 	roleAdmin := opts.From
 	tx, err = auctionContract.Initialize(
@@ -147,7 +149,7 @@ func setupAuctionTest(t testing.TB, ctx context.Context) *auctionSetup {
 		erc20Addr:              erc20Addr,
 		erc20Contract:          erc20,
 		initialTimestamp:       now,
-		roundDuration:          time.Minute,
+		roundDuration:          roundDuration,
 		expressLaneAddr:        expressLaneAddr,
 		beneficiaryAddr:        beneficiary,
 		accounts:               accs,
@@ -0,0 +1,386 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/metrics"
+
+	"github.com/offchainlabs/nitro/util/containers"
+	"github.com/offchainlabs/nitro/util/stopwaiter"
+	"github.com/offchainlabs/nitro/validator"
+	"github.com/offchainlabs/nitro/validator/server_common"
+)
+
+const (
+	PolicyLeastLoaded = "least-loaded"
+	PolicyWeighted    = "weighted"
+)
+
+type LoadBalancerConfig struct {
+	Enable          bool          `koanf:"enable"`
+	Policy          string        `koanf:"policy"`
+	Weights         []int         `koanf:"weights"`
+	PollInterval    time.Duration `koanf:"poll-interval"`
+	FailuresToEject int           `koanf:"failures-to-eject"`
+	InitialBackoff  time.Duration `koanf:"initial-backoff"`
+	MaxBackoff      time.Duration `koanf:"max-backoff"`
+}
+
+var DefaultLoadBalancerConfig = LoadBalancerConfig{
+	Enable:          false,
+	Policy:          PolicyLeastLoaded,
+	Weights:         []int{},
+	PollInterval:    10 * time.Second,
+	FailuresToEject: 3,
+	InitialBackoff:  5 * time.Second,
+	MaxBackoff:      5 * time.Minute,
+}
+
+func LoadBalancerConfigAddOptions(prefix string, f *pflag.FlagSet) {
+	f.Bool(prefix+".enable", DefaultLoadBalancerConfig.Enable, "balance the validations across all the validation servers, instead of using the first one supporting the module root")
+	f.String(prefix+".policy", DefaultLoadBalancerConfig.Policy, "policy choosing the validation server of each validation ('least-loaded' relative to the room reported by the servers, or 'weighted' relative to the configured weights)")
+	f.IntSlice(prefix+".weights", DefaultLoadBalancerConfig.Weights, "weights of the validation servers, in the order of the validation server configs, for the weighted policy (empty for equal weights)")
+	f.Duration(prefix+".poll-interval", DefaultLoadBalancerConfig.PollInterval, "interval between polls of the room and module roots of the validation servers")
+	f.Int(prefix+".failures-to-eject", DefaultLoadBalancerConfig.FailuresToEject, "number of consecutive failed polls or validations ejecting a validation server")
+	f.Duration(prefix+".initial-backoff", DefaultLoadBalancerConfig.InitialBackoff, "time before an ejected validation server is polled again, doubled on each failed poll")
+	f.Duration(prefix+".max-backoff", DefaultLoadBalancerConfig.MaxBackoff, "maximum time before an ejected validation server is polled again")
+}
+
+func (c *LoadBalancerConfig) Validate(servers int) error {
+	if !c.Enable {
+		return nil
+	}
+	if c.Policy != PolicyLeastLoaded && c.Policy != PolicyWeighted {
+		return fmt.Errorf("invalid validation load balancer policy %q", c.Policy)
+	}
+	if len(c.Weights) != 0 && len(c.Weights) != servers {
+		return fmt.Errorf("validation load balancer has %d weights for %d validation servers", len(c.Weights), servers)
+	}
+	for _, weight := range c.Weights {
+		if weight <= 0 {
+			return errors.New("validation load balancer weights must be greater than 0")
+		}
+	}
+	if c.PollInterval <= 0 {
+		return errors.New("validation load balancer poll-interval must be greater than 0")
+	}
+	if c.FailuresToEject <= 0 {
+		return errors.New("validation load balancer failures-to-eject must be greater than 0")
+	}
+	if c.InitialBackoff <= 0 || c.MaxBackoff < c.InitialBackoff {
+		return errors.New("validation load balancer backoffs must be positive, with max-backoff at least initial-backoff")
+	}
+	return nil
+}
+
+// balancedClient is a validation server client of the load balancer.
+type balancedClient interface {
+	validator.ExecutionSpawner
+	Started() bool
+	ServerRoom(ctx context.Context) (int, error)
+	ServerWasmModuleRoots(ctx context.Context) ([]common.Hash, error)
+}
+
+type balancedServer struct {
+	client balancedClient
+	weight int
+
+	// protected by the load balancer's mutex
+	healthy         bool
+	failures        int
+	backoff         time.Duration
+	retryAt         time.Time
+	room            int
+	inFlight        int
+	moduleRoots     []common.Hash
+	healthyGauge    *metrics.Gauge
+	roomGauge       *metrics.Gauge
+	inFlightGauge   *metrics.Gauge
+	launchedCounter *metrics.Counter
+	failedCounter   *metrics.Counter
+}
+
+func (s *balancedServer) supports(moduleRoot common.Hash) bool {
+	for _, root := range s.moduleRoots {
+		if root == moduleRoot {
+			return true
+		}
+	}
+	return false
+}
+
+// LoadBalancer spreads the validations across validation servers, by the room
+// they report or by configured weights, ejecting the servers failing polls or
+// validations until they answer polls again.
+type LoadBalancer struct {
+	stopwaiter.StopWaiter
+	config  func() *LoadBalancerConfig
+	mutex   sync.Mutex
+	servers []*balancedServer
+}
+
+func NewLoadBalancer(config func() *LoadBalancerConfig, clients []*ExecutionClient) *LoadBalancer {
+	balanced := make([]balancedClient, 0, len(clients))
+	for _, client := range clients {
+		balanced = append(balanced, client)
+	}
+	return newLoadBalancer(config, balanced)
+}
+
+func newLoadBalancer(config func() *LoadBalancerConfig, clients []balancedClient) *LoadBalancer {
+	b := &LoadBalancer{config: config}
+	weights := config().Weights
+	for i, client := range clients {
+		weight := 1
+		if i < len(weights) {
+			weight = weights[i]
+		}
+		prefix := fmt.Sprintf("arb/validator/loadbalancer/server%d/", i)
+		b.servers = append(b.servers, &balancedServer{
+			client:          client,
+			weight:          weight,
+			healthyGauge:    metrics.GetOrRegisterGauge(prefix+"healthy", nil),
+			roomGauge:       metrics.GetOrRegisterGauge(prefix+"room", nil),
+			inFlightGauge:   metrics.GetOrRegisterGauge(prefix+"inflight", nil),
+			launchedCounter: metrics.GetOrRegisterCounter(prefix+"launched", nil),
+			failedCounter:   metrics.GetOrRegisterCounter(prefix+"failed", nil),
+		})
+	}
+	return b
+}
+
+// Start connects to the validation servers, and fails only if none of them
+// can be reached. The others are retried with backoff.
+func (b *LoadBalancer) Start(ctx context.Context) error {
+	b.StopWaiter.Start(ctx, b)
+	var errs []error
+	for _, server := range b.servers {
+		if err := b.pollServer(ctx, server); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == len(b.servers) {
+		return fmt.Errorf("couldn't reach any validation server: %w", errors.Join(errs...))
+	}
+	b.CallIteratively(b.poll)
+	return nil
+}
+
+func (b *LoadBalancer) poll(ctx context.Context) time.Duration {
+	for _, server := range b.servers {
+		b.mutex.Lock()
+		skip := !server.healthy && time.Now().Before(server.retryAt)
+		b.mutex.Unlock()
+		if skip {
+			continue
+		}
+		if err := b.pollServer(ctx, server); err != nil && ctx.Err() == nil {
+			log.Warn("polling validation server failed", "name", server.client.Name(), "err", err)
+		}
+	}
+	return b.config().PollInterval
+}
+
+// pollServer refreshes the room and module roots of the server, connecting to
+// it first if it was never reached.
+func (b *LoadBalancer) pollServer(ctx context.Context, server *balancedServer) error {
+	var room int
+	var moduleRoots []common.Hash
+	var err error
+	if !server.client.Started() {
+		err = server.client.Start(ctx)
+	}
+	if err == nil {
+		room, err = server.client.ServerRoom(ctx)
+	}
+	if err == nil {
+		moduleRoots, err = server.client.ServerWasmModuleRoots(ctx)
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if err != nil {
+		b.recordFailure(server)
+		return err
+	}
+	if !server.healthy {
+		log.Info("validation server admitted", "name", server.client.Name(), "room", room)
+	}
+	server.healthy = true
+	server.failures = 0
+	server.backoff = 0
+	server.room = room
+	server.moduleRoots = moduleRoots
+	server.healthyGauge.Update(1)
+	server.roomGauge.Update(int64(room))
+	return nil
+}
+
+// recordFailure must be called with the mutex held.
+func (b *LoadBalancer) recordFailure(server *balancedServer) {
+	config := b.config()
+	server.failures++
+	if server.healthy && server.failures < config.FailuresToEject {
+		return
+	}
+	if server.healthy {
+		log.Warn("validation server ejected", "name", server.client.Name(), "failures", server.failures)
+	}
+	server.healthy = false
+	server.healthyGauge.Update(0)
+	if server.backoff == 0 {
+		server.backoff = config.InitialBackoff
+	} else {
+		server.backoff = min(server.backoff*2, config.MaxBackoff)
+	}
+	server.retryAt = time.Now().Add(server.backoff)
+}
+
+// load returns how busy the server is relative to its capacity, per the policy.
+func (b *LoadBalancer) load(server *balancedServer) float64 {
+	capacity := server.weight
+	if b.config().Policy == PolicyLeastLoaded {
+		capacity = max(server.room, 1)
+	}
+	return float64(server.inFlight) / float64(capacity)
+}
+
+// pick chooses the least loaded healthy server supporting the module root,
+// and counts a validation in flight on it.
+func (b *LoadBalancer) pick(moduleRoot common.Hash) (*balancedServer, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	var best *balancedServer
+	for _, server := range b.servers {
+		if !server.healthy || !server.supports(moduleRoot) {
+			continue
+		}
+		if best == nil || b.load(server) < b.load(best) {
+			best = server
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no healthy validation server supports module root %v", moduleRoot)
+	}
+	best.inFlight++
+	best.inFlightGauge.Update(int64(best.inFlight))
+	best.launchedCounter.Inc(1)
+	return best, nil
+}
+
+func (b *LoadBalancer) done(server *balancedServer, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	server.inFlight--
+	server.inFlightGauge.Update(int64(server.inFlight))
+	if err != nil && !errors.Is(err, context.Canceled) {
+		server.failedCounter.Inc(1)
+		b.recordFailure(server)
+	} else if err == nil && server.healthy {
+		server.failures = 0
+	}
+}
+
+func (b *LoadBalancer) Launch(entry *validator.ValidationInput, moduleRoot common.Hash) validator.ValidationRun {
+	server, err := b.pick(moduleRoot)
+	if err != nil {
+		return server_common.NewValRun(containers.NewReadyPromise(validator.GoGlobalState{}, err), moduleRoot)
+	}
+	run := server.client.Launch(entry, moduleRoot)
+	promise := stopwaiter.LaunchPromiseThread[validator.GoGlobalState](b, func(ctx context.Context) (validator.GoGlobalState, error) {
+		res, err := run.Await(ctx)
+		b.done(server, err)
+		return res, err
+	})
+	return server_common.NewValRun(promise, moduleRoot)
+}
+
+func (b *LoadBalancer) CreateExecutionRun(wasmModuleRoot common.Hash, input *validator.ValidationInput, useBoldMachine bool) containers.PromiseInterface[validator.ExecutionRun] {
+	server, err := b.pick(wasmModuleRoot)
+	if err != nil {
+		return containers.NewReadyPromise[validator.ExecutionRun](nil, err)
+	}
+	// The execution run itself isn't tracked, only its creation
+	return stopwaiter.LaunchPromiseThread[validator.ExecutionRun](b, func(ctx context.Context) (validator.ExecutionRun, error) {
+		run, err := server.client.CreateExecutionRun(wasmModuleRoot, input, useBoldMachine).Await(ctx)
+		b.done(server, err)
+		return run, err
+	})
+}
+
+// WasmModuleRoots returns the module roots supported by any healthy server.
+func (b *LoadBalancer) WasmModuleRoots() ([]common.Hash, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	var roots []common.Hash
+	seen := make(map[common.Hash]struct{})
+	for _, server := range b.servers {
+		if !server.healthy {
+			continue
+		}
+		for _, root := range server.moduleRoots {
+			if _, ok := seen[root]; !ok {
+				seen[root] = struct{}{}
+				roots = append(roots, root)
+			}
+		}
+	}
+	if len(roots) == 0 {
+		return nil, errors.New("no healthy validation server")
+	}
+	return roots, nil
+}
+
+// StylusArchs returns the union of the archs of the servers, so inputs can be
+// validated by any of them.
+func (b *LoadBalancer) StylusArchs() []rawdb.WasmTarget {
+	var archs []rawdb.WasmTarget
+	seen := make(map[rawdb.WasmTarget]struct{})
+	for _, server := range b.servers {
+		if !server.client.Started() {
+			continue
+		}
+		for _, arch := range server.client.StylusArchs() {
+			if _, ok := seen[arch]; !ok {
+				seen[arch] = struct{}{}
+				archs = append(archs, arch)
+			}
+		}
+	}
+	return archs
+}
+
+// Room returns the room left across the healthy servers.
+func (b *LoadBalancer) Room() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	room := 0
+	for _, server := range b.servers {
+		if server.healthy {
+			room += max(server.room-server.inFlight, 0)
+		}
+	}
+	return room
+}
+
+func (b *LoadBalancer) Name() string {
+	return fmt.Sprintf("load-balancer(%d servers)", len(b.servers))
+}
+
+func (b *LoadBalancer) Stop() {
+	b.StopOnly()
+	for _, server := range b.servers {
+		server.client.Stop()
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+
+	"github.com/offchainlabs/nitro/util/containers"
+	"github.com/offchainlabs/nitro/validator"
+	"github.com/offchainlabs/nitro/validator/server_common"
+)
+
+var errMockOffline = errors.New("mock validation server offline")
+
+type mockBalancedClient struct {
+	name        string
+	room        int
+	moduleRoots []common.Hash
+	offline     bool
+	started     bool
+	launches    chan *containers.Promise[validator.GoGlobalState]
+}
+
+func (c *mockBalancedClient) Launch(entry *validator.ValidationInput, moduleRoot common.Hash) validator.ValidationRun {
+	promise := containers.NewPromise[validator.GoGlobalState](nil)
+	c.launches <- &promise
+	return server_common.NewValRun(&promise, moduleRoot)
+}
+
+func (c *mockBalancedClient) CreateExecutionRun(common.Hash, *validator.ValidationInput, bool) containers.PromiseInterface[validator.ExecutionRun] {
+	return containers.NewReadyPromise[validator.ExecutionRun](nil, errors.New("not supported"))
+}
+
+func (c *mockBalancedClient) Start(context.Context) error {
+	if c.offline {
+		return errMockOffline
+	}
+	c.started = true
+	return nil
+}
+
+func (c *mockBalancedClient) ServerRoom(context.Context) (int, error) {
+	if c.offline {
+		return 0, errMockOffline
+	}
+	return c.room, nil
+}
+
+func (c *mockBalancedClient) ServerWasmModuleRoots(context.Context) ([]common.Hash, error) {
+	if c.offline {
+		return nil, errMockOffline
+	}
+	return c.moduleRoots, nil
+}
+
+func (c *mockBalancedClient) WasmModuleRoots() ([]common.Hash, error) { return c.moduleRoots, nil }
+func (c *mockBalancedClient) Started() bool                           { return c.started }
+func (c *mockBalancedClient) Stop()                                   {}
+func (c *mockBalancedClient) Name() string                            { return c.name }
+func (c *mockBalancedClient) StylusArchs() []rawdb.WasmTarget         { return nil }
+func (c *mockBalancedClient) Room() int                               { return c.room }
+
+func TestLoadBalancer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	root := common.Hash{1}
+	otherRoot := common.Hash{2}
+	big := &mockBalancedClient{name: "big", room: 3, moduleRoots: []common.Hash{root}, launches: make(chan *containers.Promise[validator.GoGlobalState], 10)}
+	small := &mockBalancedClient{name: "small", room: 1, moduleRoots: []common.Hash{root, otherRoot}, launches: make(chan *containers.Promise[validator.GoGlobalState], 10)}
+	offline := &mockBalancedClient{name: "offline", offline: true}
+	config := DefaultLoadBalancerConfig
+	config.Enable = true
+	config.FailuresToEject = 1
+	config.PollInterval = time.Hour
+	b := newLoadBalancer(func() *LoadBalancerConfig { return &config }, []balancedClient{big, small, offline})
+	if err := b.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	defer b.StopAndWait()
+	if b.servers[2].healthy {
+		t.Fatal("expected the offline server not to be admitted")
+	}
+	if room := b.Room(); room != 4 {
+		t.Fatalf("expected room 4, got %d", room)
+	}
+
+	// The least loaded server relative to its room gets each validation
+	var bigRuns, smallRuns []*containers.Promise[validator.GoGlobalState]
+	for i := 0; i < 4; i++ {
+		b.Launch(&validator.ValidationInput{}, root)
+	}
+	for len(bigRuns)+len(smallRuns) < 4 {
+		select {
+		case run := <-big.launches:
+			bigRuns = append(bigRuns, run)
+		case run := <-small.launches:
+			smallRuns = append(smallRuns, run)
+		}
+	}
+	if len(bigRuns) != 3 || len(smallRuns) != 1 {
+		t.Fatalf("expected 3 validations on the big server and 1 on the small one, got %d and %d", len(bigRuns), len(smallRuns))
+	}
+	if room := b.Room(); room != 0 {
+		t.Fatalf("expected no room left, got %d", room)
+	}
+
+	// Only the small server supports the other module root
+	b.Launch(&validator.ValidationInput{}, otherRoot)
+	smallRuns = append(smallRuns, <-small.launches)
+
+	// A failed validation ejects the server
+	bigRuns[0].ProduceError(errMockOffline)
+	for i := 0; ; i++ {
+		b.mutex.Lock()
+		ejected := !b.servers[0].healthy
+		b.mutex.Unlock()
+		if ejected {
+			break
+		}
+		if i == 100 {
+			t.Fatal("expected the big server to be ejected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	roots, err := b.WasmModuleRoots()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roots) != 2 {
+		t.Fatalf("expected 2 module roots from the small server, got %d", len(roots))
+	}
+	run := b.Launch(&validator.ValidationInput{}, root)
+	smallRuns = append(smallRuns, <-small.launches)
+	for _, smallRun := range smallRuns {
+		smallRun.Produce(validator.GoGlobalState{Batch: 1})
+	}
+	if res, err := run.Await(ctx); err != nil || res.Batch != 1 {
+		t.Fatalf("unexpected validation result %v (err %v)", res, err)
+	}
+
+	// Servers are re-admitted once they answer polls again
+	offline.offline = false
+	b.mutex.Lock()
+	for _, server := range b.servers {
+		server.retryAt = time.Time{}
+	}
+	b.mutex.Unlock()
+	b.poll(ctx)
+	for i, server := range b.servers {
+		if !server.healthy {
+			t.Fatalf("expected server %d to be re-admitted", i)
+		}
+	}
+
+	offline.offline = true
+	b.poll(ctx)
+	if b.servers[2].healthy || b.servers[2].backoff != config.InitialBackoff {
+		t.Fatal("expected the server failing a poll to be ejected with the initial backoff")
+	}
+	b.servers[2].retryAt = time.Time{}
+	b.poll(ctx)
+	if b.servers[2].backoff != 2*config.InitialBackoff {
+		t.Fatalf("expected the backoff to double, got %v", b.servers[2].backoff)
+	}
+}
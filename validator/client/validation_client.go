@@ -126,6 +126,21 @@ func (c *ValidationClient) StylusArchs() []rawdb.WasmTarget {
 	return []rawdb.WasmTarget{"not started"}
 }
 
+// ServerRoom returns the room currently reported by the server, which may
+// change after Start, e.g. when its config is reloaded.
+func (c *ValidationClient) ServerRoom(ctx context.Context) (int, error) {
+	var room int
+	err := c.client.CallContext(ctx, &room, server_api.Namespace+"_room")
+	return room, err
+}
+
+// ServerWasmModuleRoots returns the module roots currently reported by the server.
+func (c *ValidationClient) ServerWasmModuleRoots(ctx context.Context) ([]common.Hash, error) {
+	var moduleRoots []common.Hash
+	err := c.client.CallContext(ctx, &moduleRoots, server_api.Namespace+"_wasmModuleRoots")
+	return moduleRoots, err
+}
+
 func (c *ValidationClient) Stop() {
 	c.StopWaiter.StopOnly()
 	if c.client != nil {
@@ -0,0 +1,321 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package valnode
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/offchainlabs/nitro/util/stopwaiter"
+	"github.com/offchainlabs/nitro/validator"
+	"github.com/offchainlabs/nitro/validator/server_api"
+)
+
+const JobsNamespace string = "validationjobs"
+
+type JobsConfig struct {
+	Enable          bool          `koanf:"enable"`
+	Workers         int           `koanf:"workers"`
+	MaxQueued       int           `koanf:"max-queued"`
+	InputsDirectory string        `koanf:"inputs-directory"`
+	ResultRetention time.Duration `koanf:"result-retention"`
+}
+
+var DefaultJobsConfig = JobsConfig{
+	Enable:          false,
+	Workers:         1,
+	MaxQueued:       1000,
+	InputsDirectory: "",
+	ResultRetention: 7 * 24 * time.Hour,
+}
+
+func JobsConfigAddOptions(prefix string, f *pflag.FlagSet) {
+	f.Bool(prefix+".enable", DefaultJobsConfig.Enable, "enable the validation job API, to validate inputs asynchronously and persist the results")
+	f.Int(prefix+".workers", DefaultJobsConfig.Workers, "number of validation jobs run concurrently")
+	f.Int(prefix+".max-queued", DefaultJobsConfig.MaxQueued, "maximum number of validation jobs waiting to be run")
+	f.String(prefix+".inputs-directory", DefaultJobsConfig.InputsDirectory, "directory of block input JSON files that can be submitted by name (empty to disallow submitting files)")
+	f.Duration(prefix+".result-retention", DefaultJobsConfig.ResultRetention, "how long the results of finished validation jobs are kept (0 to keep them forever)")
+}
+
+func (c *JobsConfig) Validate() error {
+	if c.Enable && c.Workers <= 0 {
+		return errors.New("validation jobs workers must be greater than 0")
+	}
+	if c.Enable && c.MaxQueued <= 0 {
+		return errors.New("validation jobs max-queued must be greater than 0")
+	}
+	return nil
+}
+
+type JobStatus string
+
+const (
+	JobQueued    JobStatus = "queued"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+)
+
+// ValidationJob is the persisted record of a validation job. The input itself
+// isn't persisted, so jobs interrupted by a restart fail and must be resubmitted.
+type ValidationJob struct {
+	Id         uint64                   `json:"id"`
+	InputId    uint64                   `json:"inputId"`
+	ModuleRoot common.Hash              `json:"moduleRoot"`
+	Status     JobStatus                `json:"status"`
+	StartState validator.GoGlobalState  `json:"startState"`
+	EndState   *validator.GoGlobalState `json:"endState,omitempty"`
+	Error      string                   `json:"error,omitempty"`
+	Submitted  time.Time                `json:"submitted"`
+	Started    *time.Time               `json:"started,omitempty"`
+	Finished   *time.Time               `json:"finished,omitempty"`
+	// DurationMs is the time spent validating, excluding the time queued
+	DurationMs uint64 `json:"durationMs,omitempty"`
+}
+
+var (
+	jobPrefix    = []byte("j") // maps a job id to its JSON encoded ValidationJob
+	nextJobIdKey = []byte("_nextJobId")
+)
+
+func jobKey(id uint64) []byte {
+	key := make([]byte, len(jobPrefix)+8)
+	copy(key, jobPrefix)
+	binary.BigEndian.PutUint64(key[len(jobPrefix):], id)
+	return key
+}
+
+type queuedJob struct {
+	id    uint64
+	input *validator.ValidationInput
+}
+
+// ValidationJobs runs validation inputs submitted through the job API in the
+// background, and persists their results.
+type ValidationJobs struct {
+	stopwaiter.StopWaiter
+	config  func() *JobsConfig
+	spawner validator.ValidationSpawner
+	db      ethdb.Database
+
+	mutex  sync.Mutex
+	nextId uint64
+	queue  chan queuedJob
+}
+
+func NewValidationJobs(config func() *JobsConfig, spawner validator.ValidationSpawner, db ethdb.Database) (*ValidationJobs, error) {
+	j := &ValidationJobs{
+		config:  config,
+		spawner: spawner,
+		db:      db,
+		queue:   make(chan queuedJob, config().MaxQueued),
+	}
+	nextIdBytes, err := db.Get(nextJobIdKey)
+	if err == nil && len(nextIdBytes) == 8 {
+		j.nextId = binary.BigEndian.Uint64(nextIdBytes)
+	}
+	// Inputs aren't persisted, so unfinished jobs can't be resumed
+	iter := db.NewIterator(jobPrefix, nil)
+	defer iter.Release()
+	for iter.Next() {
+		var job ValidationJob
+		if err := json.Unmarshal(iter.Value(), &job); err != nil {
+			return nil, fmt.Errorf("error decoding validation job: %w", err)
+		}
+		if job.Status == JobQueued || job.Status == JobRunning {
+			job.Status = JobFailed
+			job.Error = "interrupted by a restart"
+			if err := j.writeJob(&job); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return j, iter.Error()
+}
+
+func (j *ValidationJobs) Start(ctx context.Context) {
+	j.StopWaiter.Start(ctx, j)
+	for i := 0; i < j.config().Workers; i++ {
+		j.LaunchThread(j.runWorker)
+	}
+	j.CallIteratively(j.pruneJobs)
+}
+
+func (j *ValidationJobs) writeJob(job *ValidationJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return j.db.Put(jobKey(job.Id), data)
+}
+
+func (j *ValidationJobs) GetJob(id uint64) (*ValidationJob, error) {
+	data, err := j.db.Get(jobKey(id))
+	if err != nil {
+		return nil, fmt.Errorf("validation job %d not found: %w", id, err)
+	}
+	var job ValidationJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// Submit queues the validation of the input, and returns the id of the job.
+func (j *ValidationJobs) Submit(input *validator.ValidationInput, moduleRoot common.Hash) (uint64, error) {
+	if j.Stopped() {
+		return 0, errors.New("validation jobs are stopped")
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	id := j.nextId
+	job := &ValidationJob{
+		Id:         id,
+		InputId:    input.Id,
+		ModuleRoot: moduleRoot,
+		Status:     JobQueued,
+		StartState: input.StartState,
+		Submitted:  time.Now(),
+	}
+	nextIdBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(nextIdBytes, id+1)
+	if err := j.db.Put(nextJobIdKey, nextIdBytes); err != nil {
+		return 0, err
+	}
+	j.nextId++
+	// The job is written before a worker can pick it up
+	if err := j.writeJob(job); err != nil {
+		return 0, err
+	}
+	select {
+	case j.queue <- queuedJob{id: id, input: input}:
+	default:
+		if err := j.db.Delete(jobKey(id)); err != nil {
+			log.Warn("error deleting rejected validation job", "id", id, "err", err)
+		}
+		return 0, errors.New("too many queued validation jobs")
+	}
+	return id, nil
+}
+
+func (j *ValidationJobs) runWorker(ctx context.Context) {
+	for {
+		var queued queuedJob
+		select {
+		case <-ctx.Done():
+			return
+		case queued = <-j.queue:
+		}
+		job, err := j.GetJob(queued.id)
+		if err != nil {
+			log.Error("error reading queued validation job", "id", queued.id, "err", err)
+			continue
+		}
+		started := time.Now()
+		job.Status = JobRunning
+		job.Started = &started
+		if err := j.writeJob(job); err != nil {
+			log.Error("error writing validation job", "id", job.Id, "err", err)
+		}
+		endState, err := j.spawner.Launch(queued.input, job.ModuleRoot).Await(ctx)
+		if ctx.Err() != nil {
+			// Marked as interrupted on the next start
+			return
+		}
+		finished := time.Now()
+		job.Finished = &finished
+		job.DurationMs = uint64(finished.Sub(started).Milliseconds()) // #nosec G115
+		if err != nil {
+			job.Status = JobFailed
+			job.Error = err.Error()
+		} else {
+			job.Status = JobSucceeded
+			job.EndState = &endState
+		}
+		if err := j.writeJob(job); err != nil {
+			log.Error("error writing validation job", "id", job.Id, "err", err)
+		}
+		log.Info("validation job finished", "id", job.Id, "inputId", job.InputId, "status", job.Status, "durationMs", job.DurationMs)
+	}
+}
+
+// pruneJobs removes the finished jobs older than the result retention.
+func (j *ValidationJobs) pruneJobs(ctx context.Context) time.Duration {
+	retention := j.config().ResultRetention
+	if retention == 0 {
+		return time.Hour
+	}
+	iter := j.db.NewIterator(jobPrefix, nil)
+	defer iter.Release()
+	for iter.Next() {
+		var job ValidationJob
+		if err := json.Unmarshal(iter.Value(), &job); err != nil {
+			log.Warn("error decoding validation job", "err", err)
+			continue
+		}
+		if job.Finished != nil && time.Since(*job.Finished) > retention {
+			if err := j.db.Delete(iter.Key()); err != nil {
+				log.Warn("error deleting validation job", "id", job.Id, "err", err)
+			}
+		}
+	}
+	return time.Hour
+}
+
+// ValidationJobsAPI is the RPC API of the validation jobs.
+type ValidationJobsAPI struct {
+	jobs *ValidationJobs
+}
+
+func NewValidationJobsAPI(jobs *ValidationJobs) *ValidationJobsAPI {
+	return &ValidationJobsAPI{jobs}
+}
+
+func (a *ValidationJobsAPI) Submit(ctx context.Context, input *server_api.InputJSON, moduleRoot common.Hash) (uint64, error) {
+	valInput, err := server_api.ValidationInputFromJson(input)
+	if err != nil {
+		return 0, err
+	}
+	return a.jobs.Submit(valInput, moduleRoot)
+}
+
+// SubmitFile submits a block input JSON file, as written by inputs.Writer,
+// from the configured inputs directory.
+func (a *ValidationJobsAPI) SubmitFile(ctx context.Context, fileName string, moduleRoot common.Hash) (uint64, error) {
+	dir := a.jobs.config().InputsDirectory
+	if dir == "" {
+		return 0, errors.New("submitting block input files is disabled")
+	}
+	path := filepath.Join(dir, filepath.Clean(fileName))
+	if rel, err := filepath.Rel(dir, path); err != nil || strings.HasPrefix(rel, "..") {
+		return 0, fmt.Errorf("block input file %s is outside of the inputs directory", fileName)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	var input server_api.InputJSON
+	if err := json.Unmarshal(data, &input); err != nil {
+		return 0, fmt.Errorf("error decoding block input file %s: %w", fileName, err)
+	}
+	return a.Submit(ctx, &input, moduleRoot)
+}
+
+func (a *ValidationJobsAPI) Status(ctx context.Context, id uint64) (*ValidationJob, error) {
+	return a.jobs.GetJob(id)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package valnode
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+
+	"github.com/offchainlabs/nitro/util/containers"
+	"github.com/offchainlabs/nitro/validator"
+	"github.com/offchainlabs/nitro/validator/server_common"
+)
+
+var errMockValidation = errors.New("mock validation failure")
+
+// mockSpawner advances the batch of the start state, and fails inputs with an odd id.
+type mockSpawner struct{}
+
+func (s *mockSpawner) Launch(entry *validator.ValidationInput, moduleRoot common.Hash) validator.ValidationRun {
+	if entry.Id%2 == 1 {
+		return server_common.NewValRun(containers.NewReadyPromise(validator.GoGlobalState{}, errMockValidation), moduleRoot)
+	}
+	endState := entry.StartState
+	endState.Batch++
+	return server_common.NewValRun(containers.NewReadyPromise(endState, nil), moduleRoot)
+}
+
+func (s *mockSpawner) WasmModuleRoots() ([]common.Hash, error) { return nil, nil }
+func (s *mockSpawner) Start(context.Context) error             { return nil }
+func (s *mockSpawner) Stop()                                   {}
+func (s *mockSpawner) Name() string                            { return "mock" }
+func (s *mockSpawner) StylusArchs() []rawdb.WasmTarget         { return nil }
+func (s *mockSpawner) Room() int                               { return 1 }
+
+func waitForJob(t *testing.T, jobs *ValidationJobs, id uint64) *ValidationJob {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		job, err := jobs.GetJob(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if job.Status == JobSucceeded || job.Status == JobFailed {
+			return job
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("validation job %d didn't finish", id)
+	return nil
+}
+
+func TestValidationJobs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	db := rawdb.NewMemoryDatabase()
+	config := DefaultJobsConfig
+	configFetcher := func() *JobsConfig { return &config }
+	jobs, err := NewValidationJobs(configFetcher, &mockSpawner{}, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobs.Start(ctx)
+
+	moduleRoot := common.Hash{1}
+	startState := validator.GoGlobalState{Batch: 5}
+	okId, err := jobs.Submit(&validator.ValidationInput{Id: 2, StartState: startState}, moduleRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failId, err := jobs.Submit(&validator.ValidationInput{Id: 3, StartState: startState}, moduleRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if okId == failId {
+		t.Fatal("expected distinct job ids")
+	}
+
+	job := waitForJob(t, jobs, okId)
+	if job.Status != JobSucceeded || job.EndState == nil || job.EndState.Batch != 6 || job.ModuleRoot != moduleRoot {
+		t.Fatalf("unexpected succeeded job %+v", job)
+	}
+	job = waitForJob(t, jobs, failId)
+	if job.Status != JobFailed || job.Error != errMockValidation.Error() || job.EndState != nil {
+		t.Fatalf("unexpected failed job %+v", job)
+	}
+	jobs.StopAndWait()
+
+	// Results and ids survive a restart, while unfinished jobs are failed
+	interrupted := &ValidationJob{Id: 100, Status: JobRunning}
+	if err := jobs.writeJob(interrupted); err != nil {
+		t.Fatal(err)
+	}
+	restarted, err := NewValidationJobs(configFetcher, &mockSpawner{}, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if job, err := restarted.GetJob(okId); err != nil || job.Status != JobSucceeded {
+		t.Fatalf("expected job %d to be persisted, got %+v (err %v)", okId, job, err)
+	}
+	if job, err := restarted.GetJob(100); err != nil || job.Status != JobFailed {
+		t.Fatalf("expected interrupted job to be failed, got %+v (err %v)", job, err)
+	}
+	if restarted.nextId != failId+1 {
+		t.Fatalf("expected next job id %d, got %d", failId+1, restarted.nextId)
+	}
+
+	config.ResultRetention = time.Nanosecond
+	restarted.pruneJobs(ctx)
+	if _, err := restarted.GetJob(okId); err == nil {
+		t.Fatal("expected finished job to be pruned")
+	}
+}
@@ -42,6 +42,7 @@ type Config struct {
 	Arbitrator server_arb.ArbitratorSpawnerConfig `koanf:"arbitrator" reload:"hot"`
 	Jit        server_jit.JitSpawnerConfig        `koanf:"jit" reload:"hot"`
 	Wasm       WasmConfig                         `koanf:"wasm"`
+	Jobs       JobsConfig                         `koanf:"jobs"`
 }
 
 type ValidationConfigFetcher func() *Config
@@ -53,6 +54,7 @@ var DefaultValidationConfig = Config{
 	ApiPublic:  false,
 	Arbitrator: server_arb.DefaultArbitratorSpawnerConfig,
 	Wasm:       DefaultWasmConfig,
+	Jobs:       DefaultJobsConfig,
 }
 
 var TestValidationConfig = Config{
@@ -62,6 +64,7 @@ var TestValidationConfig = Config{
 	ApiPublic:  true,
 	Arbitrator: server_arb.DefaultArbitratorSpawnerConfig,
 	Wasm:       DefaultWasmConfig,
+	Jobs:       DefaultJobsConfig,
 }
 
 func ValidationConfigAddOptions(prefix string, f *pflag.FlagSet) {
@@ -71,6 +74,7 @@ func ValidationConfigAddOptions(prefix string, f *pflag.FlagSet) {
 	server_arb.ArbitratorSpawnerConfigAddOptions(prefix+".arbitrator", f)
 	server_jit.JitSpawnerConfigAddOptions(prefix+".jit", f)
 	WasmConfigAddOptions(prefix+".wasm", f)
+	JobsConfigAddOptions(prefix+".jobs", f)
 }
 
 type ValidationNode struct {
@@ -78,20 +82,23 @@ type ValidationNode struct {
 	arbSpawner *server_arb.ArbitratorSpawner
 	jitSpawner *server_jit.JitSpawner
 	serverAPI  *ExecServerAPI
+	jobs       *ValidationJobs
 
 	redisConsumer *redis.ValidationServer
 }
 
 func EnsureValidationExposedViaAuthRPC(stackConf *node.Config) {
-	found := false
-	for _, module := range stackConf.AuthModules {
-		if module == server_api.Namespace {
-			found = true
-			break
+	for _, namespace := range []string{server_api.Namespace, JobsNamespace} {
+		found := false
+		for _, module := range stackConf.AuthModules {
+			if module == namespace {
+				found = true
+				break
+			}
+		}
+		if !found {
+			stackConf.AuthModules = append(stackConf.AuthModules, namespace)
 		}
-	}
-	if !found {
-		stackConf.AuthModules = append(stackConf.AuthModules, server_api.Namespace)
 	}
 }
 
@@ -110,6 +117,7 @@ func CreateValidationNode(configFetcher ValidationConfigFetcher, stack *node.Nod
 	}
 	var serverAPI *ExecServerAPI
 	var jitSpawner *server_jit.JitSpawner
+	var valSpawner validator.ValidationSpawner = arbSpawner
 	if config.UseJit {
 		jitConfigFetcher := func() *server_jit.JitSpawnerConfig { return &configFetcher().Jit }
 		var err error
@@ -118,6 +126,7 @@ func CreateValidationNode(configFetcher ValidationConfigFetcher, stack *node.Nod
 			return nil, err
 		}
 		serverAPI = NewExecutionServerAPI(jitSpawner, arbSpawner, arbConfigFetcher)
+		valSpawner = jitSpawner
 	} else {
 		serverAPI = NewExecutionServerAPI(arbSpawner, arbSpawner, arbConfigFetcher)
 	}
@@ -136,9 +145,27 @@ func CreateValidationNode(configFetcher ValidationConfigFetcher, stack *node.Nod
 		Public:        config.ApiPublic,
 		Authenticated: config.ApiAuth,
 	}}
+	var jobs *ValidationJobs
+	if config.Jobs.Enable {
+		jobsDb, err := stack.OpenDatabaseWithOptions("validation-jobs", node.DatabaseOptions{MetricsNamespace: "validationjobs/"})
+		if err != nil {
+			return nil, err
+		}
+		jobs, err = NewValidationJobs(func() *JobsConfig { return &configFetcher().Jobs }, valSpawner, jobsDb)
+		if err != nil {
+			return nil, err
+		}
+		valAPIs = append(valAPIs, rpc.API{
+			Namespace:     JobsNamespace,
+			Version:       "1.0",
+			Service:       NewValidationJobsAPI(jobs),
+			Public:        config.ApiPublic,
+			Authenticated: config.ApiAuth,
+		})
+	}
 	stack.RegisterAPIs(valAPIs)
 
-	return &ValidationNode{configFetcher, arbSpawner, jitSpawner, serverAPI, redisConsumer}, nil
+	return &ValidationNode{configFetcher, arbSpawner, jitSpawner, serverAPI, jobs, redisConsumer}, nil
 }
 
 func (v *ValidationNode) Start(ctx context.Context) error {
@@ -154,6 +181,9 @@ func (v *ValidationNode) Start(ctx context.Context) error {
 		v.redisConsumer.Start(ctx)
 	}
 	v.serverAPI.Start(ctx) // starting cleanup of stale execRuns
+	if v.jobs != nil {
+		v.jobs.Start(ctx)
+	}
 	return nil
 }
 
@@ -166,6 +196,9 @@ func (v *ValidationNode) Stop() {
 		v.jitSpawner.Stop()
 	}
 	v.serverAPI.StopAndWait() // cleanup of all execRuns
+	if v.jobs != nil {
+		v.jobs.StopAndWait()
+	}
 }
 
 func (v *ValidationNode) GetExec() validator.ExecutionSpawner {
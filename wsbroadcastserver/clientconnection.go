@@ -20,6 +20,7 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/offchainlabs/nitro/arbutil"
+	"github.com/offchainlabs/nitro/broadcaster/archive"
 	"github.com/offchainlabs/nitro/broadcaster/backlog"
 	m "github.com/offchainlabs/nitro/broadcaster/message"
 	"github.com/offchainlabs/nitro/util/stopwaiter"
@@ -27,6 +28,10 @@ import (
 
 var errContextDone = errors.New("context done")
 
+// historyBatchSize is the maximum number of messages read from the history and
+// sent to a client at once.
+const historyBatchSize = 512
+
 type message struct {
 	data           []byte
 	sequenceNumber *arbutil.MessageIndex
@@ -55,11 +60,19 @@ type ClientConnection struct {
 	lastHeardUnix atomic.Int64
 	out           chan message
 	backlog       backlog.Backlog
-	registered    chan bool
-	backlogSent   bool
+	// history is nil if messages older than the backlog can't be sent to the client.
+	history archive.Source
+	// maxHistoryCatchup is the maximum number of messages sent from the history, -1 means unlimited.
+	maxHistoryCatchup int
+	registered        chan bool
+	backlogSent       bool
 
 	compression bool
 	flateReader *wsflate.Reader
+	// binary is set if the client negotiated the binary (RLP) encoding of messages.
+	binary bool
+	// filter is nil if the client didn't request any filter.
+	filter *ClientFilter
 
 	delay time.Duration
 }
@@ -71,25 +84,33 @@ func NewClientConnection(
 	requestedSeqNum arbutil.MessageIndex,
 	connectingIP net.IP,
 	compression bool,
+	binary bool,
+	filter *ClientFilter,
 	maxSendQueue int,
 	delay time.Duration,
 	bklg backlog.Backlog,
+	history archive.Source,
+	maxHistoryCatchup int,
 ) *ClientConnection {
 	clientConnection := &ClientConnection{
-		conn:            conn,
-		clientIp:        connectingIP,
-		desc:            desc,
-		creation:        time.Now(),
-		Name:            fmt.Sprintf("%s@%s-%d", connectingIP, conn.RemoteAddr(), rand.Intn(10)),
-		clientAction:    clientAction,
-		requestedSeqNum: requestedSeqNum,
-		out:             make(chan message, maxSendQueue),
-		compression:     compression,
-		flateReader:     NewFlateReader(),
-		delay:           delay,
-		backlog:         bklg,
-		registered:      make(chan bool, 1),
-		backlogSent:     false,
+		conn:              conn,
+		clientIp:          connectingIP,
+		desc:              desc,
+		creation:          time.Now(),
+		Name:              fmt.Sprintf("%s@%s-%d", connectingIP, conn.RemoteAddr(), rand.Intn(10)),
+		clientAction:      clientAction,
+		requestedSeqNum:   requestedSeqNum,
+		out:               make(chan message, maxSendQueue),
+		compression:       compression,
+		flateReader:       NewFlateReader(),
+		binary:            binary,
+		filter:            filter,
+		delay:             delay,
+		backlog:           bklg,
+		history:           history,
+		maxHistoryCatchup: maxHistoryCatchup,
+		registered:        make(chan bool, 1),
+		backlogSent:       false,
 	}
 	clientConnection.lastHeardUnix.Store(time.Now().Unix())
 	return clientConnection
@@ -103,6 +124,10 @@ func (cc *ClientConnection) Compression() bool {
 	return cc.compression
 }
 
+func (cc *ClientConnection) Binary() bool {
+	return cc.binary
+}
+
 // Register sends the ClientConnection to be registered with the ClientManager.
 func (cc *ClientConnection) Register() {
 	cc.clientAction <- ClientConnectionAction{
@@ -165,8 +190,68 @@ func (cc *ClientConnection) writeBacklog(ctx context.Context, segment backlog.Ba
 	return nil
 }
 
+// writeHistory sends the messages from the requested sequence number up to the
+// start of the backlog from the history, and returns whether any were sent.
+func (cc *ClientConnection) writeHistory(ctx context.Context) (bool, error) {
+	next := uint64(cc.requestedSeqNum)
+	sent := false
+	// A single iterator is used so that archive files are read once per client
+	history := archive.NewIterator(cc.history, cc.requestedSeqNum)
+	for {
+		select {
+		case <-ctx.Done():
+			return sent, errContextDone
+		default:
+		}
+
+		// The start of the backlog moves forward as messages are confirmed,
+		// so it's checked before each batch.
+		var end uint64
+		if segment := cc.backlog.Head(); !backlog.IsBacklogSegmentNil(segment) {
+			end = segment.Start()
+		} else {
+			count, err := cc.history.GetMessageCount()
+			if err != nil {
+				return sent, err
+			}
+			end = uint64(count)
+		}
+		if next >= end {
+			break
+		}
+		if !sent && cc.maxHistoryCatchup >= 0 && end-next > uint64(cc.maxHistoryCatchup) {
+			log.Debug("client requested more messages from history than the catchup limit, sending the backlog instead", "client", cc.Name, "requestedSeqNum", cc.requestedSeqNum, "backlogStart", end, "maxCatchup", cc.maxHistoryCatchup)
+			return false, nil
+		}
+		last := min(end-1, next+historyBatchSize-1)
+		msgs, err := history.Next(arbutil.MessageIndex(last))
+		if err != nil {
+			return sent, err
+		}
+		err = cc.writeBroadcastMessage(&m.BroadcastMessage{
+			Version:  m.V1,
+			Messages: msgs,
+		})
+		if err != nil {
+			return sent, err
+		}
+		sent = true
+		cc.LastSentSeqNum.Store(last)
+		next = last + 1
+	}
+	if sent {
+		log.Debug("history sent to client", "client", cc.Name, "requestedSeqNum", cc.requestedSeqNum, "lastSentSeqNum", next-1)
+		cc.requestedSeqNum = arbutil.MessageIndex(next)
+	}
+	return sent, nil
+}
+
 func (cc *ClientConnection) writeBroadcastMessage(bm *m.BroadcastMessage) error {
-	notCompressed, compressed, err := serializeMessage(bm, !cc.compression, cc.compression)
+	bm = cc.filter.apply(bm, addressCache{})
+	if bm == nil {
+		return nil
+	}
+	notCompressed, compressed, err := serializeMessage(bm, cc.binary, !cc.compression, cc.compression)
 	if err != nil {
 		return err
 	}
@@ -200,6 +285,20 @@ func (cc *ClientConnection) Start(parentCtx context.Context) {
 			}
 		}
 
+		// Send the messages older than the backlog first, if requested
+		if cc.history != nil {
+			sent, err := cc.writeHistory(ctx)
+			if errors.Is(err, errContextDone) {
+				return
+			} else if err != nil && sent {
+				logWarn(err, "error writing messages from history")
+				cc.Remove()
+				return
+			} else if err != nil {
+				logWarn(err, "error reading requested messages from history: sending the backlog instead")
+			}
+		}
+
 		// Send the current backlog before registering the ClientConnection in
 		// case the backlog is very large
 		segment := cc.backlog.Head()
@@ -262,6 +361,10 @@ func (cc *ClientConnection) Start(parentCtx context.Context) {
 				}
 				cc.backlogSent = true
 
+				if len(msg.data) == 0 {
+					// Filtered out for this client
+					continue
+				}
 				err := cc.writeRaw(msg.data)
 				if err != nil {
 					logWarn(err, "error writing data to client")
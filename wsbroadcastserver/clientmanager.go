@@ -22,6 +22,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/offchainlabs/nitro/arbutil"
 	"github.com/offchainlabs/nitro/broadcaster/backlog"
@@ -156,22 +157,47 @@ func (cm *ClientManager) doBroadcast(bm *m.BroadcastMessage) ([]*ClientConnectio
 		return nil, err
 	}
 	config := cm.config()
-	//                                        /-> wsutil.Writer -> not compressed msg buffer
-	// bm -> json.Encoder -> io.MultiWriter -|
-	//                                        \-> flateWriter -> wsutil.Writer -> compressed msg buffer
+	//                                             /-> wsutil.Writer -> not compressed msg buffer
+	// bm -> json.Encoder or rlp -> io.MultiWriter -|
+	//                                             \-> flateWriter -> wsutil.Writer -> compressed msg buffer
 
-	notCompressed, compressed, err := serializeMessage(bm, !config.RequireCompression, config.EnableCompression)
+	notCompressed, compressed, err := serializeMessage(bm, false, !config.RequireCompression, config.EnableCompression)
 	if err != nil {
 		return nil, err
 	}
+	// The binary encoding is only serialized if a client negotiated it
+	var binaryNotCompressed, binaryCompressed bytes.Buffer
+	binarySerialized := false
+	addrCache := addressCache{}
 
 	sendQueueTooLargeCount := 0
 	clientDeleteList := make([]*ClientConnection, 0, len(cm.clientPtrMap))
 	for client := range cm.clientPtrMap {
+		clientNotCompressed, clientCompressed := &notCompressed, &compressed
+		filtered := client.filter.apply(bm, addrCache)
+		if filtered == nil {
+			// Nothing is sent, but the message is still queued so the client keeps track of the sequence number
+			clientNotCompressed, clientCompressed = &bytes.Buffer{}, &bytes.Buffer{}
+		} else if filtered != bm {
+			filteredNotCompressed, filteredCompressed, err := serializeMessage(filtered, client.Binary(), !client.Compression(), client.Compression())
+			if err != nil {
+				return nil, err
+			}
+			clientNotCompressed, clientCompressed = &filteredNotCompressed, &filteredCompressed
+		} else if client.Binary() {
+			if !binarySerialized {
+				binaryNotCompressed, binaryCompressed, err = serializeMessage(bm, true, !config.RequireCompression, config.EnableCompression)
+				if err != nil {
+					return nil, err
+				}
+				binarySerialized = true
+			}
+			clientNotCompressed, clientCompressed = &binaryNotCompressed, &binaryCompressed
+		}
 		var data []byte
 		if client.Compression() {
 			if config.EnableCompression {
-				data = compressed.Bytes()
+				data = clientCompressed.Bytes()
 			} else {
 				log.Warn("disconnecting because client has enabled compression, but compression support is disabled", "client", client.Name)
 				clientDeleteList = append(clientDeleteList, client)
@@ -179,7 +205,7 @@ func (cm *ClientManager) doBroadcast(bm *m.BroadcastMessage) ([]*ClientConnectio
 			}
 		} else {
 			if !config.RequireCompression {
-				data = notCompressed.Bytes()
+				data = clientNotCompressed.Bytes()
 			} else {
 				log.Warn("disconnecting because client has disabled compression, but compression support is required", "client", client.Name)
 				clientDeleteList = append(clientDeleteList, client)
@@ -221,7 +247,8 @@ func (cm *ClientManager) doBroadcast(bm *m.BroadcastMessage) ([]*ClientConnectio
 	return clientDeleteList, nil
 }
 
-func serializeMessage(bm *m.BroadcastMessage, enableNonCompressedOutput, enableCompressedOutput bool) (bytes.Buffer, bytes.Buffer, error) {
+// serializeMessage encodes bm as JSON in text frames, or as RLP in binary frames if binary is set.
+func serializeMessage(bm *m.BroadcastMessage, binary, enableNonCompressedOutput, enableCompressedOutput bool) (bytes.Buffer, bytes.Buffer, error) {
 	flateWriter, err := flate.NewWriterDict(nil, DeflateCompressionLevel, GetStaticCompressorDictionary())
 	if err != nil {
 		return bytes.Buffer{}, bytes.Buffer{}, fmt.Errorf("unable to create flate writer: %w", err)
@@ -232,12 +259,16 @@ func serializeMessage(bm *m.BroadcastMessage, enableNonCompressedOutput, enableC
 	writers := []io.Writer{}
 	var notCompressedWriter *wsutil.Writer
 	var compressedWriter *wsutil.Writer
+	opCode := ws.OpText
+	if binary {
+		opCode = ws.OpBinary
+	}
 	if enableNonCompressedOutput {
-		notCompressedWriter = wsutil.NewWriter(&notCompressed, ws.StateServerSide, ws.OpText)
+		notCompressedWriter = wsutil.NewWriter(&notCompressed, ws.StateServerSide, opCode)
 		writers = append(writers, notCompressedWriter)
 	}
 	if enableCompressedOutput {
-		compressedWriter = wsutil.NewWriter(&compressed, ws.StateServerSide|ws.StateExtended, ws.OpText)
+		compressedWriter = wsutil.NewWriter(&compressed, ws.StateServerSide|ws.StateExtended, opCode)
 		var msg wsflate.MessageState
 		msg.SetCompressed(true)
 		compressedWriter.SetExtensions(&msg)
@@ -246,9 +277,15 @@ func serializeMessage(bm *m.BroadcastMessage, enableNonCompressedOutput, enableC
 	}
 
 	multiWriter := io.MultiWriter(writers...)
-	encoder := json.NewEncoder(multiWriter)
-	if err := encoder.Encode(bm); err != nil {
-		return bytes.Buffer{}, bytes.Buffer{}, fmt.Errorf("unable to encode message: %w", err)
+	if binary {
+		if err := rlp.Encode(multiWriter, bm); err != nil {
+			return bytes.Buffer{}, bytes.Buffer{}, fmt.Errorf("unable to encode message: %w", err)
+		}
+	} else {
+		encoder := json.NewEncoder(multiWriter)
+		if err := encoder.Encode(bm); err != nil {
+			return bytes.Buffer{}, bytes.Buffer{}, fmt.Errorf("unable to encode message: %w", err)
+		}
 	}
 	if notCompressedWriter != nil {
 		if err := notCompressedWriter.Flush(); err != nil {
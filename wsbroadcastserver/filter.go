@@ -0,0 +1,155 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package wsbroadcastserver
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/offchainlabs/nitro/arbos"
+	m "github.com/offchainlabs/nitro/broadcaster/message"
+)
+
+const (
+	FilterExcludeBlockMetadata = "block-metadata"
+	FilterExcludeSignature     = "signature"
+)
+
+// ClientFilter restricts the messages sent to a client, as requested with
+// the feed filter headers when connecting. The zero value sends everything.
+type ClientFilter struct {
+	// Addresses, if set, only keeps the feed messages with a transaction
+	// sent from or to one of the addresses.
+	Addresses map[common.Address]struct{}
+	// ConfirmationsOnly drops all the feed messages, only keeping the
+	// confirmed sequence number updates.
+	ConfirmationsOnly    bool
+	ExcludeBlockMetadata bool
+	ExcludeSignature     bool
+
+	chainId *big.Int
+}
+
+func NewClientFilter(chainId uint64) *ClientFilter {
+	return &ClientFilter{chainId: new(big.Int).SetUint64(chainId)}
+}
+
+// ParseHeader updates the filter from a feed filter header, returning
+// whether the header is a feed filter header.
+func (f *ClientFilter) ParseHeader(headerName string, value string) (bool, error) {
+	switch headerName {
+	case HTTPHeaderFeedFilterAddresses:
+		if f.Addresses == nil {
+			f.Addresses = make(map[common.Address]struct{})
+		}
+		for _, addr := range strings.Split(value, ",") {
+			addr = strings.TrimSpace(addr)
+			if !common.IsHexAddress(addr) {
+				return true, fmt.Errorf("invalid address %q", addr)
+			}
+			f.Addresses[common.HexToAddress(addr)] = struct{}{}
+		}
+	case HTTPHeaderFeedFilterConfirmations:
+		confirmationsOnly, err := strconv.ParseBool(value)
+		if err != nil {
+			return true, err
+		}
+		f.ConfirmationsOnly = confirmationsOnly
+	case HTTPHeaderFeedFilterExclude:
+		for _, field := range strings.Split(value, ",") {
+			switch strings.TrimSpace(field) {
+			case FilterExcludeBlockMetadata:
+				f.ExcludeBlockMetadata = true
+			case FilterExcludeSignature:
+				f.ExcludeSignature = true
+			default:
+				return true, fmt.Errorf("unknown excluded field %q", field)
+			}
+		}
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+func (f *ClientFilter) IsEmpty() bool {
+	return f == nil || (f.Addresses == nil && !f.ConfirmationsOnly && !f.ExcludeBlockMetadata && !f.ExcludeSignature)
+}
+
+// addressCache memoizes the addresses touched by feed messages, so messages
+// are only parsed once when broadcast to multiple filtered clients.
+type addressCache map[*m.BroadcastFeedMessage]map[common.Address]struct{}
+
+func (c addressCache) addresses(msg *m.BroadcastFeedMessage, chainId *big.Int) map[common.Address]struct{} {
+	if addrs, ok := c[msg]; ok {
+		return addrs
+	}
+	addrs := make(map[common.Address]struct{})
+	if msg.Message.Message != nil && msg.Message.Message.Header != nil {
+		// The arbos version only affects the encoding of batch posting reports,
+		// which are sent from and to arbos addresses either way.
+		txs, err := arbos.ParseL2Transactions(msg.Message.Message, chainId, 0)
+		if err == nil {
+			signer := types.NewArbitrumSigner(types.LatestSignerForChainID(chainId))
+			for _, tx := range txs {
+				if sender, err := types.Sender(signer, tx); err == nil {
+					addrs[sender] = struct{}{}
+				}
+				if to := tx.To(); to != nil {
+					addrs[*to] = struct{}{}
+				}
+			}
+		}
+	}
+	c[msg] = addrs
+	return addrs
+}
+
+// apply returns the part of bm to send to the client, bm itself if nothing
+// is filtered, or nil if nothing is left to send.
+func (f *ClientFilter) apply(bm *m.BroadcastMessage, cache addressCache) *m.BroadcastMessage {
+	if f.IsEmpty() {
+		return bm
+	}
+	filtered := &m.BroadcastMessage{
+		Version:                        bm.Version,
+		ConfirmedSequenceNumberMessage: bm.ConfirmedSequenceNumberMessage,
+	}
+	if !f.ConfirmationsOnly {
+		for _, msg := range bm.Messages {
+			if f.Addresses != nil && !touchesAny(cache.addresses(msg, f.chainId), f.Addresses) {
+				continue
+			}
+			if f.ExcludeBlockMetadata || f.ExcludeSignature {
+				msgCopy := *msg
+				if f.ExcludeBlockMetadata {
+					msgCopy.BlockMetadata = nil
+				}
+				if f.ExcludeSignature {
+					msgCopy.Signature = nil
+				}
+				msg = &msgCopy
+			}
+			filtered.Messages = append(filtered.Messages, msg)
+		}
+	}
+	if len(filtered.Messages) == 0 && filtered.ConfirmedSequenceNumberMessage == nil {
+		return nil
+	}
+	return filtered
+}
+
+func touchesAny(touched map[common.Address]struct{}, addrs map[common.Address]struct{}) bool {
+	for addr := range touched {
+		if _, ok := addrs[addr]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+// Copyright 2025, Offchain Labs, Inc.
+// For license information, see https://github.com/OffchainLabs/nitro/blob/master/LICENSE.md
+
+package wsbroadcastserver
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/offchainlabs/nitro/arbos"
+	"github.com/offchainlabs/nitro/arbos/arbostypes"
+	"github.com/offchainlabs/nitro/arbutil"
+	m "github.com/offchainlabs/nitro/broadcaster/message"
+)
+
+func TestClientFilterParseHeader(t *testing.T) {
+	filter := NewClientFilter(1)
+	Expect(t, filter.IsEmpty())
+
+	isFilter, err := filter.ParseHeader(HTTPHeaderRequestedSequenceNumber, "5")
+	Require(t, err)
+	Expect(t, !isFilter)
+
+	isFilter, err = filter.ParseHeader(HTTPHeaderFeedFilterAddresses, "0x0000000000000000000000000000000000000001, 0x0000000000000000000000000000000000000002")
+	Require(t, err)
+	Expect(t, isFilter)
+	Expect(t, len(filter.Addresses) == 2)
+
+	isFilter, err = filter.ParseHeader(HTTPHeaderFeedFilterExclude, "block-metadata,signature")
+	Require(t, err)
+	Expect(t, isFilter)
+	Expect(t, filter.ExcludeBlockMetadata && filter.ExcludeSignature)
+
+	isFilter, err = filter.ParseHeader(HTTPHeaderFeedFilterConfirmations, "true")
+	Require(t, err)
+	Expect(t, isFilter)
+	Expect(t, filter.ConfirmationsOnly)
+	Expect(t, !filter.IsEmpty())
+
+	for header, value := range map[string]string{
+		HTTPHeaderFeedFilterAddresses:     "0x1234",
+		HTTPHeaderFeedFilterExclude:       "block-hash",
+		HTTPHeaderFeedFilterConfirmations: "maybe",
+	} {
+		isFilter, err = NewClientFilter(1).ParseHeader(header, value)
+		Expect(t, isFilter)
+		if err == nil {
+			Fail(t, "expected error parsing header", header, "with value", value)
+		}
+	}
+}
+
+func TestClientFilterApply(t *testing.T) {
+	chainId := big.NewInt(412346)
+	key, err := crypto.GenerateKey()
+	Require(t, err)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0x0000000000000000000000000000000000000abc")
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(chainId), &types.DynamicFeeTx{
+		ChainID:   chainId,
+		Gas:       21000,
+		GasFeeCap: big.NewInt(1),
+		To:        &to,
+	})
+	Require(t, err)
+	txBytes, err := tx.MarshalBinary()
+	Require(t, err)
+
+	newFeedMessage := func(seqNum arbutil.MessageIndex, l2Msg []byte) *m.BroadcastFeedMessage {
+		return &m.BroadcastFeedMessage{
+			SequenceNumber: seqNum,
+			Message: arbostypes.MessageWithMetadata{
+				Message: &arbostypes.L1IncomingMessage{
+					Header: &arbostypes.L1IncomingMessageHeader{
+						Kind:      arbostypes.L1MessageType_L2Message,
+						L1BaseFee: big.NewInt(0),
+					},
+					L2msg: l2Msg,
+				},
+			},
+			Signature:     []byte{1},
+			BlockMetadata: []byte{2},
+		}
+	}
+	bm := &m.BroadcastMessage{
+		Version: 1,
+		Messages: []*m.BroadcastFeedMessage{
+			newFeedMessage(1, append([]byte{arbos.L2MessageKind_SignedTx}, txBytes...)),
+			newFeedMessage(2, []byte{arbos.L2MessageKind_Heartbeat}),
+		},
+	}
+	cache := addressCache{}
+
+	var filter *ClientFilter
+	Expect(t, filter.apply(bm, cache) == bm)
+
+	for _, addr := range []common.Address{sender, to} {
+		filter = NewClientFilter(chainId.Uint64())
+		filter.Addresses = map[common.Address]struct{}{addr: {}}
+		filtered := filter.apply(bm, cache)
+		Expect(t, filtered != nil && len(filtered.Messages) == 1 && filtered.Messages[0].SequenceNumber == 1)
+	}
+	filter.Addresses = map[common.Address]struct{}{{}: {}}
+	Expect(t, filter.apply(bm, cache) == nil)
+
+	filter = NewClientFilter(chainId.Uint64())
+	filter.ExcludeBlockMetadata = true
+	filtered := filter.apply(bm, cache)
+	Expect(t, filtered != nil && len(filtered.Messages) == 2)
+	for i, msg := range filtered.Messages {
+		Expect(t, msg.BlockMetadata == nil && msg.Signature != nil)
+		Expect(t, bm.Messages[i].BlockMetadata != nil, "original message was modified")
+	}
+
+	filter = NewClientFilter(chainId.Uint64())
+	filter.ConfirmationsOnly = true
+	Expect(t, filter.apply(bm, cache) == nil)
+	confirmation := &m.BroadcastMessage{
+		Version:                        1,
+		Messages:                       bm.Messages,
+		ConfirmedSequenceNumberMessage: &m.ConfirmedSequenceNumberMessage{SequenceNumber: 1},
+	}
+	filtered = filter.apply(confirmation, cache)
+	Expect(t, filtered != nil && len(filtered.Messages) == 0 && filtered.ConfirmedSequenceNumberMessage.SequenceNumber == 1)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/textproto"
@@ -26,6 +27,7 @@ import (
 	"github.com/ethereum/go-ethereum/metrics"
 
 	"github.com/offchainlabs/nitro/arbutil"
+	"github.com/offchainlabs/nitro/broadcaster/archive"
 	"github.com/offchainlabs/nitro/broadcaster/backlog"
 	m "github.com/offchainlabs/nitro/broadcaster/message"
 )
@@ -36,6 +38,10 @@ var (
 	HTTPHeaderFeedClientVersion       = textproto.CanonicalMIMEHeaderKey("Arbitrum-Feed-Client-Version")
 	HTTPHeaderRequestedSequenceNumber = textproto.CanonicalMIMEHeaderKey("Arbitrum-Requested-Sequence-Number")
 	HTTPHeaderChainId                 = textproto.CanonicalMIMEHeaderKey("Arbitrum-Chain-Id")
+	HTTPHeaderFeedEncoding            = textproto.CanonicalMIMEHeaderKey("Arbitrum-Feed-Encoding")
+	HTTPHeaderFeedFilterAddresses     = textproto.CanonicalMIMEHeaderKey("Arbitrum-Feed-Filter-Addresses")
+	HTTPHeaderFeedFilterConfirmations = textproto.CanonicalMIMEHeaderKey("Arbitrum-Feed-Filter-Confirmations-Only")
+	HTTPHeaderFeedFilterExclude       = textproto.CanonicalMIMEHeaderKey("Arbitrum-Feed-Filter-Exclude")
 	upgradeToWSTimer                  = metrics.NewRegisteredHistogram("arb/feed/clients/upgrade/duration", nil, metrics.NewBoundedHistogramSample())
 	startWithHeaderTimer              = metrics.NewRegisteredHistogram("arb/feed/clients/start/duration", nil, metrics.NewBoundedHistogramSample())
 )
@@ -64,6 +70,9 @@ type BroadcasterConfig struct {
 	LogDisconnect      bool                    `koanf:"log-disconnect"`
 	EnableCompression  bool                    `koanf:"enable-compression" reload:"hot"`  // if reloaded to false will cause disconnection of clients with enabled compression on next broadcast
 	RequireCompression bool                    `koanf:"require-compression" reload:"hot"` // if reloaded to true will cause disconnection of clients with disabled compression on next broadcast
+	EnableBinary       bool                    `koanf:"enable-binary" reload:"hot"`       // reloaded value will affect only future upgrades to websocket
+	EnableFilters      bool                    `koanf:"enable-filters" reload:"hot"`      // reloaded value will affect only future upgrades to websocket
+	ServeHistory       bool                    `koanf:"serve-history" reload:"hot"`       // reloaded value will affect only future upgrades to websocket
 	LimitCatchup       bool                    `koanf:"limit-catchup" reload:"hot"`
 	MaxCatchup         int                     `koanf:"max-catchup" reload:"hot"`
 	ConnectionLimits   ConnectionLimiterConfig `koanf:"connection-limits" reload:"hot"`
@@ -98,6 +107,9 @@ func BroadcasterConfigAddOptions(prefix string, f *pflag.FlagSet) {
 	f.Bool(prefix+".log-disconnect", DefaultBroadcasterConfig.LogDisconnect, "log every client disconnect")
 	f.Bool(prefix+".enable-compression", DefaultBroadcasterConfig.EnableCompression, "enable per message deflate compression support")
 	f.Bool(prefix+".require-compression", DefaultBroadcasterConfig.RequireCompression, "require clients to use compression")
+	f.Bool(prefix+".enable-binary", DefaultBroadcasterConfig.EnableBinary, "allow clients to request the compact binary (RLP) encoding of feed messages instead of JSON")
+	f.Bool(prefix+".enable-filters", DefaultBroadcasterConfig.EnableFilters, "allow clients to filter the feed messages they receive by address, to only receive confirmations, or to exclude block metadata and signatures")
+	f.Bool(prefix+".serve-history", DefaultBroadcasterConfig.ServeHistory, "catch up clients requesting messages older than the backlog from the message history (the node's database, or the relay's archive), up to max-catchup messages if limit-catchup is set")
 	f.Bool(prefix+".limit-catchup", DefaultBroadcasterConfig.LimitCatchup, "only supply catchup buffer if requested sequence number is reasonable")
 	f.Int(prefix+".max-catchup", DefaultBroadcasterConfig.MaxCatchup, "the maximum size of the catchup buffer (-1 means unlimited)")
 	ConnectionLimiterConfigAddOptions(prefix+".connection-limits", f)
@@ -123,6 +135,9 @@ var DefaultBroadcasterConfig = BroadcasterConfig{
 	LogDisconnect:      false,
 	EnableCompression:  false,
 	RequireCompression: false,
+	EnableBinary:       false,
+	EnableFilters:      false,
+	ServeHistory:       false,
 	LimitCatchup:       false,
 	MaxCatchup:         -1,
 	ConnectionLimits:   DefaultConnectionLimiterConfig,
@@ -148,6 +163,9 @@ var DefaultTestBroadcasterConfig = BroadcasterConfig{
 	LogDisconnect:      false,
 	EnableCompression:  true,
 	RequireCompression: false,
+	EnableBinary:       false,
+	EnableFilters:      false,
+	ServeHistory:       false,
 	LimitCatchup:       false,
 	MaxCatchup:         -1,
 	ConnectionLimits:   DefaultConnectionLimiterConfig,
@@ -167,6 +185,7 @@ type WSBroadcastServer struct {
 	started       bool
 	clientManager *ClientManager
 	backlog       backlog.Backlog
+	history       archive.Source
 	chainId       uint64
 	fatalErrChan  chan error
 }
@@ -181,6 +200,12 @@ func NewWSBroadcastServer(config BroadcasterConfigFetcher, bklg backlog.Backlog,
 	}
 }
 
+// SetHistory sets the source of the messages older than the backlog, used to
+// catch up clients if serve-history is enabled. It must be called before Start.
+func (s *WSBroadcastServer) SetHistory(history archive.Source) {
+	s.history = history
+}
+
 func (s *WSBroadcastServer) Initialize() error {
 	if s.poller != nil {
 		return errors.New("broadcast server already initialized")
@@ -253,8 +278,11 @@ func (s *WSBroadcastServer) StartWithHeader(ctx context.Context, header ws.Hands
 			negotiate = compress.Negotiate
 		}
 		var feedClientVersionSeen bool
+		var binaryRequested bool
+		filter := NewClientFilter(s.chainId)
 		var connectingIP net.IP
 		var requestedSeqNum arbutil.MessageIndex
+		var seqNumRequested bool
 		upgrader := ws.Upgrader{
 			OnRequest: func(uri []byte) error {
 				if strings.Contains(string(uri), LivenessProbeURI) {
@@ -290,6 +318,23 @@ func (s *WSBroadcastServer) StartWithHeader(ctx context.Context, header ws.Hands
 						)
 					}
 					requestedSeqNum = arbutil.MessageIndex(num)
+					seqNumRequested = true
+				} else if headerName == HTTPHeaderFeedEncoding {
+					// Unknown encodings fall back to JSON
+					binaryRequested = string(value) == m.EncodingRLP
+				} else if isFilter, err := filter.ParseHeader(headerName, string(value)); isFilter {
+					if !config.EnableFilters {
+						return ws.RejectConnectionError(
+							ws.RejectionStatus(http.StatusBadRequest),
+							ws.RejectionReason("Feed filters are not enabled"),
+						)
+					}
+					if err != nil {
+						return ws.RejectConnectionError(
+							ws.RejectionStatus(http.StatusBadRequest),
+							ws.RejectionReason(fmt.Sprintf("Malformed HTTP header %s: %v", headerName, err)),
+						)
+					}
 				} else if headerName == HTTPHeaderCloudflareConnectingIP {
 					connectingIP = net.ParseIP(string(value))
 					log.Trace("Client IP parsed from header", "ip", connectingIP, "header", headerName, "value", string(value))
@@ -320,6 +365,9 @@ func (s *WSBroadcastServer) StartWithHeader(ctx context.Context, header ws.Hands
 					)
 				}
 
+				if binaryRequested && config.EnableBinary {
+					return handshakeHeaders{header, binaryHeader}, nil
+				}
 				return header, nil
 			},
 			Negotiate: negotiate,
@@ -375,7 +423,19 @@ func (s *WSBroadcastServer) StartWithHeader(ctx context.Context, header ws.Hands
 		// Register incoming client in clientManager.
 		safeConn := writeDeadliner{conn, config.WriteTimeout}
 
-		client := NewClientConnection(safeConn, desc, s.clientManager.clientAction, requestedSeqNum, connectingIP, compressionAccepted, s.config().MaxSendQueue, s.config().ClientDelay, s.backlog)
+		binary := binaryRequested && config.EnableBinary
+		if filter.IsEmpty() {
+			filter = nil
+		}
+		var history archive.Source
+		if seqNumRequested && config.ServeHistory {
+			history = s.history
+		}
+		maxHistoryCatchup := -1
+		if config.LimitCatchup {
+			maxHistoryCatchup = config.MaxCatchup
+		}
+		client := NewClientConnection(safeConn, desc, s.clientManager.clientAction, requestedSeqNum, connectingIP, compressionAccepted, binary, filter, s.config().MaxSendQueue, s.config().ClientDelay, s.backlog, history, maxHistoryCatchup)
 		client.Start(ctx)
 
 		// Subscribe to events about conn.
@@ -540,6 +600,25 @@ func (s *WSBroadcastServer) ClientCount() int32 {
 	return s.clientManager.ClientCount()
 }
 
+var binaryHeader = ws.HandshakeHeaderHTTP(http.Header{
+	HTTPHeaderFeedEncoding: []string{m.EncodingRLP},
+})
+
+// handshakeHeaders writes multiple handshake headers in order.
+type handshakeHeaders []ws.HandshakeHeader
+
+func (h handshakeHeaders) WriteTo(w io.Writer) (int64, error) {
+	var total int64
+	for _, header := range h {
+		n, err := header.WriteTo(w)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
 // writeDeadliner is a wrapper around net.Conn that sets write deadlines before
 // every Write() call.
 type writeDeadliner struct {